@@ -19,11 +19,21 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+	"github.com/google/uuid"
 )
 
 // JSON file for persisting reports (backup storage)
 const reportsBackupFile = "data/reports_backup.json"
 
+// Optional JSON list of patch releases used to explain trend changes
+const patchCalendarFile = "data/patches.json"
+
+// Optional learned VALORANT site geometry, written by scripts/calibrate_sites.go
+const siteGeometryFile = "data/site_geometry.json"
+
+// Draft assistant sessions are dropped this long after their last update
+const draftSessionTTL = 6 * time.Hour
+
 // splitAndTrim splits a string by separator and trims whitespace from each part
 func splitAndTrim(s, sep string) []string {
 	parts := strings.Split(s, sep)
@@ -45,9 +55,13 @@ type Server struct {
 	headToHeadAnalyzer *intelligence.HeadToHeadAnalyzer // Task 10: Added head-to-head analyzer
 	lolAnalyzer        *intelligence.LoLAnalyzer        // For team analysis in matchups
 	valAnalyzer        *intelligence.VALAnalyzer        // For team analysis in matchups
+	draftAssistant     *intelligence.DraftAssistant
 	// In-memory report storage with JSON file backup
 	reports     map[string]*intelligence.ScoutingReport
 	reportsLock sync.RWMutex
+	// Live drafts for the draft assistant
+	draftSessions     map[string]*draftSession
+	draftSessionsLock sync.RWMutex
 }
 
 // saveReportsToFile persists reports to JSON file as backup
@@ -98,6 +112,42 @@ func (s *Server) loadReportsFromFile() {
 	log.Printf("Loaded %d reports from backup file", len(s.reports))
 }
 
+// loadPatchCalendar loads patch release dates for trend explanations, if present
+func (s *Server) loadPatchCalendar() {
+	data, err := os.ReadFile(patchCalendarFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Warning: Failed to read patch calendar: %v", err)
+		}
+		return
+	}
+
+	var patches []intelligence.PatchRelease
+	if err := json.Unmarshal(data, &patches); err != nil {
+		log.Printf("Warning: Failed to parse patch calendar: %v", err)
+		return
+	}
+
+	s.reportGenerator.SetPatchCalendar(patches)
+	log.Printf("Loaded %d patch releases", len(patches))
+}
+
+// loadSiteGeometry installs the learned site geometry for site classification, if present
+func (s *Server) loadSiteGeometry() {
+	if _, err := os.Stat(siteGeometryFile); os.IsNotExist(err) {
+		return
+	}
+
+	geometry, err := grid.LoadSiteGeometry(siteGeometryFile)
+	if err != nil {
+		log.Printf("Warning: Failed to load site geometry: %v", err)
+		return
+	}
+
+	grid.SetSiteGeometry(geometry)
+	log.Printf("Loaded site geometry revision %d for %d maps", geometry.Revision, len(geometry.Maps))
+}
+
 // NewRouter creates a new API router
 func NewRouter(gridClient *grid.Client, llmService llm.Service, cacheClient *cache.RedisCache) http.Handler {
 	s := &Server{
@@ -108,11 +158,15 @@ func NewRouter(gridClient *grid.Client, llmService llm.Service, cacheClient *cac
 		headToHeadAnalyzer: intelligence.NewHeadToHeadAnalyzer(gridClient), // Task 10: Initialize head-to-head analyzer
 		lolAnalyzer:        intelligence.NewLoLAnalyzer(),                  // For matchup team analysis
 		valAnalyzer:        intelligence.NewVALAnalyzer(),                  // For matchup team analysis
+		draftAssistant:     intelligence.NewDraftAssistant(),
 		reports:            make(map[string]*intelligence.ScoutingReport),
+		draftSessions:      make(map[string]*draftSession),
 	}
 
 	// Load any previously saved reports from backup file
 	s.loadReportsFromFile()
+	s.loadPatchCalendar()
+	s.loadSiteGeometry()
 
 	r := chi.NewRouter()
 
@@ -166,6 +220,7 @@ func NewRouter(gridClient *grid.Client, llmService llm.Service, cacheClient *cac
 		r.Get("/teams/search", s.searchTeams)
 		r.Get("/teams/{teamId}", s.getTeamByID)
 		r.Get("/teams/{teamId}/series", s.getSeriesForTeam)
+		r.Get("/teams/{teamId}/heatmaps", s.getTeamHeatmaps)
 
 		// Report endpoints
 		r.Post("/reports/generate", s.generateReport)
@@ -174,7 +229,13 @@ func NewRouter(gridClient *grid.Client, llmService llm.Service, cacheClient *cac
 
 		// Analysis endpoints
 		r.Get("/matchup", s.getMatchup)
+		r.Get("/matchup/veto", s.getMatchupVeto)
 		r.Get("/series/{seriesId}/state", s.getSeriesState)
+
+		// Draft assistant (LoL)
+		r.Post("/draft/sessions", s.createDraftSession)
+		r.Get("/draft/sessions/{sessionId}", s.getDraftSession)
+		r.Post("/draft/sessions/{sessionId}/turns", s.updateDraftSession)
 	})
 
 	return r
@@ -290,6 +351,51 @@ func (s *Server) getSeriesForTeam(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, series)
 }
 
+// getTeamHeatmaps returns a VALORANT team's position heatmaps on a map,
+// as JSON or, with format=svg, one layer rendered as an SVG overlay
+func (s *Server) getTeamHeatmaps(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := report.HeatmapRequest{
+		TeamID:  chi.URLParam(r, "teamId"),
+		MapName: query.Get("map"),
+		Filter: intelligence.HeatmapFilter{
+			Side:      query.Get("side"),      // "attack" or "defense"
+			RoundType: query.Get("roundType"), // "pistol", "eco", "force", "full"
+			Player:    query.Get("player"),
+			Weapon:    query.Get("weapon"),
+		},
+	}
+	if l := query.Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 50 {
+			req.SeriesCount = parsed
+		}
+	}
+
+	heatmaps, err := s.reportGenerator.GenerateHeatmaps(r.Context(), req)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to build heatmaps: "+err.Error())
+		return
+	}
+
+	if query.Get("format") != "svg" {
+		respondJSON(w, http.StatusOK, heatmaps)
+		return
+	}
+
+	layer := query.Get("layer")
+	if layer == "" {
+		layer = intelligence.HeatmapKills
+	}
+	svg, err := report.RenderHeatmapSVG(heatmaps, layer)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(svg))
+}
+
 // getSeriesState returns detailed state for a series
 func (s *Server) getSeriesState(w http.ResponseWriter, r *http.Request) {
 	seriesID := chi.URLParam(r, "seriesId")
@@ -308,6 +414,9 @@ type GenerateReportRequest struct {
 	TeamName   string `json:"teamName,omitempty"`
 	MatchCount int    `json:"matchCount"`
 	TitleID    string `json:"titleId"` // "3" for LoL, "6" for VALORANT
+	// AsOf builds the report from series played before this time (RFC3339).
+	// Reports with the same inputs and asOf are identical.
+	AsOf *time.Time `json:"asOf,omitempty"`
 }
 
 // generateReport generates a scouting report
@@ -338,6 +447,9 @@ func (s *Server) generateReport(w http.ResponseWriter, r *http.Request) {
 		TitleID:    req.TitleID,
 		MatchCount: req.MatchCount,
 	}
+	if req.AsOf != nil {
+		genReq.AsOf = *req.AsOf
+	}
 
 	scoutReport, err := s.reportGenerator.GenerateReport(r.Context(), genReq)
 	if err != nil {
@@ -414,6 +526,11 @@ func (s *Server) getMatchup(w http.ResponseWriter, r *http.Request) {
 		title = "valorant"
 	}
 
+	format := r.URL.Query().Get("format") // "bo1", "bo3", "bo5"
+	if format == "" {
+		format = "bo3"
+	}
+
 	matchCount := 20
 	if mc := r.URL.Query().Get("matches"); mc != "" {
 		if parsed, err := strconv.Atoi(mc); err == nil && parsed > 0 {
@@ -453,6 +570,11 @@ func (s *Server) getMatchup(w http.ResponseWriter, r *http.Request) {
 		team2States, err2 := s.gridClient.GetSeriesStates(r.Context(), team2SeriesIDs)
 
 		if err1 == nil && err2 == nil {
+			// Pre-match win probability from both teams' results
+			allSeries := append(append([]grid.Series{}, team1Series...), team2Series...)
+			allStates := append(append([]*grid.SeriesState{}, team1States...), team2States...)
+			s.headToHeadAnalyzer.PredictMatchup(report, format, allSeries, allStates)
+
 			// Run analyzers to get team metrics
 			var team1Analysis, team2Analysis *intelligence.TeamAnalysis
 
@@ -518,6 +640,214 @@ func (s *Server) getMatchup(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, report)
 }
 
+// getMatchupVeto suggests team1's VALORANT veto order against team2 and predicts team2's bans and picks
+func (s *Server) getMatchupVeto(w http.ResponseWriter, r *http.Request) {
+	team1 := r.URL.Query().Get("team1")
+	team2 := r.URL.Query().Get("team2")
+	if team1 == "" || team2 == "" {
+		respondError(w, http.StatusBadRequest, "team1 and team2 parameters are required")
+		return
+	}
+
+	format := r.URL.Query().Get("format") // "bo1", "bo3", "bo5"
+	if format == "" {
+		format = "bo3"
+	}
+	weStart := r.URL.Query().Get("first") != "team2"
+
+	matchCount := 20
+	if mc := r.URL.Query().Get("matches"); mc != "" {
+		if parsed, err := strconv.Atoi(mc); err == nil && parsed > 0 {
+			matchCount = parsed
+		}
+	}
+
+	analyses := make([]*intelligence.TeamAnalysis, 0, 2)
+	for _, teamID := range []string{team1, team2} {
+		teamName := teamID
+		if team, err := s.gridClient.GetTeamByID(r.Context(), teamID); err == nil && team != nil {
+			teamName = team.Name
+		}
+
+		states, err := s.gridClient.GetMatchDataForTeam(r.Context(), teamID, matchCount)
+		if err != nil {
+			respondError(w, http.StatusBadGateway, "Failed to fetch data from GRID API: "+err.Error())
+			return
+		}
+
+		analysis, err := s.valAnalyzer.AnalyzeTeam(r.Context(), teamID, teamName, states, nil)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to analyze team: "+err.Error())
+			return
+		}
+		analyses = append(analyses, analysis)
+	}
+
+	respondJSON(w, http.StatusOK, s.valAnalyzer.PredictVeto(analyses[0], analyses[1], format, weStart))
+}
+
+// draftSession is a live LoL draft. The team profiles are built once when the
+// session starts so each turn is answered without refetching data.
+type draftSession struct {
+	ID        string                         `json:"id"`
+	OurSide   string                         `json:"ourSide"`
+	Ours      *intelligence.DraftTeamProfile `json:"ours"`
+	Theirs    *intelligence.DraftTeamProfile `json:"theirs"`
+	Turns     []intelligence.DraftTurn       `json:"turns"`
+	Advice    *intelligence.DraftAdvice      `json:"advice"`
+	UpdatedAt time.Time                      `json:"updatedAt"`
+}
+
+// CreateDraftSessionRequest is the request body for starting a draft assistant session
+type CreateDraftSessionRequest struct {
+	TeamID     string                   `json:"teamId"`     // Our team
+	OpponentID string                   `json:"opponentId"` // The team we draft against
+	Side       string                   `json:"side"`       // Our side: "blue" or "red"
+	MatchCount int                      `json:"matchCount"`
+	Turns      []intelligence.DraftTurn `json:"turns,omitempty"` // Draft so far, if already started
+}
+
+// DraftTurnsRequest is the request body for posting the draft so far
+type DraftTurnsRequest struct {
+	Turns []intelligence.DraftTurn `json:"turns"`
+}
+
+// createDraftSession builds both teams' draft profiles and returns advice for the next turn
+func (s *Server) createDraftSession(w http.ResponseWriter, r *http.Request) {
+	var req CreateDraftSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.TeamID == "" || req.OpponentID == "" {
+		respondError(w, http.StatusBadRequest, "teamId and opponentId are required")
+		return
+	}
+	if req.MatchCount <= 0 {
+		req.MatchCount = 10
+	}
+
+	profiles := make([]*intelligence.DraftTeamProfile, 0, 2)
+	for _, teamID := range []string{req.TeamID, req.OpponentID} {
+		teamName := teamID
+		if team, err := s.gridClient.GetTeamByID(r.Context(), teamID); err == nil && team != nil {
+			teamName = team.Name
+		}
+
+		states, err := s.gridClient.GetMatchDataForTeam(r.Context(), teamID, req.MatchCount)
+		if err != nil {
+			respondError(w, http.StatusBadGateway, "Failed to fetch data from GRID API: "+err.Error())
+			return
+		}
+
+		players, err := s.lolAnalyzer.AnalyzePlayers(r.Context(), teamID, states)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to analyze players: "+err.Error())
+			return
+		}
+		profiles = append(profiles, s.draftAssistant.BuildDraftProfile(teamID, teamName, players, states))
+	}
+
+	session := &draftSession{
+		ID:      uuid.New().String(),
+		OurSide: strings.ToLower(req.Side),
+		Ours:    profiles[0],
+		Theirs:  profiles[1],
+		Turns:   req.Turns,
+	}
+	if session.Turns == nil {
+		session.Turns = []intelligence.DraftTurn{}
+	}
+	advice, err := s.draftAssistant.Advise(session.Ours, session.Theirs, session.OurSide, session.Turns)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	session.Advice = advice
+	session.UpdatedAt = time.Now()
+
+	s.draftSessionsLock.Lock()
+	for id, old := range s.draftSessions {
+		if time.Since(old.UpdatedAt) > draftSessionTTL {
+			delete(s.draftSessions, id)
+		}
+	}
+	s.draftSessions[session.ID] = session
+	s.draftSessionsLock.Unlock()
+
+	respondJSON(w, http.StatusOK, session)
+}
+
+// getDraftSession returns a draft session with its latest advice
+func (s *Server) getDraftSession(w http.ResponseWriter, r *http.Request) {
+	sessionID := chi.URLParam(r, "sessionId")
+
+	s.draftSessionsLock.Lock()
+	session := s.liveDraftSession(sessionID)
+	s.draftSessionsLock.Unlock()
+
+	if session == nil {
+		respondError(w, http.StatusNotFound, "Draft session not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, session)
+}
+
+// liveDraftSession returns a draft session that has not expired, removing it once
+// it has. The caller must hold draftSessionsLock for writing.
+func (s *Server) liveDraftSession(sessionID string) *draftSession {
+	session, exists := s.draftSessions[sessionID]
+	if !exists {
+		return nil
+	}
+	if time.Since(session.UpdatedAt) > draftSessionTTL {
+		delete(s.draftSessions, sessionID)
+		return nil
+	}
+	return session
+}
+
+// updateDraftSession replaces the draft so far and returns advice for the next turn
+func (s *Server) updateDraftSession(w http.ResponseWriter, r *http.Request) {
+	sessionID := chi.URLParam(r, "sessionId")
+
+	var req DraftTurnsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Lookup, advice and write happen under one lock so concurrent turn posts
+	// cannot overwrite each other or bring back an expired session
+	s.draftSessionsLock.Lock()
+	session := s.liveDraftSession(sessionID)
+	if session == nil {
+		s.draftSessionsLock.Unlock()
+		respondError(w, http.StatusNotFound, "Draft session not found")
+		return
+	}
+
+	advice, err := s.draftAssistant.Advise(session.Ours, session.Theirs, session.OurSide, req.Turns)
+	if err != nil {
+		s.draftSessionsLock.Unlock()
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated := *session
+	updated.Turns = req.Turns
+	updated.Advice = advice
+	updated.UpdatedAt = time.Now()
+	if updated.Turns == nil {
+		updated.Turns = []intelligence.DraftTurn{}
+	}
+	s.draftSessions[sessionID] = &updated
+	s.draftSessionsLock.Unlock()
+
+	respondJSON(w, http.StatusOK, &updated)
+}
+
 // calculateEnhancedConfidence calculates confidence score with style comparison data
 func (s *Server) calculateEnhancedConfidence(report *intelligence.HeadToHeadReport, team1Analysis, team2Analysis *intelligence.TeamAnalysis) float64 {
 	confidence := 50.0 // Base confidence
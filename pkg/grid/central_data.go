@@ -213,11 +213,24 @@ func (c *Client) GetTeams(ctx context.Context, tournamentID string) ([]Team, err
 // GetSeriesForTeam fetches recent series for a specific team
 // Uses GRID API's native teamId filter for direct team filtering
 func (c *Client) GetSeriesForTeam(ctx context.Context, teamID string, limit int) ([]Series, error) {
+	return c.GetSeriesForTeamBefore(ctx, teamID, time.Time{}, limit)
+}
+
+// GetSeriesForTeamBefore fetches the most recent series for a team that were
+// scheduled to start before the given time. A zero time means no cutoff.
+func (c *Client) GetSeriesForTeamBefore(ctx context.Context, teamID string, before time.Time, limit int) ([]Series, error) {
 	if limit <= 0 {
 		limit = 10
 	}
 
 	cacheKey := fmt.Sprintf("series:team:%s:limit:%d", teamID, limit)
+	seriesFilter := "{ teamId: $teamId }"
+	queryVars := "$teamId: ID!, $limit: Int!"
+	if !before.IsZero() {
+		cacheKey = fmt.Sprintf("%s:before:%d", cacheKey, before.Unix())
+		seriesFilter = "{ teamId: $teamId, startTimeScheduled: { lte: $before } }"
+		queryVars += ", $before: String"
+	}
 
 	// Check cache
 	var series []Series
@@ -228,9 +241,9 @@ func (c *Client) GetSeriesForTeam(ctx context.Context, teamID string, limit int)
 	// Query series directly using GRID API's teamId filter
 	// This is the proper approach - no hardcoded tournament IDs needed
 	req := graphql.NewRequest(`
-		query SeriesForTeam($teamId: ID!, $limit: Int!) {
+		query SeriesForTeam(` + queryVars + `) {
 			allSeries(
-				filter: { teamId: $teamId }
+				filter: ` + seriesFilter + `
 				orderBy: StartTimeScheduled
 				orderDirection: DESC
 				first: $limit
@@ -265,6 +278,9 @@ func (c *Client) GetSeriesForTeam(ctx context.Context, teamID string, limit int)
 	`)
 	req.Var("teamId", teamID)
 	req.Var("limit", limit)
+	if !before.IsZero() {
+		req.Var("before", before.UTC().Format(time.RFC3339))
+	}
 
 	var resp struct {
 		AllSeries struct {
@@ -307,14 +323,19 @@ func (c *Client) GetSeriesForTeam(ctx context.Context, teamID string, limit int)
 	series = make([]Series, 0, len(resp.AllSeries.Edges))
 	for _, edge := range resp.AllSeries.Edges {
 		s := Series{
-			ID:           edge.Node.ID,
-			TournamentID: edge.Node.Tournament.ID,
-			Format:       edge.Node.Format.NameShortened,
-			TitleID:      edge.Node.Title.ID,
+			ID:             edge.Node.ID,
+			TournamentID:   edge.Node.Tournament.ID,
+			TournamentName: edge.Node.Tournament.Name,
+			Format:         edge.Node.Format.NameShortened,
+			TitleID:        edge.Node.Title.ID,
 		}
 		if edge.Node.StartTimeScheduled != "" {
 			s.StartTime, _ = time.Parse(time.RFC3339, edge.Node.StartTimeScheduled)
 		}
+		// lte is inclusive and unparseable times can't be placed, so drop both
+		if !before.IsZero() && (s.StartTime.IsZero() || !s.StartTime.Before(before)) {
+			continue
+		}
 		for _, t := range edge.Node.Teams {
 			s.Teams = append(s.Teams, Team{
 				ID:      t.BaseInfo.ID,
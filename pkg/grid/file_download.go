@@ -4,10 +4,14 @@ import (
 	"archive/zip"
 	"bufio"
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 )
@@ -182,15 +186,17 @@ func (c *Client) DownloadEndState(ctx context.Context, seriesID string) (*Series
 
 // LoLEventData contains parsed LoL events with rich data
 type LoLEventData struct {
-	DragonKills    []DragonKillEvent
-	BaronKills     []ObjectiveKillEvent
-	HeraldKills    []ObjectiveKillEvent
-	VoidGrubKills  []ObjectiveKillEvent
-	TowerDestroys  []TowerDestroyEvent
-	Kills          []KillEvent
-	DraftActions   []DraftAction
-	FirstBloodTime time.Time
-	GameStartTime  time.Time
+	DragonKills     []DragonKillEvent
+	BaronKills      []ObjectiveKillEvent
+	HeraldKills     []ObjectiveKillEvent
+	VoidGrubKills   []ObjectiveKillEvent
+	TowerDestroys   []TowerDestroyEvent
+	Kills           []KillEvent
+	DraftActions    []DraftAction
+	GameStates      []GameStateSample      // per-minute team net worth and kills, from state snapshots
+	PlayerPositions []PlayerPositionSample // player positions in the laning phase, from state snapshots
+	FirstBloodTime  time.Time
+	GameStartTime   time.Time
 }
 
 // VALEventData contains parsed VALORANT events with rich data
@@ -199,19 +205,22 @@ type VALEventData struct {
 	Plants    []PlantEvent
 	Defuses   []DefuseEvent
 	Kills     []VALKillEvent
+	Economy   []RoundEconomy // per-round buy of each team, from state snapshots
 	MapName   string
 }
 
 // ParseLoLEvents extracts LoL-specific events from EventWrappers
 func ParseLoLEvents(wrappers []EventWrapper) (*LoLEventData, error) {
 	data := &LoLEventData{
-		DragonKills:   make([]DragonKillEvent, 0),
-		BaronKills:    make([]ObjectiveKillEvent, 0),
-		HeraldKills:   make([]ObjectiveKillEvent, 0),
-		VoidGrubKills: make([]ObjectiveKillEvent, 0),
-		TowerDestroys: make([]TowerDestroyEvent, 0),
-		Kills:         make([]KillEvent, 0),
-		DraftActions:  make([]DraftAction, 0),
+		DragonKills:     make([]DragonKillEvent, 0),
+		BaronKills:      make([]ObjectiveKillEvent, 0),
+		HeraldKills:     make([]ObjectiveKillEvent, 0),
+		VoidGrubKills:   make([]ObjectiveKillEvent, 0),
+		TowerDestroys:   make([]TowerDestroyEvent, 0),
+		Kills:           make([]KillEvent, 0),
+		DraftActions:    make([]DraftAction, 0),
+		GameStates:      make([]GameStateSample, 0),
+		PlayerPositions: make([]PlayerPositionSample, 0),
 	}
 
 	// Regex for parsing tower IDs like "red-turret-mid-2"
@@ -240,33 +249,29 @@ func ParseLoLEvents(wrappers []EventWrapper) (*LoLEventData, error) {
 		}
 	}
 
-	// Helper to extract in-game clock time from seriesState (more accurate than wall-clock diff)
-	// Returns time in milliseconds
-	getGameClockTime := func(event GridEvent) int {
-		if event.SeriesState == nil {
-			return 0
-		}
-		// Navigate: seriesState.games[0].clock.currentSeconds
-		if games, ok := event.SeriesState["games"].([]interface{}); ok && len(games) > 0 {
-			if game, ok := games[0].(map[string]interface{}); ok {
-				if clock, ok := game["clock"].(map[string]interface{}); ok {
-					if currentSeconds, ok := clock["currentSeconds"].(float64); ok {
-						return int(currentSeconds * 1000) // Convert to milliseconds
-					}
-				}
-			}
-		}
-		return 0
-	}
+	// The game in progress, and when each game started
+	currentGameID := ""
+	gameStarts := make(map[string]time.Time)
 
-	// Fallback: calculate game time from wall-clock difference
+	// Fallback: calculate game time from wall-clock difference to the start of
+	// the game in progress
 	calcGameTimeFromWallClock := func(eventTime time.Time) int {
-		if gameStartTime.IsZero() {
+		start := gameStartTime
+		if s, ok := gameStarts[currentGameID]; ok {
+			start = s
+		}
+		if start.IsZero() {
 			return 0
 		}
-		return int(eventTime.Sub(gameStartTime).Milliseconds())
+		return int(eventTime.Sub(start).Milliseconds())
 	}
 
+	// Net worth and kill totals of each game, sampled on minute boundaries
+	timelines := make(map[string]*gameTimeline)
+
+	// Each player's last recorded position, per game
+	lastPositions := make(map[string]PlayerPositionSample)
+
 	for _, wrapper := range wrappers {
 		for _, event := range wrapper.Events {
 			actorType := ""
@@ -278,16 +283,39 @@ func ParseLoLEvents(wrappers []EventWrapper) (*LoLEventData, error) {
 				targetType = event.Target.Type
 			}
 
-			// Get game time - prefer in-game clock, fallback to wall-clock calculation
-			gameTime := getGameClockTime(event)
-			if gameTime == 0 {
+			if event.Action == "started" && targetType == "game" && event.Target != nil {
+				currentGameID = event.Target.ID
+				gameStarts[currentGameID] = wrapper.OccurredAt
+			}
+
+			// Get game time - prefer the in-game clock of the game in progress,
+			// fallback to wall-clock calculation
+			gameTime := gameClock(currentGame(event, currentGameID))
+			if gameTime <= 0 {
 				gameTime = calcGameTimeFromWallClock(wrapper.OccurredAt)
 			}
 
+			if gameID, clock, teams := teamStateSnapshot(event, currentGameID); gameID != "" {
+				if clock < 0 && gameID == currentGameID {
+					clock = gameTime
+				}
+				timeline := timelines[gameID]
+				if timeline == nil {
+					timeline = &gameTimeline{gameID: gameID, lastMinute: -1, totals: make(map[string]*TeamStateSample)}
+					timelines[gameID] = timeline
+				}
+				data.GameStates = timeline.update(data.GameStates, clock, teams)
+			}
+
+			if gameTime <= LaningPositionWindow {
+				data.PlayerPositions = appendPlayerPositions(data.PlayerPositions, event, currentGameID, gameTime, lastPositions)
+			}
+
 			switch {
 			// Player kills
 			case actorType == "player" && event.Action == "killed" && targetType == "player":
 				kill := parseKillEvent(event, wrapper.OccurredAt, isFirstKill)
+				kill.GameID = currentGameID
 				kill.GameTime = gameTime
 				data.Kills = append(data.Kills, kill)
 				if isFirstKill {
@@ -304,18 +332,22 @@ func ParseLoLEvents(wrappers []EventWrapper) (*LoLEventData, error) {
 				
 				if strings.Contains(targetID, "drake") || strings.Contains(targetID, "dragon") {
 					dragon := parseDragonKillEvent(event, wrapper.OccurredAt)
+					dragon.GameID = currentGameID
 					dragon.GameTime = gameTime
 					data.DragonKills = append(data.DragonKills, dragon)
 				} else if strings.Contains(targetID, "baron") || strings.Contains(targetID, "nashor") {
 					baron := parseObjectiveKillEvent(event, wrapper.OccurredAt, "baron")
+					baron.GameID = currentGameID
 					baron.GameTime = gameTime
 					data.BaronKills = append(data.BaronKills, baron)
 				} else if strings.Contains(targetID, "herald") || strings.Contains(targetID, "rift") {
 					herald := parseObjectiveKillEvent(event, wrapper.OccurredAt, "herald")
+					herald.GameID = currentGameID
 					herald.GameTime = gameTime
 					data.HeraldKills = append(data.HeraldKills, herald)
 				} else if strings.Contains(targetID, "voidgrub") || strings.Contains(targetID, "grub") {
 					grub := parseObjectiveKillEvent(event, wrapper.OccurredAt, "voidGrub")
+					grub.GameID = currentGameID
 					grub.GameTime = gameTime
 					data.VoidGrubKills = append(data.VoidGrubKills, grub)
 				}
@@ -323,16 +355,17 @@ func ParseLoLEvents(wrappers []EventWrapper) (*LoLEventData, error) {
 			// Tower destruction
 			case event.Action == "destroyed" && (targetType == "tower" || targetType == "fortifier"):
 				tower := parseTowerDestroyEvent(event, wrapper.OccurredAt, towerRegex)
+				tower.GameID = currentGameID
 				tower.GameTime = gameTime
 				data.TowerDestroys = append(data.TowerDestroys, tower)
 
 			// Draft picks and bans
 			case event.Action == "picked" && targetType == "character":
-				draft := parseDraftAction(event, wrapper.OccurredAt, "picked")
+				draft := parseDraftAction(event, wrapper.OccurredAt)
 				data.DraftActions = append(data.DraftActions, draft)
 
 			case event.Action == "banned" && targetType == "character":
-				draft := parseDraftAction(event, wrapper.OccurredAt, "banned")
+				draft := parseDraftAction(event, wrapper.OccurredAt)
 				data.DraftActions = append(data.DraftActions, draft)
 
 			// Game start
@@ -352,6 +385,7 @@ func ParseVALEvents(wrappers []EventWrapper) (*VALEventData, error) {
 		Plants:    make([]PlantEvent, 0),
 		Defuses:   make([]DefuseEvent, 0),
 		Kills:     make([]VALKillEvent, 0),
+		Economy:   make([]RoundEconomy, 0),
 	}
 
 	// Track current round, map, and round start times
@@ -359,6 +393,15 @@ func ParseVALEvents(wrappers []EventWrapper) (*VALEventData, error) {
 	currentGameID := ""
 	roundStartTimes := make(map[int]time.Time)
 
+	// Team economy seen since the previous round ended. The buy phase can fall on
+	// either side of the round start event, so the whole gap is included.
+	roundEconomy := make(map[string]*RoundEconomy)
+
+	// What happened in the current round, for the win condition
+	var roundPlant *PlantEvent
+	roundDefused := false
+	roundDeaths := make(map[string]int)
+
 	// Helper to calculate game time from round start
 	calcRoundGameTime := func(eventTime time.Time, roundNum int) int {
 		if startTime, ok := roundStartTimes[roundNum]; ok {
@@ -378,6 +421,28 @@ func ParseVALEvents(wrappers []EventWrapper) (*VALEventData, error) {
 				targetType = event.Target.Type
 			}
 
+			// A new game starts with a fresh economy
+			if event.Action == "started" && targetType == "round" && event.Actor != nil && event.Actor.ID != currentGameID {
+				roundEconomy = make(map[string]*RoundEconomy)
+			}
+			gameID := currentGameID
+			if event.Action == "started" && targetType == "round" && event.Actor != nil {
+				gameID = event.Actor.ID
+			}
+			for teamID, snap := range economySnapshot(event, gameID) {
+				econ, ok := roundEconomy[teamID]
+				if !ok {
+					econ = &RoundEconomy{TeamID: teamID, Money: -1}
+					roundEconomy[teamID] = econ
+				}
+				if econ.Money < 0 && snap.hasMoney {
+					econ.Money = snap.money
+				}
+				if snap.loadoutValue > econ.LoadoutValue {
+					econ.LoadoutValue = snap.loadoutValue
+				}
+			}
+
 			switch {
 			// Round start - track round number and start time
 			case event.Action == "started" && targetType == "round":
@@ -399,29 +464,65 @@ func ParseVALEvents(wrappers []EventWrapper) (*VALEventData, error) {
 				if event.Actor != nil {
 					currentGameID = event.Actor.ID
 				}
+				roundPlant, roundDefused = nil, false
+				roundDeaths = make(map[string]int)
 
 			// Round end with winner
 			case event.Action == "won" && targetType == "round":
 				roundEnd := parseRoundEndEvent(event, wrapper.OccurredAt, currentGameID)
+				roundEnd.MapName = data.MapName
+				if roundPlant != nil && roundEnd.AttackTeam == "" {
+					roundEnd.AttackTeam = roundPlant.TeamID
+				}
+				roundEnd.WinCondition = ClassifyWinCondition(roundEnd, roundPlant, roundDefused, roundDeaths)
 				data.RoundEnds = append(data.RoundEnds, roundEnd)
+				roundPlant, roundDefused = nil, false
+				roundDeaths = make(map[string]int)
+
+				// Close out the economy of this round
+				roundNum := roundEnd.RoundNum
+				if roundNum == 0 {
+					roundNum = currentRound
+				}
+				for _, teamID := range sortedTeamIDs(roundEconomy) {
+					econ := roundEconomy[teamID]
+					if econ.LoadoutValue == 0 && econ.Money < 0 {
+						continue
+					}
+					if econ.Money < 0 {
+						econ.Money = 0
+					}
+					econ.GameID = currentGameID
+					econ.RoundNum = roundNum
+					econ.MapName = data.MapName
+					data.Economy = append(data.Economy, *econ)
+				}
+				roundEconomy = make(map[string]*RoundEconomy)
 
 			// Spike plant
 			case event.Action == "completed" && targetType == "plantBomb":
 				plant := parsePlantEvent(event, wrapper.OccurredAt, currentRound, data.MapName)
+				plant.GameID = currentGameID
 				plant.GameTime = calcRoundGameTime(wrapper.OccurredAt, currentRound)
 				data.Plants = append(data.Plants, plant)
+				roundPlant = &plant
 
 			// Spike defuse
 			case event.Action == "completed" && targetType == "defuseBomb":
 				defuse := parseDefuseEvent(event, wrapper.OccurredAt, currentRound)
+				defuse.GameID = currentGameID
+				defuse.MapName = data.MapName
 				defuse.GameTime = calcRoundGameTime(wrapper.OccurredAt, currentRound)
 				data.Defuses = append(data.Defuses, defuse)
+				roundDefused = true
 
 			// Player kills
 			case actorType == "player" && event.Action == "killed" && targetType == "player":
 				kill := parseVALKillEvent(event, wrapper.OccurredAt, currentRound, data.MapName)
+				kill.GameID = currentGameID
 				kill.GameTime = calcRoundGameTime(wrapper.OccurredAt, currentRound)
 				data.Kills = append(data.Kills, kill)
+				roundDeaths[kill.VictimTeamID]++
 			}
 		}
 	}
@@ -429,6 +530,255 @@ func ParseVALEvents(wrappers []EventWrapper) (*VALEventData, error) {
 	return data, nil
 }
 
+// teamEconomy is one team's money and loadout value in a state snapshot
+type teamEconomy struct {
+	money        int
+	hasMoney     bool
+	loadoutValue int
+}
+
+// economySnapshot reads each team's money and loadout value for the given game
+// from the event's series state (full or delta), or from a game actor's state
+func economySnapshot(event GridEvent, gameID string) map[string]teamEconomy {
+	game := currentGame(event, gameID)
+	if game == nil && event.Actor != nil && event.Actor.Type == "game" {
+		game = event.Actor.State
+	}
+	if game == nil {
+		return nil
+	}
+
+	teams, ok := game["teams"].([]interface{})
+	if !ok {
+		return nil
+	}
+	snapshot := make(map[string]teamEconomy, len(teams))
+	for _, t := range teams {
+		team, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := team["id"].(string)
+		if id == "" {
+			continue
+		}
+		var econ teamEconomy
+		if money, ok := team["money"].(float64); ok {
+			econ.money = int(money)
+			econ.hasMoney = true
+		}
+		if loadout, ok := team["loadoutValue"].(float64); ok {
+			econ.loadoutValue = int(loadout)
+		}
+		snapshot[id] = econ
+	}
+	return snapshot
+}
+
+// teamStateSnapshot reads a LoL game's clock (-1 when absent) and each team's
+// net worth and kills from the event's series state (full or delta). Fields a
+// delta does not carry are left nil.
+func teamStateSnapshot(event GridEvent, gameID string) (string, int, map[string]teamTotals) {
+	game := currentGame(event, gameID)
+	if game == nil {
+		return "", 0, nil
+	}
+	id, _ := game["id"].(string)
+	if id == "" {
+		id = gameID
+	}
+
+	teams, ok := game["teams"].([]interface{})
+	if !ok || id == "" {
+		return "", 0, nil
+	}
+	snapshot := make(map[string]teamTotals, len(teams))
+	for _, t := range teams {
+		team, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		teamID, _ := team["id"].(string)
+		if teamID == "" {
+			continue
+		}
+		var totals teamTotals
+		totals.side, _ = team["side"].(string)
+		if netWorth, ok := team["netWorth"].(float64); ok {
+			v := int(netWorth)
+			totals.netWorth = &v
+		}
+		if kills, ok := team["kills"].(float64); ok {
+			v := int(kills)
+			totals.kills = &v
+		}
+		snapshot[teamID] = totals
+	}
+	if len(snapshot) == 0 {
+		return "", 0, nil
+	}
+
+	return id, gameClock(game), snapshot
+}
+
+// appendPlayerPositions records the position of every player in the event's state
+// of the game in progress who moved since their previous sample. After the first
+// clear a player gets a sample every LaningPositionInterval at most.
+func appendPlayerPositions(samples []PlayerPositionSample, event GridEvent, gameID string, clock int, last map[string]PlayerPositionSample) []PlayerPositionSample {
+	game := currentGame(event, gameID)
+	if game == nil {
+		return samples
+	}
+	id, _ := game["id"].(string)
+	if id == "" {
+		id = gameID
+	}
+
+	teams, _ := game["teams"].([]interface{})
+	for _, t := range teams {
+		team, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		teamID, _ := team["id"].(string)
+		players, _ := team["players"].([]interface{})
+		for _, p := range players {
+			player, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			playerID, _ := player["id"].(string)
+			pos, ok := player["position"].(map[string]interface{})
+			if playerID == "" || !ok {
+				continue
+			}
+			x, _ := pos["x"].(float64)
+			y, _ := pos["y"].(float64)
+			position := Position{X: x, Y: y}
+
+			key := id + "/" + playerID
+			if prev, seen := last[key]; seen && (prev.Position == position ||
+				clock > EarlyPositionWindow && clock-prev.GameTime < LaningPositionInterval) {
+				continue
+			}
+			sample := PlayerPositionSample{
+				GameID:   id,
+				GameTime: clock,
+				TeamID:   teamID,
+				PlayerID: playerID,
+				Position: position,
+			}
+			last[key] = sample
+			samples = append(samples, sample)
+		}
+	}
+	return samples
+}
+
+// currentGame returns the state of the game in progress from the event's series
+// state (full or delta), or nil when the event carries none
+func currentGame(event GridEvent, gameID string) map[string]interface{} {
+	var game map[string]interface{}
+	for _, state := range []map[string]interface{}{event.SeriesState, event.SeriesStateDelta} {
+		games, ok := state["games"].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, g := range games {
+			gm, ok := g.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			// Without a known game, the last game in the list is the one in progress
+			if id, _ := gm["id"].(string); gameID == "" || id == gameID {
+				game = gm
+			}
+		}
+		if game != nil {
+			break
+		}
+	}
+	return game
+}
+
+// gameClock returns a game state's clock in milliseconds, or -1 when it has none
+func gameClock(game map[string]interface{}) int {
+	if clock, ok := game["clock"].(map[string]interface{}); ok {
+		if currentSeconds, ok := clock["currentSeconds"].(float64); ok {
+			return int(currentSeconds * 1000) // Convert to milliseconds
+		}
+	}
+	return -1
+}
+
+// teamTotals is one team's values in a state snapshot
+type teamTotals struct {
+	side     string
+	netWorth *int
+	kills    *int
+}
+
+// gameTimeline merges a game's state snapshots into per-minute samples
+type gameTimeline struct {
+	gameID     string
+	lastMinute int
+	totals     map[string]*TeamStateSample
+}
+
+// update records the totals at every minute boundary passed since the previous
+// snapshot, then applies this one. Totals only change at events, so a boundary's
+// value is the one from before the first snapshot after it.
+func (t *gameTimeline) update(samples []GameStateSample, clock int, teams map[string]teamTotals) []GameStateSample {
+	if clock >= 0 {
+		minute := clock / 60000
+		if len(t.totals) > 0 {
+			for m := t.lastMinute + 1; m <= minute; m++ {
+				sample := GameStateSample{GameID: t.gameID, Minute: m}
+				for _, id := range sortedKeys(t.totals) {
+					sample.Teams = append(sample.Teams, *t.totals[id])
+				}
+				samples = append(samples, sample)
+			}
+		}
+		if minute > t.lastMinute {
+			t.lastMinute = minute
+		}
+	}
+
+	for id, team := range teams {
+		totals := t.totals[id]
+		if totals == nil {
+			totals = &TeamStateSample{TeamID: id}
+			t.totals[id] = totals
+		}
+		if team.side != "" {
+			totals.Side = team.side
+		}
+		if team.netWorth != nil {
+			totals.NetWorth = *team.netWorth
+		}
+		if team.kills != nil {
+			totals.Kills = *team.kills
+		}
+	}
+	return samples
+}
+
+// sortedKeys returns the keys of a map in a stable order
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// sortedTeamIDs returns the keys of a per-team map in a stable order
+func sortedTeamIDs(m map[string]*RoundEconomy) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 // Helper functions for parsing specific event types
 
 func parseKillEvent(event GridEvent, occurredAt time.Time, isFirstBlood bool) KillEvent {
@@ -596,9 +946,9 @@ func parseTowerDestroyEvent(event GridEvent, occurredAt time.Time, towerRegex *r
 	return tower
 }
 
-func parseDraftAction(event GridEvent, occurredAt time.Time, action string) DraftAction {
+func parseDraftAction(event GridEvent, occurredAt time.Time) DraftAction {
 	draft := DraftAction{
-		Action:     action,
+		Action:     normalizeDraftAction(event.Action),
 		OccurredAt: occurredAt,
 	}
 
@@ -641,13 +991,13 @@ func parseRoundEndEvent(event GridEvent, occurredAt time.Time, gameID string) Ro
 		}
 	}
 
-	// Extract round number and win type from target
+	// Extract round number, win type and sides from target
 	if event.Target != nil {
 		if event.Target.State != nil {
 			if seq, ok := event.Target.State["sequenceNumber"].(float64); ok {
 				roundEnd.RoundNum = int(seq)
 			}
-			// Win type is in teams array
+			// Win type and sides are in teams array
 			if teams, ok := event.Target.State["teams"].([]interface{}); ok {
 				for _, t := range teams {
 					if team, ok := t.(map[string]interface{}); ok {
@@ -656,6 +1006,14 @@ func parseRoundEndEvent(event GridEvent, occurredAt time.Time, gameID string) Ro
 								roundEnd.WinType = winType
 							}
 						}
+						id, _ := team["id"].(string)
+						side, _ := team["side"].(string)
+						switch strings.ToLower(side) {
+						case "attack", "attacker", "attackers":
+							roundEnd.AttackTeam = id
+						case "defense", "defender", "defenders":
+							roundEnd.DefenseTeam = id
+						}
 					}
 				}
 			}
@@ -665,6 +1023,45 @@ func parseRoundEndEvent(event GridEvent, occurredAt time.Time, gameID string) Ro
 	return roundEnd
 }
 
+// ClassifyWinCondition names how a round was won. GRID's win type is used when
+// present, otherwise it is inferred from the round's plant, defuse and deaths.
+func ClassifyWinCondition(roundEnd RoundEndEvent, plant *PlantEvent, defused bool, deaths map[string]int) string {
+	switch strings.ToLower(roundEnd.WinType) {
+	case "opponenteliminated", "eliminated", "elimination":
+		return WinConditionElimination
+	case "bombexploded", "detonate", "detonated", "spikedetonated":
+		return WinConditionDetonation
+	case "bombdefused", "defuse", "defused", "spikedefused":
+		return WinConditionDefuse
+	case "timeexpired", "time", "timeout":
+		return WinConditionTime
+	}
+	if roundEnd.WinnerTeam == "" {
+		return ""
+	}
+
+	// The losing side lost all five players
+	eliminated := false
+	for teamID, n := range deaths {
+		if teamID != "" && teamID != roundEnd.WinnerTeam && n >= 5 {
+			eliminated = true
+		}
+	}
+
+	switch {
+	case defused:
+		return WinConditionDefuse
+	case plant != nil && plant.TeamID != "" && plant.TeamID != roundEnd.WinnerTeam:
+		// Defenders can only win a planted round by defusing
+		return WinConditionDefuse
+	case eliminated:
+		return WinConditionElimination
+	case plant != nil:
+		return WinConditionDetonation
+	}
+	return WinConditionTime
+}
+
 func parsePlantEvent(event GridEvent, occurredAt time.Time, roundNum int, mapName string) PlantEvent {
 	plant := PlantEvent{
 		RoundNum:   roundNum,
@@ -757,6 +1154,7 @@ func parseVALKillEvent(event GridEvent, occurredAt time.Time, roundNum int, mapN
 			}
 		}
 		kill.KillerPosition = event.GetActorPosition()
+		kill.Weapon = event.GetActorWeapon()
 	}
 
 	// Extract victim info
@@ -783,15 +1181,26 @@ func parseVALKillEvent(event GridEvent, occurredAt time.Time, roundNum int, mapN
 	return kill
 }
 
-// inferSiteFromPosition determines the spike site based on plant position
-// This uses approximate site boundaries for each VALORANT map
+// inferSiteFromPosition determines the spike site based on plant position, from the
+// learned site geometry when it covers the map, otherwise from hand-drawn boundaries
 func inferSiteFromPosition(pos *Position, mapName string) string {
 	if pos == nil {
 		return ""
 	}
+	if site, _ := CurrentSiteGeometry().ForMap(mapName).Site(pos); site != "" {
+		return site
+	}
+	return HandDrawnSite(pos, mapName)
+}
+
+// HandDrawnSite determines the site from approximate hand-drawn boundaries for each
+// VALORANT map; the calibration tool compares learned sites against it
+func HandDrawnSite(pos *Position, mapName string) string {
+	if pos == nil {
+		return ""
+	}
 
 	// Site boundaries are approximate and based on map analysis
-	// These would need to be refined with more data
 	switch strings.ToLower(mapName) {
 	case "ascent":
 		// A site is roughly in the upper area, B site in the lower
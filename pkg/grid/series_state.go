@@ -3,6 +3,7 @@ package grid
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -37,6 +38,19 @@ func (c *Client) GetSeriesState(ctx context.Context, seriesID string) (*SeriesSt
 				started
 				finished
 				format
+				draftActions {
+					id
+					type
+					sequenceNumber
+					drafter {
+						id
+					}
+					draftable {
+						id
+						type
+						name
+					}
+				}
 				teams {
 					id
 					name
@@ -65,6 +79,7 @@ func (c *Client) GetSeriesState(ctx context.Context, seriesID string) (*SeriesSt
 						}
 						draftable {
 							id
+							type
 							name
 						}
 					}
@@ -170,11 +185,12 @@ func (c *Client) GetSeriesState(ctx context.Context, seriesID string) (*SeriesSt
 
 	var resp struct {
 		SeriesState struct {
-			ID       string `json:"id"`
-			Started  bool   `json:"started"`
-			Finished bool   `json:"finished"`
-			Format   string `json:"format"`
-			Teams    []struct {
+			ID           string                `json:"id"`
+			Started      bool                  `json:"started"`
+			Finished     bool                  `json:"finished"`
+			Format       string                `json:"format"`
+			DraftActions []draftActionResponse `json:"draftActions"`
+			Teams        []struct {
 				ID     string `json:"id"`
 				Name   string `json:"name"`
 				Won    bool   `json:"won"`
@@ -193,19 +209,8 @@ func (c *Client) GetSeriesState(ctx context.Context, seriesID string) (*SeriesSt
 				Clock struct {
 					CurrentSeconds int `json:"currentSeconds"`
 				} `json:"clock"`
-				DraftActions []struct {
-					ID             string `json:"id"`
-					Type           string `json:"type"`
-					SequenceNumber string `json:"sequenceNumber"`
-					Drafter        struct {
-						ID string `json:"id"`
-					} `json:"drafter"`
-					Draftable struct {
-						ID   string `json:"id"`
-						Name string `json:"name"`
-					} `json:"draftable"`
-				} `json:"draftActions"`
-				Segments []struct {
+				DraftActions []draftActionResponse `json:"draftActions"`
+				Segments     []struct {
 					ID             string `json:"id"`
 					SequenceNumber int    `json:"sequenceNumber"`
 					Type           string `json:"type"`
@@ -316,6 +321,7 @@ func (c *Client) GetSeriesState(ctx context.Context, seriesID string) (*SeriesSt
 		ID:       resp.SeriesState.ID,
 		Started:  resp.SeriesState.Started,
 		Finished: resp.SeriesState.Finished,
+		Format:   resp.SeriesState.Format,
 	}
 
 	// Convert teams
@@ -330,6 +336,17 @@ func (c *Client) GetSeriesState(ctx context.Context, seriesID string) (*SeriesSt
 		})
 	}
 
+	// Series-level draft (the VALORANT map veto)
+	for _, da := range resp.SeriesState.DraftActions {
+		action := da.toDraftAction()
+		for _, t := range state.Teams {
+			if t.ID == action.TeamID {
+				action.TeamName = t.Name
+			}
+		}
+		state.DraftActions = append(state.DraftActions, action)
+	}
+
 	// Convert games
 	for i, g := range resp.SeriesState.Games {
 		game := Game{
@@ -344,12 +361,7 @@ func (c *Client) GetSeriesState(ctx context.Context, seriesID string) (*SeriesSt
 
 		// Convert draft actions
 		for _, da := range g.DraftActions {
-			game.DraftActions = append(game.DraftActions, DraftAction{
-				TeamID:        da.Drafter.ID,
-				Action:        da.Type,
-				CharacterName: da.Draftable.Name,
-				CharacterID:   da.Draftable.ID,
-			})
+			game.DraftActions = append(game.DraftActions, da.toDraftAction())
 		}
 
 		// Convert segments (rounds for VALORANT)
@@ -556,6 +568,33 @@ func (c *Client) GetSeriesStates(ctx context.Context, seriesIDs []string) ([]*Se
 	return validResults, nil
 }
 
+// draftActionResponse is a draft action as returned by the Series State API
+type draftActionResponse struct {
+	ID             string `json:"id"`
+	Type           string `json:"type"`
+	SequenceNumber string `json:"sequenceNumber"`
+	Drafter        struct {
+		ID string `json:"id"`
+	} `json:"drafter"`
+	Draftable struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+		Name string `json:"name"`
+	} `json:"draftable"`
+}
+
+func (da draftActionResponse) toDraftAction() DraftAction {
+	sequence, _ := strconv.Atoi(da.SequenceNumber)
+	return DraftAction{
+		TeamID:        da.Drafter.ID,
+		Action:        normalizeDraftAction(da.Type),
+		CharacterName: da.Draftable.Name,
+		CharacterID:   da.Draftable.ID,
+		DraftableType: da.Draftable.Type,
+		Sequence:      sequence,
+	}
+}
+
 // GetMatchDataForTeam fetches all match data for a team's recent series
 func (c *Client) GetMatchDataForTeam(ctx context.Context, teamID string, matchCount int) ([]*SeriesState, error) {
 	// First get the series IDs
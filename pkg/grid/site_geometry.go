@@ -0,0 +1,177 @@
+package grid
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SiteGeometryVersion is the format version of the site geometry data file
+const SiteGeometryVersion = 1
+
+// Point is a map coordinate
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Region is a learned map area: a convex polygon around its samples
+type Region struct {
+	Name    string  `json:"name"`
+	Polygon []Point `json:"polygon"`
+	Center  Point   `json:"center"`
+	Samples int     `json:"samples"`
+}
+
+// MapGeometry contains the learned regions of one map
+type MapGeometry struct {
+	MapName string             `json:"mapName"`
+	Sites   map[string]*Region `json:"sites"`
+	Mid     *Region            `json:"mid,omitempty"`
+	Lobbies map[string]*Region `json:"lobbies,omitempty"` // Attacker approach to each site, keyed by site
+	Plants  int                `json:"plants"`            // Plants the sites were learned from
+	Kills   int                `json:"kills"`             // Kills the mid and lobbies were learned from
+}
+
+// SiteGeometry is the versioned site geometry data file, produced by
+// scripts/calibrate_sites.go and shared by both site classifiers
+type SiteGeometry struct {
+	Version     int                     `json:"version"`
+	Revision    int                     `json:"revision"` // Bumped on every calibration
+	GeneratedAt time.Time               `json:"generatedAt"`
+	Maps        map[string]*MapGeometry `json:"maps"` // Keyed by lowercase map name
+}
+
+var (
+	siteGeometryMu sync.RWMutex
+	siteGeometry   *SiteGeometry
+)
+
+// LoadSiteGeometry reads a site geometry data file
+func LoadSiteGeometry(path string) (*SiteGeometry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read site geometry: %w", err)
+	}
+
+	var geometry SiteGeometry
+	if err := json.Unmarshal(data, &geometry); err != nil {
+		return nil, fmt.Errorf("parse site geometry: %w", err)
+	}
+	if geometry.Version != SiteGeometryVersion {
+		return nil, fmt.Errorf("site geometry version %d is not supported (want %d)", geometry.Version, SiteGeometryVersion)
+	}
+	return &geometry, nil
+}
+
+// SaveSiteGeometry writes a site geometry data file
+func SaveSiteGeometry(path string, geometry *SiteGeometry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create site geometry directory: %w", err)
+	}
+	data, err := json.MarshalIndent(geometry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal site geometry: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write site geometry: %w", err)
+	}
+	return nil
+}
+
+// SetSiteGeometry installs learned geometry for the site classifiers
+func SetSiteGeometry(geometry *SiteGeometry) {
+	siteGeometryMu.Lock()
+	defer siteGeometryMu.Unlock()
+	siteGeometry = geometry
+}
+
+// CurrentSiteGeometry returns the installed geometry, or nil when none is loaded
+func CurrentSiteGeometry() *SiteGeometry {
+	siteGeometryMu.RLock()
+	defer siteGeometryMu.RUnlock()
+	return siteGeometry
+}
+
+// ForMap returns a map's learned geometry, or nil
+func (g *SiteGeometry) ForMap(mapName string) *MapGeometry {
+	if g == nil {
+		return nil
+	}
+	return g.Maps[strings.ToLower(strings.TrimSpace(mapName))]
+}
+
+// Contains reports whether p lies inside the region's polygon
+func (r *Region) Contains(p Point) bool {
+	if r == nil || len(r.Polygon) < 3 {
+		return false
+	}
+	inside := false
+	for i, j := 0, len(r.Polygon)-1; i < len(r.Polygon); j, i = i, i+1 {
+		a, b := r.Polygon[i], r.Polygon[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func distance(a, b Point) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
+
+func sortedRegionKeys(regions map[string]*Region) []string {
+	keys := make([]string, 0, len(regions))
+	for k := range regions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Site returns the site whose polygon contains pos, otherwise the nearest site,
+// and whether pos was inside a site polygon
+func (m *MapGeometry) Site(pos *Position) (string, bool) {
+	if m == nil || pos == nil || len(m.Sites) == 0 {
+		return "", false
+	}
+	p := Point{X: pos.X, Y: pos.Y}
+	nearest, best := "", math.MaxFloat64
+	for _, name := range sortedRegionKeys(m.Sites) {
+		site := m.Sites[name]
+		if site.Contains(p) {
+			return name, true
+		}
+		if d := distance(p, site.Center); d < best {
+			nearest, best = name, d
+		}
+	}
+	return nearest, false
+}
+
+// Region names the learned area containing pos: a site letter, "Mid", "A Lobby",
+// or "" when pos is in none of them
+func (m *MapGeometry) Region(pos *Position) string {
+	if m == nil || pos == nil {
+		return ""
+	}
+	if site, inside := m.Site(pos); inside {
+		return site
+	}
+	p := Point{X: pos.X, Y: pos.Y}
+	if m.Mid.Contains(p) {
+		return "Mid"
+	}
+	for _, site := range sortedRegionKeys(m.Lobbies) {
+		if m.Lobbies[site].Contains(p) {
+			return site + " Lobby"
+		}
+	}
+	return ""
+}
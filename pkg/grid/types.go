@@ -1,6 +1,9 @@
 package grid
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Title represents a game title (LoL, VALORANT)
 type Title struct {
@@ -36,21 +39,24 @@ type Player struct {
 
 // Series represents a match series (Bo1, Bo3, Bo5)
 type Series struct {
-	ID           string    `json:"id"`
-	TournamentID string    `json:"tournamentId"`
-	StartTime    time.Time `json:"startTime"`
-	Format       string    `json:"format,omitempty"`
-	Teams        []Team    `json:"teams"`
-	TitleID      string    `json:"titleId"`
+	ID             string    `json:"id"`
+	TournamentID   string    `json:"tournamentId"`
+	TournamentName string    `json:"tournamentName,omitempty"`
+	StartTime      time.Time `json:"startTime"`
+	Format         string    `json:"format,omitempty"`
+	Teams          []Team    `json:"teams"`
+	TitleID        string    `json:"titleId"`
 }
 
 // SeriesState represents the detailed state of a series
 type SeriesState struct {
-	ID       string      `json:"id"`
-	Started  bool        `json:"started"`
-	Finished bool        `json:"finished"`
-	Teams    []TeamState `json:"teams"`
-	Games    []Game      `json:"games"`
+	ID           string        `json:"id"`
+	Started      bool          `json:"started"`
+	Finished     bool          `json:"finished"`
+	Format       string        `json:"format,omitempty"`
+	Teams        []TeamState   `json:"teams"`
+	Games        []Game        `json:"games"`
+	DraftActions []DraftAction `json:"draftActions,omitempty"` // Series-level draft, e.g. VALORANT map veto
 }
 
 // TeamState represents team-level state in a series
@@ -316,6 +322,29 @@ func (e *GridEvent) GetTargetPosition() *Position {
 	return nil
 }
 
+// GetActorWeapon returns the weapon of a kill: the weaponKills entry the event
+// added to the actor's state, or "" when the delta does not carry one
+func (e *GridEvent) GetActorWeapon() string {
+	if e.Actor == nil || e.Actor.StateDelta == nil {
+		return ""
+	}
+	deltas := []map[string]interface{}{e.Actor.StateDelta}
+	if game, ok := e.Actor.StateDelta["game"].(map[string]interface{}); ok {
+		deltas = append(deltas, game)
+	}
+	for _, delta := range deltas {
+		entries, _ := delta["weaponKills"].([]interface{})
+		for _, entry := range entries {
+			if weapon, ok := entry.(map[string]interface{}); ok {
+				if name, ok := weapon["weaponName"].(string); ok && name != "" {
+					return strings.ToLower(name)
+				}
+			}
+		}
+	}
+	return ""
+}
+
 // GameEvent is kept for backward compatibility but deprecated
 // Use EventWrapper and GridEvent instead
 type GameEvent struct {
@@ -327,6 +356,7 @@ type GameEvent struct {
 
 // LoL-specific event types
 type DragonKillEvent struct {
+	GameID     string    `json:"gameId,omitempty"`
 	TeamID     string    `json:"teamId"`
 	TeamName   string    `json:"teamName"`
 	PlayerID   string    `json:"playerId"`
@@ -338,6 +368,7 @@ type DragonKillEvent struct {
 }
 
 type TowerDestroyEvent struct {
+	GameID     string    `json:"gameId,omitempty"`
 	TeamID     string    `json:"teamId"`
 	TeamName   string    `json:"teamName"`
 	PlayerID   string    `json:"playerId,omitempty"` // empty if team credit
@@ -350,6 +381,7 @@ type TowerDestroyEvent struct {
 }
 
 type KillEvent struct {
+	GameID         string    `json:"gameId,omitempty"`
 	KillerID       string    `json:"killerId"`
 	KillerName     string    `json:"killerName"`
 	KillerTeamID   string    `json:"killerTeamId"`
@@ -371,15 +403,25 @@ type Position struct {
 
 // VALORANT-specific event types
 type RoundEndEvent struct {
-	GameID      string    `json:"gameId"`
-	RoundNum    int       `json:"roundNum"`
-	WinnerTeam  string    `json:"winnerTeam"`
-	WinnerName  string    `json:"winnerName"`
-	WinType     string    `json:"winType"`     // "opponentEliminated", "defuse", "detonate", "time"
-	AttackTeam  string    `json:"attackTeam"`  // For backward compatibility
-	DefenseTeam string    `json:"defenseTeam"` // For backward compatibility
-	OccurredAt  time.Time `json:"occurredAt"`
-}
+	GameID       string    `json:"gameId"`
+	RoundNum     int       `json:"roundNum"`
+	WinnerTeam   string    `json:"winnerTeam"`
+	WinnerName   string    `json:"winnerName"`
+	WinType      string    `json:"winType"`      // "opponentEliminated", "defuse", "detonate", "time"
+	WinCondition string    `json:"winCondition"` // Normalized, one of the WinCondition constants
+	MapName      string    `json:"mapName,omitempty"`
+	AttackTeam   string    `json:"attackTeam"`  // For backward compatibility
+	DefenseTeam  string    `json:"defenseTeam"` // For backward compatibility
+	OccurredAt   time.Time `json:"occurredAt"`
+}
+
+// Round win conditions for VALORANT
+const (
+	WinConditionElimination = "elimination"
+	WinConditionDetonation  = "detonation"
+	WinConditionDefuse      = "defuse"
+	WinConditionTime        = "time"
+)
 
 // IsDefendingSide returns true if the given teamID was defending this round
 func (r *RoundEndEvent) IsDefendingSide(teamID string) bool {
@@ -391,7 +433,19 @@ func (r *RoundEndEvent) IsAttackingSide(teamID string) bool {
 	return r.AttackTeam == teamID
 }
 
+// RoundEconomy is a team's buy in one VALORANT round, taken from the state
+// snapshots in the event stream
+type RoundEconomy struct {
+	GameID       string `json:"gameId"`
+	RoundNum     int    `json:"roundNum"`
+	MapName      string `json:"mapName"`
+	TeamID       string `json:"teamId"`
+	Money        int    `json:"money"`        // credits before buying
+	LoadoutValue int    `json:"loadoutValue"` // highest team loadout value seen in the round
+}
+
 type PlantEvent struct {
+	GameID     string    `json:"gameId,omitempty"`
 	PlayerID   string    `json:"playerId"`
 	PlayerName string    `json:"playerName"`
 	TeamID     string    `json:"teamId"`
@@ -405,23 +459,27 @@ type PlantEvent struct {
 }
 
 type DefuseEvent struct {
+	GameID     string    `json:"gameId,omitempty"`
 	PlayerID   string    `json:"playerId"`
 	PlayerName string    `json:"playerName"`
 	TeamID     string    `json:"teamId"`
 	Agent      string    `json:"agent"`
 	Position   *Position `json:"position,omitempty"`
 	RoundNum   int       `json:"roundNum"`
+	MapName    string    `json:"mapName,omitempty"`
 	OccurredAt time.Time `json:"occurredAt"`
 	GameTime   int       `json:"gameTime"` // milliseconds from round start
 }
 
 // VALKillEvent is VALORANT-specific kill event with additional context
 type VALKillEvent struct {
+	GameID         string    `json:"gameId,omitempty"`
 	KillerID       string    `json:"killerId"`
 	KillerName     string    `json:"killerName"`
 	KillerTeamID   string    `json:"killerTeamId"`
 	KillerAgent    string    `json:"killerAgent"`
 	KillerPosition *Position `json:"killerPosition,omitempty"`
+	Weapon         string    `json:"weapon,omitempty"` // e.g. "operator", when the event carries it
 	VictimID       string    `json:"victimId"`
 	VictimName     string    `json:"victimName"`
 	VictimTeamID   string    `json:"victimTeamId"`
@@ -433,19 +491,70 @@ type VALKillEvent struct {
 	GameTime       int       `json:"gameTime"` // milliseconds from round start
 }
 
+// GameStateSample is both teams' net worth and kills at a minute of a LoL game,
+// from the series state snapshots in the event feed
+type GameStateSample struct {
+	GameID string            `json:"gameId"`
+	Minute int               `json:"minute"`
+	Teams  []TeamStateSample `json:"teams"`
+}
+
+// EarlyPositionWindow is the start of a LoL game (ms) where PlayerPositions keeps
+// every move: the first jungle clear
+const EarlyPositionWindow = 210000
+
+// PlayerPositions covers the laning phase up to LaningPositionWindow (ms), with
+// a player's position at most every LaningPositionInterval after the first clear
+const (
+	LaningPositionWindow   = 1200000
+	LaningPositionInterval = 5000
+)
+
+// PlayerPositionSample is where a player was at a moment of a LoL game, from the
+// series state snapshots in the event feed
+type PlayerPositionSample struct {
+	GameID   string   `json:"gameId"`
+	GameTime int      `json:"gameTime"` // milliseconds from game start
+	TeamID   string   `json:"teamId"`
+	PlayerID string   `json:"playerId"`
+	Position Position `json:"position"`
+}
+
+// TeamStateSample is one team's totals in a GameStateSample
+type TeamStateSample struct {
+	TeamID   string `json:"teamId"`
+	Side     string `json:"side,omitempty"` // "blue" or "red"
+	NetWorth int    `json:"netWorth"`
+	Kills    int    `json:"kills"`
+}
+
 // DraftAction represents a pick or ban in draft phase
 type DraftAction struct {
 	TeamID        string    `json:"teamId"`
 	TeamName      string    `json:"teamName"`
-	Action        string    `json:"action"` // "picked" or "banned"
-	CharacterName string    `json:"characterName"`
+	Action        string    `json:"action"`        // "pick" or "ban"
+	CharacterName string    `json:"characterName"` // Draftable name: a champion, agent or map
 	CharacterID   string    `json:"characterId"`
+	DraftableType string    `json:"draftableType,omitempty"` // "character" or "map"
 	Sequence      int       `json:"sequence"`
 	OccurredAt    time.Time `json:"occurredAt"`
 }
 
+// normalizeDraftAction maps the Series State API's "pick"/"ban" and the event
+// feed's "picked"/"banned" to "pick" or "ban"
+func normalizeDraftAction(action string) string {
+	switch strings.ToLower(action) {
+	case "pick", "picked":
+		return "pick"
+	case "ban", "banned":
+		return "ban"
+	}
+	return strings.ToLower(action)
+}
+
 // ObjectiveKillEvent represents Baron, Herald, or other major objective kills
 type ObjectiveKillEvent struct {
+	GameID        string    `json:"gameId,omitempty"`
 	TeamID        string    `json:"teamId"`
 	TeamName      string    `json:"teamName"`
 	PlayerID      string    `json:"playerId"`
@@ -0,0 +1,87 @@
+package intelligence
+
+import (
+	"math"
+	"slices"
+)
+
+// ChangePointDetector finds shifts in the mean of a metric series using PELT
+// (pruned exact linear time) with a Gaussian cost
+type ChangePointDetector struct {
+	PenaltyScale float64 // multiplier on the log(n) penalty paid per change point
+	MinSegment   int     // fewest points on each side of a change
+}
+
+// NewChangePointDetector creates a detector tuned for short match histories
+func NewChangePointDetector() *ChangePointDetector {
+	return &ChangePointDetector{
+		PenaltyScale: 3,
+		MinSegment:   3,
+	}
+}
+
+// Detect returns the indexes at which a new segment starts, in ascending order
+func (d *ChangePointDetector) Detect(values []float64) []int {
+	n := len(values)
+	minSeg := d.MinSegment
+	if minSeg < 1 {
+		minSeg = 1
+	}
+	if n < 2*minSeg {
+		return nil
+	}
+
+	// Cumulative sums give O(1) segment costs
+	sum := make([]float64, n+1)
+	sumSq := make([]float64, n+1)
+	for i, v := range values {
+		sum[i+1] = sum[i] + v
+		sumSq[i+1] = sumSq[i] + v*v
+	}
+	variance := (sumSq[n] - sum[n]*sum[n]/float64(n)) / float64(n)
+	if variance <= 1e-12 {
+		return nil
+	}
+
+	// cost is the squared error of [start, end) around its own mean, in units of the overall variance
+	cost := func(start, end int) float64 {
+		s := sum[end] - sum[start]
+		return (sumSq[end] - sumSq[start] - s*s/float64(end-start)) / variance
+	}
+	penalty := d.PenaltyScale * math.Log(float64(n))
+
+	best := make([]float64, n+1)
+	prev := make([]int, n+1)
+	best[0] = -penalty
+	candidates := []int{0}
+
+	for t := minSeg; t <= n; t++ {
+		best[t] = math.Inf(1)
+		for _, tau := range candidates {
+			if t-tau < minSeg {
+				continue
+			}
+			if v := best[tau] + cost(tau, t) + penalty; v < best[t] {
+				best[t] = v
+				prev[t] = tau
+			}
+		}
+
+		// Prune candidates that can never start the optimal last segment again
+		kept := candidates[:0]
+		for _, tau := range candidates {
+			if t-tau < minSeg || best[tau]+cost(tau, t) <= best[t] {
+				kept = append(kept, tau)
+			}
+		}
+		candidates = append(kept, t)
+	}
+
+	var points []int
+	for t := prev[n]; t > 0; t = prev[t] {
+		points = append(points, t)
+	}
+	slices.Reverse(points)
+
+	return points
+}
@@ -0,0 +1,147 @@
+package intelligence
+
+import (
+	"fmt"
+	"sort"
+
+	"scout9/pkg/grid"
+)
+
+// ClutchAnalyzer detects 1vX situations in VALORANT rounds from the kill feed
+type ClutchAnalyzer struct{}
+
+// NewClutchAnalyzer creates a new clutch analyzer
+func NewClutchAnalyzer() *ClutchAnalyzer {
+	return &ClutchAnalyzer{}
+}
+
+// clutchAggregator accumulates clutch records overall, by situation and by side
+type clutchAggregator struct {
+	overall     ClutchRecord
+	bySituation map[string]*ClutchRecord
+	bySide      map[string]*ClutchRecord
+}
+
+func newClutchAggregator() *clutchAggregator {
+	return &clutchAggregator{
+		bySituation: make(map[string]*ClutchRecord),
+		bySide:      make(map[string]*ClutchRecord),
+	}
+}
+
+func (a *clutchAggregator) add(situation, side string, won bool) {
+	records := []*ClutchRecord{&a.overall}
+	if a.bySituation[situation] == nil {
+		a.bySituation[situation] = &ClutchRecord{}
+	}
+	records = append(records, a.bySituation[situation])
+	if side != "" {
+		if a.bySide[side] == nil {
+			a.bySide[side] = &ClutchRecord{}
+		}
+		records = append(records, a.bySide[side])
+	}
+	for _, r := range records {
+		r.Attempts++
+		if won {
+			r.Wins++
+		}
+	}
+}
+
+func (a *clutchAggregator) finish() {
+	a.overall.finish()
+	for _, r := range a.bySituation {
+		r.finish()
+	}
+	for _, r := range a.bySide {
+		r.finish()
+	}
+}
+
+func (r *ClutchRecord) finish() {
+	if r.Attempts > 0 {
+		r.WinRate = float64(r.Wins) / float64(r.Attempts)
+	}
+}
+
+// AnalyzeClutches replays each round's kill feed with alive counts and records every
+// round where one of our players was left alone against one to five opponents
+func (c *ClutchAnalyzer) AnalyzeClutches(
+	teamID string,
+	teamName string,
+	seriesStates []*grid.SeriesState,
+	events map[string]*grid.VALEventData,
+) *ClutchAnalysis {
+	team := newClutchAggregator()
+	players := make(map[string]*clutchAggregator)
+	names := make(map[string]string)
+
+	for _, round := range collectVALRounds(teamID, teamName, seriesStates, events) {
+		if len(round.kills) == 0 || len(round.players) == 0 {
+			continue
+		}
+
+		alive := make(map[string]bool, len(round.players))
+		for _, p := range round.players {
+			alive[p.ID] = true
+			names[p.ID] = p.Name
+		}
+		oursAlive, theirsAlive := len(round.players), round.enemies
+
+		for _, kill := range round.kills {
+			switch {
+			case kill.VictimTeamID == round.ourID && alive[kill.VictimID]:
+				alive[kill.VictimID] = false
+				oursAlive--
+			case kill.VictimTeamID != round.ourID && kill.VictimTeamID != "":
+				theirsAlive--
+			default:
+				continue
+			}
+
+			if oursAlive == 0 || theirsAlive <= 0 {
+				break
+			}
+			if oursAlive == 1 {
+				clutcher := ""
+				for _, p := range round.players {
+					if alive[p.ID] {
+						clutcher = p.ID
+					}
+				}
+				situation := fmt.Sprintf("1v%d", min(theirsAlive, 5))
+				team.add(situation, round.side, round.won)
+				if players[clutcher] == nil {
+					players[clutcher] = newClutchAggregator()
+				}
+				players[clutcher].add(situation, round.side, round.won)
+				break
+			}
+		}
+	}
+
+	team.finish()
+	analysis := &ClutchAnalysis{
+		Team:        team.overall,
+		BySituation: team.bySituation,
+		BySide:      team.bySide,
+		Players:     make([]*PlayerClutchStats, 0, len(players)),
+	}
+	for _, id := range sortedKeys(players) {
+		agg := players[id]
+		agg.finish()
+		analysis.Players = append(analysis.Players, &PlayerClutchStats{
+			PlayerID:    id,
+			PlayerName:  names[id],
+			Overall:     agg.overall,
+			BySituation: agg.bySituation,
+			BySide:      agg.bySide,
+		})
+	}
+	sort.SliceStable(analysis.Players, func(i, j int) bool {
+		return analysis.Players[i].Overall.Attempts > analysis.Players[j].Overall.Attempts
+	})
+
+	return analysis
+}
@@ -8,11 +8,15 @@ import (
 )
 
 // CompositionAnalyzer analyzes team compositions and draft patterns
-type CompositionAnalyzer struct{}
+type CompositionAnalyzer struct {
+	roleDetector *DataDrivenRoleDetector
+}
 
 // NewCompositionAnalyzer creates a new composition analyzer
 func NewCompositionAnalyzer() *CompositionAnalyzer {
-	return &CompositionAnalyzer{}
+	return &CompositionAnalyzer{
+		roleDetector: NewDataDrivenRoleDetector(),
+	}
 }
 
 // AnalyzeCompositions analyzes team compositions from match data
@@ -144,7 +148,10 @@ func (a *CompositionAnalyzer) AnalyzeCompositions(
 
 	// Sort by frequency
 	sort.Slice(analysis.TopCompositions, func(i, j int) bool {
-		return analysis.TopCompositions[i].GamesPlayed > analysis.TopCompositions[j].GamesPlayed
+		if analysis.TopCompositions[i].GamesPlayed != analysis.TopCompositions[j].GamesPlayed {
+			return analysis.TopCompositions[i].GamesPlayed > analysis.TopCompositions[j].GamesPlayed
+		}
+		return joinChars(analysis.TopCompositions[i].Characters) < joinChars(analysis.TopCompositions[j].Characters)
 	})
 
 	// Limit to top 5
@@ -167,7 +174,10 @@ func (a *CompositionAnalyzer) AnalyzeCompositions(
 
 	// Sort by pick rate
 	sort.Slice(analysis.FirstPickPriorities, func(i, j int) bool {
-		return analysis.FirstPickPriorities[i].Rate > analysis.FirstPickPriorities[j].Rate
+		if analysis.FirstPickPriorities[i].Rate != analysis.FirstPickPriorities[j].Rate {
+			return analysis.FirstPickPriorities[i].Rate > analysis.FirstPickPriorities[j].Rate
+		}
+		return analysis.FirstPickPriorities[i].Character < analysis.FirstPickPriorities[j].Character
 	})
 
 	// Limit to top 10
@@ -199,11 +209,25 @@ func (a *CompositionAnalyzer) AnalyzeCompositions(
 
 	// Sort synergies by games played
 	sort.Slice(analysis.PlayerSynergies, func(i, j int) bool {
-		return analysis.PlayerSynergies[i].GamesPlayed > analysis.PlayerSynergies[j].GamesPlayed
+		a, b := analysis.PlayerSynergies[i], analysis.PlayerSynergies[j]
+		if a.GamesPlayed != b.GamesPlayed {
+			return a.GamesPlayed > b.GamesPlayed
+		}
+		if a.PlayerName != b.PlayerName {
+			return a.PlayerName < b.PlayerName
+		}
+		return a.Character < b.Character
 	})
 
 	// Calculate archetype breakdown for LoL
 	if title == "lol" {
+		// Draft-order aware priorities when drafts were recorded
+		analysis.DraftPhases = NewDraftPhaseAnalyzer().AnalyzeDraftPhases(teamID, seriesStates)
+		if analysis.DraftPhases.Games > 0 {
+			analysis.FirstPickPriorities = analysis.DraftPhases.FirstRotation
+			analysis.CommonBans = analysis.DraftPhases.BansAgainst
+		}
+
 		archetypeCounts := make(map[string]int)
 		for _, comp := range analysis.TopCompositions {
 			if comp.Archetype != "" {
@@ -215,6 +239,15 @@ func (a *CompositionAnalyzer) AnalyzeCompositions(
 		}
 	}
 
+	// LoL flex picks are champions played in two or more roles, by detected role
+	if title == "lol" {
+		analysis.FlexDetails = a.detectFlexPicks(teamID, seriesStates)
+		for _, flex := range analysis.FlexDetails {
+			analysis.FlexPicks = append(analysis.FlexPicks, flex.Character)
+		}
+		return analysis, nil
+	}
+
 	// Identify flex picks (characters played by multiple players)
 	charPlayers := make(map[string]map[string]bool)
 	for playerName, chars := range playerChars {
@@ -225,8 +258,8 @@ func (a *CompositionAnalyzer) AnalyzeCompositions(
 			charPlayers[charName][playerName] = true
 		}
 	}
-	for charName, players := range charPlayers {
-		if len(players) >= 2 {
+	for _, charName := range sortedKeys(charPlayers) {
+		if len(charPlayers[charName]) >= 2 {
 			analysis.FlexPicks = append(analysis.FlexPicks, charName)
 		}
 	}
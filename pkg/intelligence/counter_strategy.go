@@ -737,8 +737,12 @@ func (e *CounterStrategyEngine) enhanceLoLStrategyWithInsights(
 	// Analyze objective timings
 	objectiveTimings := e.timingAnalyzer.AnalyzeObjectiveTimings(teamAnalysis.TeamID, seriesStates, events)
 
+	// Analyze laning phase roams, lane swaps and grouping
+	lanePatterns := e.timingAnalyzer.AnalyzeLanePatterns(teamAnalysis.TeamID, seriesStates, events)
+
 	// Generate timing-based counter-strategies
 	timingStrategies := e.timingAnalyzer.GenerateTimingCounterStrategies(junglePathing, objectiveTimings)
+	timingStrategies = append(timingStrategies, e.timingAnalyzer.GenerateLanePatternStrategies(lanePatterns)...)
 	for _, ts := range timingStrategies {
 		strategy.InGameStrategies = append(strategy.InGameStrategies, Strategy{
 			Title:       ts.Strategy,
@@ -834,7 +838,7 @@ func (e *CounterStrategyEngine) enhanceVALStrategyWithInsights(
 	events map[string]*grid.VALEventData,
 ) ([]EconomyInsight, []StrategyInsight) {
 	// Analyze economy rounds (Task 9.4)
-	economyAnalysis := e.economyAnalyzer.AnalyzeEconomyRounds(teamAnalysis.TeamID, teamAnalysis.TeamName, seriesStates)
+	economyAnalysis := e.economyAnalyzer.AnalyzeEconomyRounds(teamAnalysis.TeamID, teamAnalysis.TeamName, seriesStates, events)
 	economyInsights := e.economyAnalyzer.GenerateEconomyInsights(economyAnalysis)
 
 	// Add economy insights to strategy
@@ -890,8 +894,10 @@ func (e *CounterStrategyEngine) enhanceVALStrategyWithInsights(
 	}
 
 	// Add site insights to weaknesses
-	for mapName, analysis := range siteAnalysis {
-		for siteName, stats := range analysis.Sites {
+	for _, mapName := range sortedKeys(siteAnalysis) {
+		analysis := siteAnalysis[mapName]
+		for _, siteName := range sortedKeys(analysis.Sites) {
+			stats := analysis.Sites[siteName]
 			if stats.DefenseAttempts >= 3 && stats.DefenseWinRate < 0.4 {
 				strategy.Weaknesses = append(strategy.Weaknesses, WeaknessTarget{
 					Title:       fmt.Sprintf("Weak %s-Site Defense on %s", siteName, mapName),
@@ -924,9 +930,42 @@ func (e *CounterStrategyEngine) enhanceVALStrategyWithInsights(
 		}
 	}
 
+	// Post-plant conversion and retake tendencies
+	if teamAnalysis.VALMetrics != nil {
+		strategy.InGameStrategies = append(strategy.InGameStrategies, GeneratePostPlantCounters(teamAnalysis.VALMetrics.PostPlant)...)
+	}
+
+	// Target players who lose entry duels or die untraded
+	if teamAnalysis.VALMetrics != nil {
+		for _, target := range GenerateTradeTargets(teamAnalysis.VALMetrics.Trades, playerProfiles) {
+			found := false
+			for i, existing := range strategy.TargetPlayers {
+				if existing.PlayerName == target.PlayerName {
+					strategy.TargetPlayers[i].Reason = fmt.Sprintf("%s. %s", existing.Reason, target.Reason)
+					if target.Priority < existing.Priority {
+						strategy.TargetPlayers[i].Priority = target.Priority
+					}
+					found = true
+					break
+				}
+			}
+			if !found {
+				strategy.TargetPlayers = append(strategy.TargetPlayers, target)
+			}
+		}
+		sort.SliceStable(strategy.TargetPlayers, func(i, j int) bool {
+			return strategy.TargetPlayers[i].Priority < strategy.TargetPlayers[j].Priority
+		})
+		if len(strategy.TargetPlayers) > 3 {
+			strategy.TargetPlayers = strategy.TargetPlayers[:3]
+		}
+	}
+
 	// Update win condition with site data
-	for mapName, analysis := range siteAnalysis {
-		for siteName, stats := range analysis.Sites {
+	for _, mapName := range sortedKeys(siteAnalysis) {
+		analysis := siteAnalysis[mapName]
+		for _, siteName := range sortedKeys(analysis.Sites) {
+			stats := analysis.Sites[siteName]
 			if stats.DefenseAttempts >= 3 && stats.DefenseWinRate < 0.4 {
 				strategy.WinCondition = fmt.Sprintf("%s Attack %s-Site on %s (%.0f%% defense win rate).",
 					strategy.WinCondition, siteName, mapName, stats.DefenseWinRate*100)
@@ -0,0 +1,584 @@
+package intelligence
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"scout9/pkg/grid"
+)
+
+// DraftAssistant ranks our next LoL pick or ban during a live draft. It weighs both
+// teams' champion pools, lane class matchups, composition archetypes and each
+// team's draft tendencies, and predicts the opponent's answer to each option.
+type DraftAssistant struct {
+	matchupAnalyzer *MatchupAnalyzer
+}
+
+// NewDraftAssistant creates a new draft assistant
+func NewDraftAssistant() *DraftAssistant {
+	return &DraftAssistant{matchupAnalyzer: NewMatchupAnalyzer()}
+}
+
+// Pick phases
+const (
+	PickPhaseOne = "pick1"
+	PickPhaseTwo = "pick2"
+)
+
+// lolDraftTurn is one turn of the tournament draft
+type lolDraftTurn struct {
+	side, action, phase string
+}
+
+// lolDraftOrder is the tournament draft: 6 bans, picks B1 R1 R2 B2 B3 R3, 4 bans
+// starting with red, then picks R4 B4 B5 R5
+var lolDraftOrder = []lolDraftTurn{
+	{"blue", "ban", BanPhaseOne}, {"red", "ban", BanPhaseOne},
+	{"blue", "ban", BanPhaseOne}, {"red", "ban", BanPhaseOne},
+	{"blue", "ban", BanPhaseOne}, {"red", "ban", BanPhaseOne},
+	{"blue", "pick", PickPhaseOne}, {"red", "pick", PickPhaseOne}, {"red", "pick", PickPhaseOne},
+	{"blue", "pick", PickPhaseOne}, {"blue", "pick", PickPhaseOne}, {"red", "pick", PickPhaseOne},
+	{"red", "ban", BanPhaseTwo}, {"blue", "ban", BanPhaseTwo},
+	{"red", "ban", BanPhaseTwo}, {"blue", "ban", BanPhaseTwo},
+	{"red", "pick", PickPhaseTwo}, {"blue", "pick", PickPhaseTwo},
+	{"blue", "pick", PickPhaseTwo}, {"red", "pick", PickPhaseTwo},
+}
+
+// lolRoles are the LoL positions in draft display order
+var lolRoles = []string{"Top", "Jungle", "Mid", "Bot", "Support"}
+
+const (
+	draftOptions     = 5 // suggestions returned per turn
+	draftTrustGames  = 5 // games on a champion it takes to fully trust its win rate
+	draftWeakBonus   = 0.15
+	draftDenialBonus = 0.1
+)
+
+// BuildDraftProfile collects a team's players with their pools and lane class
+// matchups, and the team's draft tendencies
+func (d *DraftAssistant) BuildDraftProfile(teamID, teamName string, players []*PlayerProfile, seriesStates []*grid.SeriesState) *DraftTeamProfile {
+	profile := &DraftTeamProfile{
+		TeamID:   teamID,
+		TeamName: teamName,
+		Players:  make([]DraftPlayer, 0, len(players)),
+		Phases:   NewDraftPhaseAnalyzer().AnalyzeDraftPhases(teamID, seriesStates),
+	}
+
+	for _, player := range players {
+		if player == nil || len(player.CharacterPool) == 0 {
+			continue
+		}
+		matchups := d.matchupAnalyzer.AnalyzeLoLPlayerMatchups(player.PlayerID, player.Nickname, player.Role, seriesStates)
+		profile.Players = append(profile.Players, DraftPlayer{
+			PlayerID:      player.PlayerID,
+			Nickname:      player.Nickname,
+			Role:          player.Role,
+			Games:         player.GamesPlayed,
+			Pool:          player.CharacterPool,
+			WeakAgainst:   matchups.WeakAgainst,
+			StrongAgainst: matchups.StrongAgainst,
+		})
+	}
+
+	sort.SliceStable(profile.Players, func(i, j int) bool {
+		return profile.Players[i].Games > profile.Players[j].Games
+	})
+	return profile
+}
+
+// player is the team's main player in a role, or nil
+func (p *DraftTeamProfile) player(role string) *DraftPlayer {
+	if p == nil {
+		return nil
+	}
+	for i := range p.Players {
+		if p.Players[i].Role == role {
+			return &p.Players[i]
+		}
+	}
+	return nil
+}
+
+// roleFor is the open role a pick fills: the role of a player with the champion in
+// their pool, otherwise the champion's primary position, otherwise the first open role
+func (p *DraftTeamProfile) roleFor(champion string, filled map[string]string) string {
+	if p != nil {
+		for _, player := range p.Players {
+			if _, taken := filled[player.Role]; taken || player.Role == "" {
+				continue
+			}
+			for _, stats := range player.Pool {
+				if stats.Character == champion {
+					return player.Role
+				}
+			}
+		}
+	}
+	if role := GetChampionRole(champion); role != "" {
+		if _, taken := filled[role]; !taken {
+			return role
+		}
+	}
+	for _, role := range lolRoles {
+		if _, taken := filled[role]; !taken {
+			return role
+		}
+	}
+	return ""
+}
+
+// draftBoard is a draft in progress from our point of view
+type draftBoard struct {
+	ourSide string
+	teams   map[string]*DraftTeamProfile // "us" and "them"
+	used    map[string]bool
+	picks   map[string][]string
+	roles   map[string]map[string]string // team -> role -> champion
+}
+
+func newDraftBoard(ours, theirs *DraftTeamProfile, ourSide string) *draftBoard {
+	return &draftBoard{
+		ourSide: ourSide,
+		teams:   map[string]*DraftTeamProfile{"us": ours, "them": theirs},
+		used:    make(map[string]bool),
+		picks:   map[string][]string{"us": {}, "them": {}},
+		roles:   map[string]map[string]string{"us": {}, "them": {}},
+	}
+}
+
+// clone copies the board so a line of the draft can be played out
+func (b *draftBoard) clone() *draftBoard {
+	c := newDraftBoard(b.teams["us"], b.teams["them"], b.ourSide)
+	for key := range b.used {
+		c.used[key] = true
+	}
+	for _, team := range []string{"us", "them"} {
+		c.picks[team] = append(c.picks[team], b.picks[team]...)
+		for role, champion := range b.roles[team] {
+			c.roles[team][role] = champion
+		}
+	}
+	return c
+}
+
+// apply records a pick or ban
+func (b *draftBoard) apply(team, action, champion string) {
+	if champion == "" {
+		return
+	}
+	b.used[strings.ToLower(champion)] = true
+	if action != "pick" {
+		return
+	}
+	b.picks[team] = append(b.picks[team], champion)
+	if role := b.teams[team].roleFor(champion, b.roles[team]); role != "" {
+		b.roles[team][role] = champion
+	}
+}
+
+// team is "us" or "them" for a draft side
+func (b *draftBoard) team(side string) string {
+	if side == b.ourSide {
+		return "us"
+	}
+	return "them"
+}
+
+// openRoles are a team's roles still to pick
+func (b *draftBoard) openRoles(team string) []string {
+	open := make([]string, 0, len(lolRoles))
+	for _, role := range lolRoles {
+		if _, taken := b.roles[team][role]; !taken {
+			open = append(open, role)
+		}
+	}
+	return open
+}
+
+func otherTeam(team string) string {
+	if team == "us" {
+		return "them"
+	}
+	return "us"
+}
+
+// Advise validates the draft so far and ranks the options for the next turn. On our
+// turns it suggests picks or bans with the opponent's predicted response; on theirs
+// it predicts what they will do.
+func (d *DraftAssistant) Advise(ours, theirs *DraftTeamProfile, ourSide string, turns []DraftTurn) (*DraftAdvice, error) {
+	ourSide = strings.ToLower(ourSide)
+	if ourSide != "blue" && ourSide != "red" {
+		return nil, fmt.Errorf("side must be blue or red, got %q", ourSide)
+	}
+	if len(turns) > len(lolDraftOrder) {
+		return nil, fmt.Errorf("draft has %d turns, a LoL draft has %d", len(turns), len(lolDraftOrder))
+	}
+
+	board := newDraftBoard(ours, theirs, ourSide)
+	for i, turn := range turns {
+		expected := lolDraftOrder[i]
+		team := board.team(expected.side)
+		gotTeam, gotAction := turn.Team, turn.Action
+		if gotTeam == "" {
+			gotTeam = team
+		}
+		if gotAction == "" {
+			gotAction = expected.action
+		}
+		if gotTeam != team || gotAction != expected.action {
+			return nil, fmt.Errorf("turn %d is a %s %s, got a %s %s", i+1, team, expected.action, gotTeam, gotAction)
+		}
+		if turn.Character == "" && expected.action == "pick" {
+			return nil, fmt.Errorf("turn %d: pick has no champion", i+1)
+		}
+		if board.used[strings.ToLower(turn.Character)] {
+			return nil, fmt.Errorf("turn %d: %s is already picked or banned", i+1, turn.Character)
+		}
+		board.apply(team, expected.action, turn.Character)
+	}
+
+	advice := &DraftAdvice{
+		OurArchetype:   draftArchetype(board.picks["us"]),
+		TheirArchetype: draftArchetype(board.picks["them"]),
+		OpenRoles:      board.openRoles("us"),
+		Suggestions:    make([]DraftSuggestion, 0),
+	}
+	if len(turns) == len(lolDraftOrder) {
+		advice.Complete = true
+		return advice, nil
+	}
+
+	index := len(turns)
+	next := lolDraftOrder[index]
+	advice.Turn = index + 1
+	advice.Team = board.team(next.side)
+	advice.Action = next.action
+	advice.Phase = next.phase
+	if next.action == "pick" {
+		advice.Slot = lolPickSlot(next.side, len(board.picks[advice.Team])+1)
+	}
+
+	options := d.rank(board, advice.Team, next)
+	if advice.Team == "them" {
+		advice.TheirLikely = draftResponses(options, advice.Turn, next.action)
+		return advice, nil
+	}
+
+	for _, option := range options {
+		line := board.clone()
+		line.apply("us", next.action, option.character)
+		advice.Suggestions = append(advice.Suggestions, DraftSuggestion{
+			Character:         option.character,
+			Role:              option.role,
+			Score:             option.score,
+			Rationale:         option.reasons,
+			PredictedResponse: d.predictResponse(line, index+1),
+		})
+	}
+	return advice, nil
+}
+
+// predictResponse plays the draft forward from a turn with each side's top option
+// and returns the opponent's actions until it is our turn again
+func (d *DraftAssistant) predictResponse(board *draftBoard, from int) []DraftResponse {
+	responses := make([]DraftResponse, 0)
+	for i := from; i < len(lolDraftOrder); i++ {
+		turn := lolDraftOrder[i]
+		team := board.team(turn.side)
+		if team == "us" && len(responses) > 0 {
+			break
+		}
+		options := d.rank(board, team, turn)
+		if len(options) == 0 {
+			break
+		}
+		if team == "them" {
+			responses = append(responses, draftResponses(options, i+1, turn.action)[0])
+		}
+		board.apply(team, turn.action, options[0].character)
+	}
+	return responses
+}
+
+// draftCandidate is a scored pick or ban
+type draftCandidate struct {
+	character string
+	role      string
+	score     float64
+	reasons   []string
+}
+
+// rank scores every available pick or ban for a team's turn, best first
+func (d *DraftAssistant) rank(board *draftBoard, team string, turn lolDraftTurn) []draftCandidate {
+	candidates := make(map[string]*draftCandidate)
+	if turn.action == "pick" {
+		scorePicks(board, team, turn, candidates)
+	} else {
+		scoreBans(board, team, turn, candidates)
+	}
+
+	ranked := make([]draftCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		ranked = append(ranked, *c)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].character < ranked[j].character
+	})
+	if len(ranked) > draftOptions {
+		ranked = ranked[:draftOptions]
+	}
+	return ranked
+}
+
+// candidate returns the scored entry for a champion, creating it
+func candidate(candidates map[string]*draftCandidate, champion, role string) *draftCandidate {
+	c := candidates[champion]
+	if c == nil {
+		c = &draftCandidate{character: champion, role: role, reasons: make([]string, 0)}
+		candidates[champion] = c
+	}
+	return c
+}
+
+// comfort is how much a player's record on a champion is worth, shrunk on small samples
+func comfort(stats CharacterStats) float64 {
+	trust := float64(min(stats.GamesPlayed, draftTrustGames)) / draftTrustGames
+	return trust * (0.3 + stats.WinRate - 0.5)
+}
+
+// scorePicks scores the champions in the pools of a team's players whose roles are open
+func scorePicks(board *draftBoard, team string, turn lolDraftTurn, candidates map[string]*draftCandidate) {
+	own, other := board.teams[team], board.teams[otherTeam(team)]
+	ownPicks := board.picks[team]
+	currentArchetype := draftArchetype(ownPicks)
+	slot := lolPickSlot(turn.side, len(ownPicks)+1)
+
+	for _, role := range board.openRoles(team) {
+		player := own.player(role)
+		laneOpponent := other.player(role)
+		enemy := board.roles[otherTeam(team)][role]
+
+		// Once the player's pool is used up, or without a player for the role, any
+		// champion for the role is scored on matchups alone
+		options := make([]CharacterStats, 0)
+		if player != nil {
+			for _, stats := range player.Pool {
+				if !board.used[strings.ToLower(stats.Character)] {
+					options = append(options, stats)
+				}
+			}
+		}
+		fromPool := len(options) > 0
+		if !fromPool {
+			for _, champion := range sortedKeys(ChampionDatabase) {
+				if !board.used[strings.ToLower(champion)] && GetChampionRole(champion) == role {
+					options = append(options, CharacterStats{Character: champion})
+				}
+			}
+		}
+
+		for _, stats := range options {
+			champion := stats.Character
+			if candidates[champion] != nil {
+				continue
+			}
+			c := candidate(candidates, champion, role)
+			class := GetClassCategory(champion)
+
+			if fromPool {
+				c.score += comfort(stats)
+				c.reasons = append(c.reasons, fmt.Sprintf("%s's pool: %d games, %.0f%% win rate",
+					player.Nickname, stats.GamesPlayed, stats.WinRate*100))
+			} else if player != nil {
+				c.reasons = append(c.reasons, fmt.Sprintf("Outside %s's pool", player.Nickname))
+			} else {
+				c.reasons = append(c.reasons, fmt.Sprintf("No %s player data", role))
+			}
+
+			// Lane matchup against their pick in the role
+			if enemy != "" && class != "unknown" {
+				enemyClass := GetClassCategory(enemy)
+				if mod := GetMatchupModifier(class, enemyClass); mod != 0 {
+					c.score += mod
+					verdict := "Favoured"
+					if mod < 0 {
+						verdict = "Unfavoured"
+					}
+					c.reasons = append(c.reasons, fmt.Sprintf("%s into %s (%s vs %s)", verdict, enemy, class, enemyClass))
+				}
+			}
+
+			// Their laner's class weaknesses
+			if laneOpponent != nil {
+				for _, weak := range laneOpponent.WeakAgainst {
+					if weak.VsCharacter == class {
+						c.score += draftWeakBonus
+						c.reasons = append(c.reasons, fmt.Sprintf("%s struggles against %ss (%.1f KDA, %.0f%% win rate over %d games)",
+							laneOpponent.Nickname, class, weak.KDA, weak.WinRate*100, weak.GamesPlayed))
+					}
+				}
+				for _, strong := range laneOpponent.StrongAgainst {
+					if strong.VsCharacter == class {
+						c.score -= draftWeakBonus / 2
+						c.reasons = append(c.reasons, fmt.Sprintf("%s does well against %ss (%.1f KDA over %d games)",
+							laneOpponent.Nickname, class, strong.KDA, strong.GamesPlayed))
+					}
+				}
+			}
+
+			// Taking it away from them
+			if other != nil {
+				for _, theirs := range other.Players {
+					for _, s := range theirs.Pool {
+						if s.Character == champion && s.GamesPlayed >= 2 {
+							c.score += draftDenialBonus * float64(min(s.GamesPlayed, draftTrustGames)) / draftTrustGames
+							c.reasons = append(c.reasons, fmt.Sprintf("Also denies it to %s (%d games)", theirs.Nickname, s.GamesPlayed))
+						}
+					}
+				}
+			}
+
+			// Composition
+			if len(ownPicks) >= 2 {
+				archetype := draftArchetype(append(append([]string{}, ownPicks...), champion))
+				if archetype == currentArchetype && archetype != "balanced" {
+					c.score += 0.05
+					c.reasons = append(c.reasons, fmt.Sprintf("Keeps the %s composition", archetype))
+				} else if archetype != currentArchetype {
+					c.reasons = append(c.reasons, fmt.Sprintf("Turns the composition %s", archetype))
+				}
+			}
+
+			// The team's own first-rotation habits, on its B1 or R1-R2 picks
+			if (slot == "B1" || slot == "R1-R2") && own != nil && own.Phases != nil {
+				for _, p := range own.Phases.FirstRotation {
+					if p.Character == champion && p.GamesPlayed >= 2 {
+						c.score += p.Rate / 2
+						c.reasons = append(c.reasons, fmt.Sprintf("%s first-rotation pick in %.0f%% of drafts", own.TeamName, p.Rate*100))
+					}
+				}
+			}
+		}
+	}
+}
+
+// scoreBans scores the other team's champions in their open roles, plus their draft
+// habits: first-rotation picks in ban phase 1 and counter-pick champions in phase 2
+func scoreBans(board *draftBoard, team string, turn lolDraftTurn, candidates map[string]*draftCandidate) {
+	own, other := board.teams[team], board.teams[otherTeam(team)]
+	if other == nil {
+		return
+	}
+
+	for _, role := range board.openRoles(otherTeam(team)) {
+		player := other.player(role)
+		if player == nil {
+			continue
+		}
+		ourLaner := own.player(role)
+		ourPick := board.roles[team][role]
+
+		for _, stats := range player.Pool {
+			champion := stats.Character
+			if board.used[strings.ToLower(champion)] || candidates[champion] != nil {
+				continue
+			}
+			c := candidate(candidates, champion, role)
+			class := GetClassCategory(champion)
+
+			c.score += comfort(stats)
+			c.reasons = append(c.reasons, fmt.Sprintf("%s's pool: %d games, %.0f%% win rate",
+				player.Nickname, stats.GamesPlayed, stats.WinRate*100))
+
+			// Threat to our pick in the role
+			if ourPick != "" && class != "unknown" {
+				ourClass := GetClassCategory(ourPick)
+				if mod := GetMatchupModifier(class, ourClass); mod > 0 {
+					c.score += mod
+					c.reasons = append(c.reasons, fmt.Sprintf("Counters %s (%s vs %s)", ourPick, class, ourClass))
+				}
+			}
+
+			// Our laner's class weaknesses
+			if ourLaner != nil {
+				for _, weak := range ourLaner.WeakAgainst {
+					if weak.VsCharacter == class {
+						c.score += draftWeakBonus
+						c.reasons = append(c.reasons, fmt.Sprintf("%s struggles against %ss (%.1f KDA over %d games)",
+							ourLaner.Nickname, class, weak.KDA, weak.GamesPlayed))
+					}
+				}
+			}
+
+			if turn.phase == BanPhaseTwo && other.Phases != nil && role == other.Phases.CounterPickRole {
+				c.score += draftDenialBonus
+				c.reasons = append(c.reasons, fmt.Sprintf("%s leave %s for the last pick", other.TeamName, role))
+			}
+		}
+	}
+
+	if other.Phases != nil && turn.phase == BanPhaseOne {
+		for _, p := range other.Phases.FirstRotation {
+			if board.used[strings.ToLower(p.Character)] || p.GamesPlayed < 2 {
+				continue
+			}
+			c := candidate(candidates, p.Character, GetChampionRole(p.Character))
+			c.score += p.Rate / 2
+			c.reasons = append(c.reasons, fmt.Sprintf("%s first-rotation pick in %.0f%% of drafts", other.TeamName, p.Rate*100))
+		}
+	}
+
+	// The banning team's own habits
+	if own != nil && own.Phases != nil {
+		habits := own.Phases.BanPhaseOne
+		if turn.phase == BanPhaseTwo {
+			habits = own.Phases.BanPhaseTwo
+		}
+		for _, p := range habits {
+			if c := candidates[p.Character]; c != nil && p.GamesPlayed >= 2 {
+				c.score += p.Rate / 2
+				c.reasons = append(c.reasons, fmt.Sprintf("%s ban it in %.0f%% of drafts", own.TeamName, p.Rate*100))
+			}
+		}
+	}
+}
+
+// draftResponses turns ranked options into predictions with likelihoods
+func draftResponses(options []draftCandidate, turn int, action string) []DraftResponse {
+	responses := make([]DraftResponse, 0, len(options))
+	if len(options) == 0 {
+		return responses
+	}
+	floor := options[len(options)-1].score
+	total := 0.0
+	for _, o := range options {
+		total += o.score - floor + 0.05
+	}
+	for _, o := range options {
+		reason := ""
+		if len(o.reasons) > 0 {
+			reason = o.reasons[0]
+		}
+		responses = append(responses, DraftResponse{
+			Turn:       turn,
+			Action:     action,
+			Character:  o.character,
+			Role:       o.role,
+			Likelihood: (o.score - floor + 0.05) / total,
+			Reason:     reason,
+		})
+	}
+	return responses
+}
+
+// draftArchetype classifies a partial composition, or "" before any picks
+func draftArchetype(champions []string) string {
+	if len(champions) == 0 {
+		return ""
+	}
+	return ClassifyLoLCompositionArchetype(champions)
+}
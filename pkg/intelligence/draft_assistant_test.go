@@ -0,0 +1,87 @@
+package intelligence
+
+import (
+	"fmt"
+	"testing"
+
+	"scout9/pkg/grid"
+)
+
+// seriesStateDraft builds a finished series of LoL games with the series-state
+// spellings of draft actions ("ban"/"pick"); blue picks and bans are in draft order
+func seriesStateDraft(games int, blueBans, bluePicks, redBans, redPicks []string) *grid.SeriesState {
+	series := &grid.SeriesState{ID: "series-1", Finished: true}
+	for g := 1; g <= games; g++ {
+		game := grid.Game{ID: fmt.Sprintf("game-%d", g), Sequence: g, Finished: true}
+		drafted := map[string][]string{"blue": bluePicks, "red": redPicks}
+		bans := map[string][]string{"blue": blueBans, "red": redBans}
+		teamIDs := map[string]string{"blue": "team-1", "red": "team-2"}
+
+		next := map[string]int{}
+		for i, turn := range lolDraftOrder {
+			key := turn.side + turn.action
+			list := bans[turn.side]
+			if turn.action == "pick" {
+				list = drafted[turn.side]
+			}
+			game.DraftActions = append(game.DraftActions, grid.DraftAction{
+				TeamID:        teamIDs[turn.side],
+				Action:        turn.action,
+				CharacterName: list[next[key]],
+				Sequence:      i + 1,
+			})
+			next[key]++
+		}
+
+		for _, side := range []string{"blue", "red"} {
+			team := grid.GameTeam{ID: teamIDs[side], Side: side, Won: side == "blue"}
+			for i, champion := range drafted[side] {
+				team.Players = append(team.Players, grid.GamePlayer{
+					ID:        fmt.Sprintf("%s-player-%d", teamIDs[side], i+1),
+					Character: champion,
+				})
+			}
+			game.Teams = append(game.Teams, team)
+		}
+		series.Games = append(series.Games, game)
+	}
+	return series
+}
+
+func TestBuildDraftProfileReadsSeriesStateDraft(t *testing.T) {
+	series := seriesStateDraft(3,
+		[]string{"Azir", "Kalista", "Rell", "Ashe", "Poppy"},
+		[]string{"Rumble", "Sejuani", "Orianna", "Jinx", "Rakan"},
+		[]string{"Vi", "Corki", "Nami", "Sion", "Ezreal"},
+		[]string{"Ksante", "Maokai", "Aphelios", "Syndra", "Alistar"},
+	)
+	assistant := NewDraftAssistant()
+
+	profile := assistant.BuildDraftProfile("team-1", "Blue Team", nil, []*grid.SeriesState{series})
+	if profile.Phases == nil {
+		t.Fatal("profile has no draft phases")
+	}
+	if profile.Phases.Games != 3 {
+		t.Fatalf("Phases.Games = %d, want 3", profile.Phases.Games)
+	}
+	if len(profile.Phases.BanPhaseOne) == 0 {
+		t.Error("no phase 1 bans read from the series-state draft")
+	}
+	if len(profile.Phases.FirstRotation) == 0 || profile.Phases.FirstRotation[0].Character != "Rumble" {
+		t.Errorf("FirstRotation = %+v, want Rumble first", profile.Phases.FirstRotation)
+	}
+
+	// Banning first against them, their B1 habit is a ban candidate
+	ours := assistant.BuildDraftProfile("team-2", "Red Team", nil, []*grid.SeriesState{series})
+	advice, err := assistant.Advise(ours, profile, "blue", nil)
+	if err != nil {
+		t.Fatalf("Advise: %v", err)
+	}
+	found := false
+	for _, suggestion := range advice.Suggestions {
+		found = found || suggestion.Character == "Rumble"
+	}
+	if !found {
+		t.Errorf("ban suggestions %+v do not include their first-rotation pick Rumble", advice.Suggestions)
+	}
+}
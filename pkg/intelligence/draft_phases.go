@@ -0,0 +1,502 @@
+package intelligence
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"scout9/pkg/grid"
+)
+
+// DraftPhaseAnalyzer models LoL drafts by phase. Each team's actions are read in
+// their own order: bans 1-3 are ban phase 1 and bans 4-5 ban phase 2; blue picks
+// B1, B2-B3 and B4-B5, red picks R1-R2, R3, R4 and R5.
+type DraftPhaseAnalyzer struct{}
+
+// NewDraftPhaseAnalyzer creates a new draft phase analyzer
+func NewDraftPhaseAnalyzer() *DraftPhaseAnalyzer {
+	return &DraftPhaseAnalyzer{}
+}
+
+// Draft slots in pick order per side
+var (
+	blueDraftSlots = []string{"B1", "B2-B3", "B2-B3", "B4-B5", "B4-B5"}
+	redDraftSlots  = []string{"R1-R2", "R1-R2", "R3", "R4", "R5"}
+)
+
+// Ban phases
+const (
+	BanPhaseOne = "ban1"
+	BanPhaseTwo = "ban2"
+)
+
+// lolPickSlot is the slot of a side's nth pick, counting from 1
+func lolPickSlot(side string, n int) string {
+	slots := blueDraftSlots
+	if strings.EqualFold(side, "red") {
+		slots = redDraftSlots
+	}
+	if n < 1 || n > len(slots) {
+		return ""
+	}
+	return slots[n-1]
+}
+
+// lolBanPhase is the ban phase of a team's nth ban, counting from 1
+func lolBanPhase(n int) string {
+	if n <= 3 {
+		return BanPhaseOne
+	}
+	return BanPhaseTwo
+}
+
+// teamDraft is one team's picks or bans ("pick" or "ban") in draft order
+func teamDraft(actions []grid.DraftAction, teamID, action string) []string {
+	own := make([]grid.DraftAction, 0)
+	for _, a := range actions {
+		if a.TeamID == teamID && a.Action == action && a.CharacterName != "" {
+			own = append(own, a)
+		}
+	}
+	sort.SliceStable(own, func(i, j int) bool { return own[i].Sequence < own[j].Sequence })
+	names := make([]string, len(own))
+	for i, a := range own {
+		names[i] = a.CharacterName
+	}
+	return names
+}
+
+// draftCounter counts champions and the games won with them
+type draftCounter struct {
+	games map[string]int
+	wins  map[string]int
+}
+
+func newDraftCounter() *draftCounter {
+	return &draftCounter{games: make(map[string]int), wins: make(map[string]int)}
+}
+
+func (c *draftCounter) add(champion string, won bool) {
+	c.games[champion]++
+	if won {
+		c.wins[champion]++
+	}
+}
+
+// priorities ranks the counted champions by rate over total games
+func (c *draftCounter) priorities(total, limit int) []DraftPriority {
+	priorities := make([]DraftPriority, 0, len(c.games))
+	if total == 0 {
+		return priorities
+	}
+	for champion, games := range c.games {
+		priorities = append(priorities, DraftPriority{
+			Character:   champion,
+			Rate:        float64(games) / float64(total),
+			WinRate:     float64(c.wins[champion]) / float64(games),
+			GamesPlayed: games,
+		})
+	}
+	sort.Slice(priorities, func(i, j int) bool {
+		if priorities[i].GamesPlayed != priorities[j].GamesPlayed {
+			return priorities[i].GamesPlayed > priorities[j].GamesPlayed
+		}
+		return priorities[i].Character < priorities[j].Character
+	})
+	if len(priorities) > limit {
+		priorities = priorities[:limit]
+	}
+	return priorities
+}
+
+// slotCounter accumulates one draft slot
+type slotCounter struct {
+	games     int
+	champions *draftCounter
+	roles     map[string]int
+	picks     int
+}
+
+// AnalyzeDraftPhases models the team's drafts in every finished game with a recorded draft
+func (d *DraftPhaseAnalyzer) AnalyzeDraftPhases(teamID string, seriesStates []*grid.SeriesState) *DraftPhaseAnalysis {
+	analysis := &DraftPhaseAnalysis{
+		BanPhaseOne:      make([]DraftPriority, 0),
+		BanPhaseTwo:      make([]DraftPriority, 0),
+		BansAgainst:      make([]DraftPriority, 0),
+		FirstRotation:    make([]DraftPriority, 0),
+		BlueSlots:        make([]DraftSlotPicks, 0),
+		RedSlots:         make([]DraftSlotPicks, 0),
+		LastPickRoles:    make(map[string]float64),
+		PhaseTwoBanRoles: make(map[string]float64),
+	}
+
+	playerRoles := lolPlayerRoles(teamID, seriesStates)
+	phaseOne, phaseTwo, against := newDraftCounter(), newDraftCounter(), newDraftCounter()
+	firstRotation := newDraftCounter()
+	slots := make(map[string]*slotCounter)
+	lastPickRoles := make(map[string]int)
+	phaseTwoRoles := make(map[string]int)
+	var phaseTwoBans, phaseTwoTargets int
+
+	for _, series := range seriesStates {
+		// Champions the opponent played anywhere in the series
+		opponentPool := make(map[string]bool)
+		for _, game := range series.Games {
+			for _, team := range game.Teams {
+				if team.ID == teamID {
+					continue
+				}
+				for _, player := range team.Players {
+					if player.Character != "" {
+						opponentPool[player.Character] = true
+					}
+				}
+			}
+		}
+
+		for _, game := range series.Games {
+			if !game.Finished || len(game.DraftActions) == 0 {
+				continue
+			}
+			var ourTeam, enemyTeam *grid.GameTeam
+			for i := range game.Teams {
+				if game.Teams[i].ID == teamID {
+					ourTeam = &game.Teams[i]
+				} else {
+					enemyTeam = &game.Teams[i]
+				}
+			}
+			side := ""
+			if ourTeam != nil {
+				side = strings.ToLower(ourTeam.Side)
+			}
+			if side != "blue" && side != "red" {
+				continue
+			}
+
+			analysis.Games++
+			if side == "blue" {
+				analysis.BlueGames++
+			} else {
+				analysis.RedGames++
+			}
+
+			for i, champion := range teamDraft(game.DraftActions, teamID, "ban") {
+				if lolBanPhase(i+1) == BanPhaseOne {
+					phaseOne.add(champion, ourTeam.Won)
+					continue
+				}
+				phaseTwo.add(champion, ourTeam.Won)
+				phaseTwoBans++
+				if role := GetChampionRole(champion); role != "" {
+					phaseTwoRoles[role]++
+				}
+				if opponentPool[champion] {
+					phaseTwoTargets++
+				}
+			}
+			if enemyTeam != nil {
+				for _, champion := range teamDraft(game.DraftActions, enemyTeam.ID, "ban") {
+					against.add(champion, ourTeam.Won)
+				}
+			}
+
+			seen := make(map[string]bool)
+			picks := teamDraft(game.DraftActions, teamID, "pick")
+			for i, champion := range picks {
+				slot := lolPickSlot(side, i+1)
+				if slot == "" {
+					continue
+				}
+				counter := slots[slot]
+				if counter == nil {
+					counter = &slotCounter{champions: newDraftCounter(), roles: make(map[string]int)}
+					slots[slot] = counter
+				}
+				if !seen[slot] {
+					counter.games++
+					seen[slot] = true
+				}
+				counter.champions.add(champion, ourTeam.Won)
+				if slot == "B1" || slot == "R1-R2" {
+					firstRotation.add(champion, ourTeam.Won)
+				}
+				counter.picks++
+				role := pickRole(ourTeam, champion, playerRoles)
+				if role != "" {
+					counter.roles[role]++
+				}
+				if i == len(picks)-1 && len(picks) == len(blueDraftSlots) && role != "" {
+					lastPickRoles[role]++
+				}
+			}
+		}
+	}
+
+	analysis.BanPhaseOne = phaseOne.priorities(analysis.Games, 10)
+	analysis.BanPhaseTwo = phaseTwo.priorities(analysis.Games, 10)
+	analysis.BansAgainst = against.priorities(analysis.Games, 10)
+	analysis.FirstRotation = firstRotation.priorities(analysis.Games, 10)
+
+	for _, slot := range []string{"B1", "B2-B3", "B4-B5", "R1-R2", "R3", "R4", "R5"} {
+		counter := slots[slot]
+		if counter == nil {
+			continue
+		}
+		picks := DraftSlotPicks{
+			Slot:      slot,
+			Games:     counter.games,
+			Champions: counter.champions.priorities(counter.games, 5),
+			Roles:     make(map[string]float64),
+		}
+		for role, n := range counter.roles {
+			picks.Roles[role] = float64(n) / float64(counter.picks)
+		}
+		if strings.HasPrefix(slot, "B") {
+			analysis.BlueSlots = append(analysis.BlueSlots, picks)
+		} else {
+			analysis.RedSlots = append(analysis.RedSlots, picks)
+		}
+	}
+
+	lastPicks, best := 0, 0
+	for _, n := range lastPickRoles {
+		lastPicks += n
+	}
+	for _, role := range sortedKeys(lastPickRoles) {
+		analysis.LastPickRoles[role] = float64(lastPickRoles[role]) / float64(lastPicks)
+		if lastPickRoles[role] > best {
+			analysis.CounterPickRole, best = role, lastPickRoles[role]
+		}
+	}
+	for role, n := range phaseTwoRoles {
+		analysis.PhaseTwoBanRoles[role] = float64(n) / float64(phaseTwoBans)
+	}
+	if phaseTwoBans > 0 {
+		analysis.PhaseTwoTargetRate = float64(phaseTwoTargets) / float64(phaseTwoBans)
+	}
+
+	return analysis
+}
+
+// Slot returns the picks for a draft slot, or nil
+func (a *DraftPhaseAnalysis) Slot(name string) *DraftSlotPicks {
+	for _, slots := range [][]DraftSlotPicks{a.BlueSlots, a.RedSlots} {
+		for i := range slots {
+			if strings.EqualFold(slots[i].Slot, name) {
+				return &slots[i]
+			}
+		}
+	}
+	return nil
+}
+
+// lastPicks is the champions the team takes with its last picks on either side
+func (a *DraftPhaseAnalysis) lastPicks() map[string]DraftPriority {
+	champions := make(map[string]DraftPriority)
+	for _, slot := range []string{"B4-B5", "R4", "R5"} {
+		picks := a.Slot(slot)
+		if picks == nil {
+			continue
+		}
+		for _, p := range picks.Champions {
+			if existing, ok := champions[p.Character]; !ok || p.GamesPlayed > existing.GamesPlayed {
+				champions[p.Character] = p
+			}
+		}
+	}
+	return champions
+}
+
+// lolPlayerRoles gives each of the team's players the role most of their champions play
+func lolPlayerRoles(teamID string, seriesStates []*grid.SeriesState) map[string]string {
+	counts := make(map[string]map[string]int)
+	for _, series := range seriesStates {
+		for _, game := range series.Games {
+			for _, team := range game.Teams {
+				if team.ID != teamID {
+					continue
+				}
+				for _, player := range team.Players {
+					role := GetChampionRole(player.Character)
+					if role == "" {
+						continue
+					}
+					if counts[player.ID] == nil {
+						counts[player.ID] = make(map[string]int)
+					}
+					counts[player.ID][role]++
+				}
+			}
+		}
+	}
+
+	roles := make(map[string]string)
+	for playerID, byRole := range counts {
+		best := 0
+		for _, role := range sortedKeys(byRole) {
+			if byRole[role] > best {
+				roles[playerID], best = role, byRole[role]
+			}
+		}
+	}
+	return roles
+}
+
+// pickRole is the role a pick was played in: its player's usual role, otherwise
+// the champion's primary position
+func pickRole(team *grid.GameTeam, champion string, playerRoles map[string]string) string {
+	for _, player := range team.Players {
+		if player.Character == champion {
+			if role := playerRoles[player.ID]; role != "" {
+				return role
+			}
+		}
+	}
+	return GetChampionRole(champion)
+}
+
+// Bans a team makes in each phase
+const (
+	phaseOneBanCount = 3
+	phaseTwoBanCount = 2
+)
+
+// PlanBanPhases splits priority bans between the ban phases and adds bans from the
+// opponent's draft order: their first rotation picks go in phase 1, the champions
+// they save for counter-picks in phase 2
+func PlanBanPhases(analysis *DraftPhaseAnalysis, priorityBans []DraftInsight) []BanPhasePlan {
+	if analysis == nil || analysis.Games == 0 {
+		return nil
+	}
+
+	lastPicks := analysis.lastPicks()
+	phases := map[string][]DraftInsight{}
+	seen := make(map[string]bool)
+	add := func(phase string, ban DraftInsight) {
+		key := strings.ToLower(ban.Character)
+		if ban.Character == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		ban.Phase = phase
+		phases[phase] = append(phases[phase], ban)
+	}
+
+	// Recommended bans go where the opponent would pick the champion
+	for _, ban := range priorityBans {
+		if _, counterPick := lastPicks[ban.Character]; counterPick {
+			add(BanPhaseTwo, ban)
+		} else {
+			add(BanPhaseOne, ban)
+		}
+	}
+
+	for _, pick := range analysis.FirstRotation {
+		if pick.GamesPlayed < 2 || pick.Rate < 0.25 {
+			continue
+		}
+		add(BanPhaseOne, DraftInsight{
+			Text: fmt.Sprintf("Ban %s in phase 1 - their first-rotation pick in %d of %d games (%.0f%% win rate)",
+				pick.Character, pick.GamesPlayed, analysis.Games, pick.WinRate*100),
+			Character:  pick.Character,
+			WinRate:    pick.WinRate,
+			SampleSize: pick.GamesPlayed,
+			Priority:   2,
+		})
+	}
+
+	if role := analysis.CounterPickRole; role != "" {
+		for _, champion := range sortedKeys(lastPicks) {
+			pick := lastPicks[champion]
+			if pick.GamesPlayed < 2 || GetChampionRole(champion) != role {
+				continue
+			}
+			add(BanPhaseTwo, DraftInsight{
+				Text: fmt.Sprintf("Ban %s in phase 2 - they leave %s for the last pick and counter-pick %s (%d games)",
+					champion, role, champion, pick.GamesPlayed),
+				Character:  champion,
+				WinRate:    pick.WinRate,
+				SampleSize: pick.GamesPlayed,
+				Priority:   2,
+			})
+		}
+	}
+
+	plans := make([]BanPhasePlan, 0, 2)
+	for _, phase := range []struct {
+		name, label string
+		limit       int
+	}{
+		{BanPhaseOne, "Ban phase 1 - deny their first-rotation priorities", phaseOneBanCount},
+		{BanPhaseTwo, "Ban phase 2 - deny their counter-picks", phaseTwoBanCount},
+	} {
+		bans := phases[phase.name]
+		sort.SliceStable(bans, func(i, j int) bool { return bans[i].Priority < bans[j].Priority })
+		if len(bans) > phase.limit {
+			bans = bans[:phase.limit]
+		}
+		if len(bans) > 0 {
+			plans = append(plans, BanPhasePlan{Phase: phase.name, Label: phase.label, Bans: bans})
+		}
+	}
+	return plans
+}
+
+// GenerateDraftPhaseInsights describes first picks per side, counter-pick roles and
+// phase 2 ban targeting
+// Example: "Blue side B1: Azir (60%), Rumble (20%)"
+func GenerateDraftPhaseInsights(analysis *DraftPhaseAnalysis) []CompositionInsight {
+	insights := make([]CompositionInsight, 0)
+	if analysis == nil || analysis.Games == 0 {
+		return insights
+	}
+
+	for _, slot := range []struct{ name, side string }{{"B1", "Blue"}, {"R1-R2", "Red"}} {
+		picks := analysis.Slot(slot.name)
+		if picks == nil || picks.Games < 2 {
+			continue
+		}
+		names := make([]string, 0, 3)
+		characters := make([]string, 0, 3)
+		for i, p := range picks.Champions {
+			if i >= 3 {
+				break
+			}
+			names = append(names, fmt.Sprintf("%s (%.0f%%)", p.Character, p.Rate*100))
+			characters = append(characters, p.Character)
+		}
+		insights = append(insights, CompositionInsight{
+			Text:        fmt.Sprintf("%s side %s: %s", slot.side, slot.name, strings.Join(names, ", ")),
+			Characters:  characters,
+			GamesPlayed: picks.Games,
+		})
+	}
+
+	if role := analysis.CounterPickRole; role != "" && analysis.LastPickRoles[role] >= 0.4 {
+		insights = append(insights, CompositionInsight{
+			Text:        fmt.Sprintf("Leaves %s for the last pick in %.0f%% of drafts - expect a %s counter-pick", role, analysis.LastPickRoles[role]*100, role),
+			Characters:  []string{},
+			Frequency:   analysis.LastPickRoles[role],
+			GamesPlayed: analysis.Games,
+		})
+	}
+
+	if len(analysis.PhaseTwoBanRoles) > 0 {
+		role, best := "", 0.0
+		for _, r := range sortedKeys(analysis.PhaseTwoBanRoles) {
+			if analysis.PhaseTwoBanRoles[r] > best {
+				role, best = r, analysis.PhaseTwoBanRoles[r]
+			}
+		}
+		insights = append(insights, CompositionInsight{
+			Text: fmt.Sprintf("Phase 2 bans: %.0f%% hit champions their opponent played in the series, %.0f%% aimed at %s",
+				analysis.PhaseTwoTargetRate*100, best*100, role),
+			Characters:  []string{},
+			Frequency:   analysis.PhaseTwoTargetRate,
+			GamesPlayed: analysis.Games,
+		})
+	}
+
+	return insights
+}
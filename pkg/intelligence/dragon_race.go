@@ -0,0 +1,318 @@
+package intelligence
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"scout9/pkg/grid"
+)
+
+// DragonRaceAnalyzer follows each LoL game's dragon race: the order of elemental
+// dragons, who reached soul and how soul point was played, and elder fights
+type DragonRaceAnalyzer struct{}
+
+// NewDragonRaceAnalyzer creates a new dragon race analyzer
+func NewDragonRaceAnalyzer() *DragonRaceAnalyzer {
+	return &DragonRaceAnalyzer{}
+}
+
+const (
+	soulDragons   = 4     // elemental dragons a team needs for soul
+	soulRiftAfter = 2     // the rift, and so the soul type, is set by the dragon after this one
+	elderFightMs  = 30000 // kills this close to an elder take are its fight
+)
+
+// AnalyzeDragonRaces builds the dragon race of every finished game and aggregates them
+func (d *DragonRaceAnalyzer) AnalyzeDragonRaces(
+	teamID string,
+	teamName string,
+	seriesStates []*grid.SeriesState,
+	events map[string]*grid.LoLEventData,
+) *DragonRaceAnalysis {
+	analysis := &DragonRaceAnalysis{
+		ByOrder:     make(map[int]map[string]*DragonRecord),
+		ByType:      make(map[string]*DragonRecord),
+		SoulsByType: make(map[string]*DragonRecord),
+		Races:       make([]DragonRace, 0),
+	}
+
+	for _, series := range seriesStates {
+		eventData := events[series.ID]
+		if eventData == nil || len(eventData.DragonKills) == 0 {
+			continue
+		}
+
+		for _, game := range series.Games {
+			if !game.Finished {
+				continue
+			}
+			var ourTeam *grid.GameTeam
+			for i := range game.Teams {
+				if game.Teams[i].ID == teamID || (teamName != "" && game.Teams[i].Name == teamName) {
+					ourTeam = &game.Teams[i]
+				}
+			}
+			if ourTeam == nil {
+				continue
+			}
+
+			var dragons []grid.DragonKillEvent
+			for _, dragon := range eventData.DragonKills {
+				if dragon.GameID == game.ID && dragon.TeamID != "" {
+					dragons = append(dragons, dragon)
+				}
+			}
+			if len(dragons) == 0 {
+				continue
+			}
+
+			var kills []grid.KillEvent
+			for _, kill := range eventData.Kills {
+				if kill.GameID == game.ID {
+					kills = append(kills, kill)
+				}
+			}
+			analysis.Races = append(analysis.Races, buildDragonRace(series.ID, game.ID, ourTeam, dragons, kills))
+		}
+	}
+
+	analysis.aggregate()
+	return analysis
+}
+
+// buildDragonRace replays a game's dragons in order
+func buildDragonRace(seriesID, gameID string, ourTeam *grid.GameTeam, dragons []grid.DragonKillEvent, kills []grid.KillEvent) DragonRace {
+	race := DragonRace{
+		SeriesID: seriesID,
+		GameID:   gameID,
+		Won:      ourTeam.Won,
+		Dragons:  make([]DragonTake, 0, len(dragons)),
+		Elders:   make([]ElderFight, 0),
+	}
+	side := func(id string) string {
+		if id == ourTeam.ID {
+			return "us"
+		}
+		return "them"
+	}
+
+	sort.SliceStable(dragons, func(i, j int) bool { return dragons[i].GameTime < dragons[j].GameTime })
+	counts := map[string]int{}
+	for _, dragon := range dragons {
+		takenBy := side(dragon.TeamID)
+
+		if dragon.DragonType == "elder" {
+			fight := ElderFight{
+				GameTime: dragon.GameTime / 1000,
+				TakenBy:  takenBy,
+				WonGame:  ourTeam.Won,
+			}
+			for _, kill := range kills {
+				if gap := kill.GameTime - dragon.GameTime; gap < -elderFightMs || gap > elderFightMs {
+					continue
+				}
+				if kill.KillerTeamID == ourTeam.ID {
+					fight.OurKills++
+				} else if kill.VictimTeamID == ourTeam.ID {
+					fight.TheirKills++
+				}
+			}
+			switch {
+			case fight.OurKills > fight.TheirKills:
+				fight.Result = "won"
+			case fight.OurKills < fight.TheirKills:
+				fight.Result = "lost"
+			default:
+				fight.Result = "even"
+			}
+			race.Elders = append(race.Elders, fight)
+			continue
+		}
+
+		take := DragonTake{
+			Number:   len(race.Dragons) + 1,
+			Type:     dragon.DragonType,
+			TakenBy:  takenBy,
+			GameTime: dragon.GameTime / 1000,
+		}
+		switch {
+		case counts["us"] == soulDragons-1 && counts["them"] == soulDragons-1:
+			take.SoulPoint = "both"
+		case counts["us"] == soulDragons-1:
+			take.SoulPoint = "us"
+		case counts["them"] == soulDragons-1:
+			take.SoulPoint = "them"
+		}
+		if take.Number == soulRiftAfter+1 {
+			race.SoulType = take.Type
+		}
+		race.Dragons = append(race.Dragons, take)
+
+		counts[takenBy]++
+		if counts[takenBy] == soulDragons && race.SoulTakenBy == "" {
+			race.SoulTakenBy = takenBy
+			race.SoulTime = take.GameTime
+		}
+	}
+	if race.SoulTakenBy == "" {
+		race.SoulType = ""
+	}
+
+	return race
+}
+
+// aggregate fills the dragon, soul and elder records
+func (a *DragonRaceAnalysis) aggregate() {
+	a.Games = len(a.Races)
+	var soulWins, elderFightWins, elderGameWins int
+
+	for _, race := range a.Races {
+		for _, take := range race.Dragons {
+			if a.ByOrder[take.Number] == nil {
+				a.ByOrder[take.Number] = make(map[string]*DragonRecord)
+			}
+			for _, record := range []*DragonRecord{&a.Dragons, dragonRecord(a.ByType, take.Type), dragonRecord(a.ByOrder[take.Number], take.Type)} {
+				record.add(take.TakenBy)
+			}
+
+			if take.SoulPoint == "us" || take.SoulPoint == "both" {
+				a.SoulChances++
+				if take.TakenBy == "us" {
+					a.SoulCloses++
+				}
+			}
+			if take.SoulPoint == "them" || take.SoulPoint == "both" {
+				a.SoulThreats++
+				if take.TakenBy == "us" {
+					a.SoulDenies++
+				}
+			}
+		}
+
+		if race.SoulTakenBy != "" {
+			a.Souls.add(race.SoulTakenBy)
+			dragonRecord(a.SoulsByType, race.SoulType).add(race.SoulTakenBy)
+			if race.SoulTakenBy == "us" && race.Won {
+				soulWins++
+			}
+		}
+
+		for _, elder := range race.Elders {
+			a.Elders.add(elder.TakenBy)
+			if elder.TakenBy != "us" {
+				continue
+			}
+			if elder.Result == "won" {
+				elderFightWins++
+			}
+			if elder.WonGame {
+				elderGameWins++
+			}
+		}
+	}
+
+	if a.Souls.Taken > 0 {
+		a.SoulWinRate = float64(soulWins) / float64(a.Souls.Taken)
+	}
+	if a.SoulChances > 0 {
+		a.SoulCloseRate = float64(a.SoulCloses) / float64(a.SoulChances)
+	}
+	if a.SoulThreats > 0 {
+		a.SoulDenyRate = float64(a.SoulDenies) / float64(a.SoulThreats)
+	}
+	if a.Elders.Taken > 0 {
+		a.ElderFightWinRate = float64(elderFightWins) / float64(a.Elders.Taken)
+		a.ElderGameWinRate = float64(elderGameWins) / float64(a.Elders.Taken)
+	}
+}
+
+func dragonRecord(records map[string]*DragonRecord, key string) *DragonRecord {
+	if records[key] == nil {
+		records[key] = &DragonRecord{}
+	}
+	return records[key]
+}
+
+func (r *DragonRecord) add(takenBy string) {
+	if takenBy == "us" {
+		r.Taken++
+	} else {
+		r.Conceded++
+	}
+	r.TakeRate = float64(r.Taken) / float64(r.Taken+r.Conceded)
+}
+
+// dragonOrdinals names the dragon numbers that tendencies are reported for
+var dragonOrdinals = map[int]string{1: "1st", 2: "2nd", 3: "3rd"}
+
+// GenerateDragonRaceInsights describes dragon tendencies by order and type, soul
+// point behavior and elder fights
+// Example: "Gives up the 1st dragon when it is Infernal (0 of 4 taken)"
+func GenerateDragonRaceInsights(analysis *DragonRaceAnalysis) []StrategyInsight {
+	insights := make([]StrategyInsight, 0)
+	if analysis == nil || analysis.Games == 0 {
+		return insights
+	}
+
+	for _, number := range []int{1, 2, 3} {
+		for _, dragonType := range sortedKeys(analysis.ByOrder[number]) {
+			record := analysis.ByOrder[number][dragonType]
+			total := record.Taken + record.Conceded
+			if dragonType == "" || dragonType == "unknown" || total < 3 {
+				continue
+			}
+			var text string
+			switch {
+			case record.TakeRate >= 0.75:
+				text = "Secures"
+			case record.TakeRate <= 0.25:
+				text = "Gives up"
+			default:
+				continue
+			}
+			insights = append(insights, StrategyInsight{
+				Text: fmt.Sprintf("%s the %s dragon when it is %s (%d of %d taken)",
+					text, dragonOrdinals[number], strings.ToUpper(dragonType[:1])+dragonType[1:], record.Taken, total),
+				Metric:     fmt.Sprintf("dragon_%d_%s_take_rate", number, dragonType),
+				Value:      record.TakeRate,
+				SampleSize: total,
+				Context:    "dragon race",
+			})
+		}
+	}
+
+	if total := analysis.Souls.Taken + analysis.Souls.Conceded; total >= 3 {
+		insights = append(insights, StrategyInsight{
+			Text: fmt.Sprintf("Secures dragon soul in %.0f%% of soul races (%d of %d), winning %.0f%% of those games",
+				analysis.Souls.TakeRate*100, analysis.Souls.Taken, total, analysis.SoulWinRate*100),
+			Metric:     "soul_rate",
+			Value:      analysis.Souls.TakeRate,
+			SampleSize: total,
+			Context:    "dragon soul",
+		})
+	}
+	if analysis.SoulThreats >= 3 {
+		insights = append(insights, StrategyInsight{
+			Text: fmt.Sprintf("Denies %.0f%% of opponent soul point dragons (%d of %d)",
+				analysis.SoulDenyRate*100, analysis.SoulDenies, analysis.SoulThreats),
+			Metric:     "soul_deny_rate",
+			Value:      analysis.SoulDenyRate,
+			SampleSize: analysis.SoulThreats,
+			Context:    "dragon soul",
+		})
+	}
+
+	if total := analysis.Elders.Taken + analysis.Elders.Conceded; total >= 2 {
+		insights = append(insights, StrategyInsight{
+			Text: fmt.Sprintf("Takes %.0f%% of elder dragons (%d of %d), winning %.0f%% of elder fights",
+				analysis.Elders.TakeRate*100, analysis.Elders.Taken, total, analysis.ElderFightWinRate*100),
+			Metric:     "elder_rate",
+			Value:      analysis.Elders.TakeRate,
+			SampleSize: total,
+			Context:    "late game",
+		})
+	}
+
+	return insights
+}
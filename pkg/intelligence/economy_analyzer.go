@@ -2,6 +2,7 @@ package intelligence
 
 import (
 	"fmt"
+	"strings"
 
 	"scout9/pkg/grid"
 )
@@ -14,32 +15,160 @@ func NewEconomyAnalyzer() *EconomyAnalyzer {
 	return &EconomyAnalyzer{}
 }
 
-// AnalyzeEconomyRounds analyzes team performance by economy state
+// Team loadout thresholds for buy classification (five players)
+const (
+	ecoLoadoutMax   = 10000 // below 2000 per player
+	forceLoadoutMax = 20000 // 2000-4000 per player; above is a full buy
+)
+
+// AnalyzeEconomyRounds analyzes team performance by economy state.
+// Each round is classified from the teams' actual loadout values in the event
+// stream; pistol rounds and rounds without a snapshot are left out.
 // Returns detailed economy analysis with per-map breakdowns
 func (e *EconomyAnalyzer) AnalyzeEconomyRounds(
 	teamID string,
 	teamName string,
 	seriesStates []*grid.SeriesState,
+	events map[string]*grid.VALEventData,
 ) *EconomyAnalysis {
 	analysis := &EconomyAnalysis{
 		TeamID: teamID,
 		ByMap:  make(map[string]*MapEconomyStats),
 	}
 
-	// Aggregate economy stats
-	var (
-		ecoRounds     int
-		ecoWins       int
-		forceRounds   int
-		forceWins     int
-		fullBuyRounds int
-		fullBuyWins   int
-	)
+	rounds := e.aggregateRounds(teamID, teamName, seriesStates, events)
+	total := rounds.total
+
+	// Calculate overall rates
+	if total.ecoRounds > 0 {
+		analysis.EcoRoundWinRate = float64(total.ecoWins) / float64(total.ecoRounds)
+	}
+	analysis.EcoRounds = total.ecoRounds
+
+	if total.forceRounds > 0 {
+		analysis.ForceWinRate = float64(total.forceWins) / float64(total.forceRounds)
+	}
+	analysis.ForceRounds = total.forceRounds
+
+	if total.fullBuyRounds > 0 {
+		analysis.FullBuyWinRate = float64(total.fullBuyWins) / float64(total.fullBuyRounds)
+	}
+	analysis.FullBuyRounds = total.fullBuyRounds
+
+	// Buy matchups
+	analysis.AntiEcoRounds = total.antiEcoRounds
+	analysis.AntiEcoLosses = total.antiEcoLosses
+	if total.antiEcoRounds > 0 {
+		analysis.AntiEcoLossRate = float64(total.antiEcoLosses) / float64(total.antiEcoRounds)
+	}
+	analysis.ForceVsFullRounds = total.forceVsFullRounds
+	analysis.ForceUpsets = total.forceUpsets
+	if total.forceVsFullRounds > 0 {
+		analysis.ForceUpsetRate = float64(total.forceUpsets) / float64(total.forceVsFullRounds)
+	}
 
-	// Per-map tracking
-	mapEcoData := make(map[string]*mapEconomyAggregator)
+	analysis.AvgLoadoutValue = rounds.avgLoadout()
+	analysis.UnclassifiedRounds = rounds.unclassified
+
+	// Build per-map stats
+	for _, mapName := range sortedKeys(rounds.byMap) {
+		agg := rounds.byMap[mapName]
+		mapStats := &MapEconomyStats{
+			MapName:           mapName,
+			EcoRounds:         agg.ecoRounds,
+			ForceRounds:       agg.forceRounds,
+			FullBuyRounds:     agg.fullBuyRounds,
+			AntiEcoRounds:     agg.antiEcoRounds,
+			AntiEcoLosses:     agg.antiEcoLosses,
+			ForceVsFullRounds: agg.forceVsFullRounds,
+			ForceUpsets:       agg.forceUpsets,
+		}
+
+		if agg.ecoRounds > 0 {
+			mapStats.EcoWinRate = float64(agg.ecoWins) / float64(agg.ecoRounds)
+		}
+		if agg.forceRounds > 0 {
+			mapStats.ForceWinRate = float64(agg.forceWins) / float64(agg.forceRounds)
+		}
+		if agg.fullBuyRounds > 0 {
+			mapStats.FullBuyWinRate = float64(agg.fullBuyWins) / float64(agg.fullBuyRounds)
+		}
+
+		analysis.ByMap[mapName] = mapStats
+	}
+
+	return analysis
+}
+
+// AnalyzeRoundStats returns the team-wide economy round summary used in VALTeamMetrics
+func (e *EconomyAnalyzer) AnalyzeRoundStats(
+	teamID string,
+	teamName string,
+	seriesStates []*grid.SeriesState,
+	events map[string]*grid.VALEventData,
+) *EconomyRoundStats {
+	rounds := e.aggregateRounds(teamID, teamName, seriesStates, events)
+	total := rounds.total
+
+	stats := &EconomyRoundStats{
+		EcoRounds:         total.ecoRounds,
+		EcoWins:           total.ecoWins,
+		ForceRounds:       total.forceRounds,
+		ForceWins:         total.forceWins,
+		FullBuyRounds:     total.fullBuyRounds,
+		FullBuyWins:       total.fullBuyWins,
+		AvgLoadoutValue:   rounds.avgLoadout(),
+		AntiEcoRounds:     total.antiEcoRounds,
+		AntiEcoLosses:     total.antiEcoLosses,
+		ForceVsFullRounds: total.forceVsFullRounds,
+		ForceUpsets:       total.forceUpsets,
+	}
+	if total.ecoRounds > 0 {
+		stats.EcoWinRate = float64(total.ecoWins) / float64(total.ecoRounds)
+	}
+	if total.forceRounds > 0 {
+		stats.ForceWinRate = float64(total.forceWins) / float64(total.forceRounds)
+	}
+	if total.fullBuyRounds > 0 {
+		stats.FullBuyWinRate = float64(total.fullBuyWins) / float64(total.fullBuyRounds)
+	}
+
+	return stats
+}
+
+// economyRounds holds classified round counts for a team
+type economyRounds struct {
+	total        *mapEconomyAggregator
+	byMap        map[string]*mapEconomyAggregator
+	totalLoadout int64
+	unclassified int
+}
+
+// avgLoadout returns the average team loadout over classified rounds
+func (r *economyRounds) avgLoadout() float64 {
+	n := r.total.ecoRounds + r.total.forceRounds + r.total.fullBuyRounds
+	if n == 0 {
+		return 0
+	}
+	return float64(r.totalLoadout) / float64(n)
+}
+
+// aggregateRounds classifies every round from loadout snapshots and counts
+// outcomes overall and per map
+func (e *EconomyAnalyzer) aggregateRounds(
+	teamID string,
+	teamName string,
+	seriesStates []*grid.SeriesState,
+	events map[string]*grid.VALEventData,
+) *economyRounds {
+	rounds := &economyRounds{
+		total: &mapEconomyAggregator{},
+		byMap: make(map[string]*mapEconomyAggregator),
+	}
 
 	for _, series := range seriesStates {
+		economy := indexRoundEconomy(events[series.ID])
+
 		for _, game := range series.Games {
 			if !game.Finished {
 				continue
@@ -50,12 +179,6 @@ func (e *EconomyAnalyzer) AnalyzeEconomyRounds(
 				mapName = "Unknown"
 			}
 
-			// Initialize map aggregator
-			if _, exists := mapEcoData[mapName]; !exists {
-				mapEcoData[mapName] = &mapEconomyAggregator{mapName: mapName}
-			}
-			mapAgg := mapEcoData[mapName]
-
 			// Find our team in this game
 			var ourTeam *grid.GameTeam
 			for i := range game.Teams {
@@ -69,18 +192,21 @@ func (e *EconomyAnalyzer) AnalyzeEconomyRounds(
 				continue
 			}
 
+			gameEconomy := economy.forGame(game.ID, mapName)
+
 			// Analyze rounds from segments
 			for _, segment := range game.Segments {
 				if segment.Type != "round" || !segment.Finished {
 					continue
 				}
 
-				// Find our team in this segment
-				var ourSegTeam *grid.SegmentTeam
+				// Find our team and the opponent in this segment
+				var ourSegTeam, theirSegTeam *grid.SegmentTeam
 				for i := range segment.Teams {
-					if segment.Teams[i].ID == ourTeam.ID || segment.Teams[i].Name == ourTeam.Name {
+					if segment.Teams[i].ID == ourTeam.ID || (ourTeam.Name != "" && segment.Teams[i].Name == ourTeam.Name) {
 						ourSegTeam = &segment.Teams[i]
-						break
+					} else {
+						theirSegTeam = &segment.Teams[i]
 					}
 				}
 
@@ -88,83 +214,37 @@ func (e *EconomyAnalyzer) AnalyzeEconomyRounds(
 					continue
 				}
 
+				// Pistol rounds have a fixed budget and say nothing about buy decisions
 				roundNum := segment.SequenceNumber
-				won := ourSegTeam.Won
+				if roundNum == 1 || roundNum == 13 {
+					continue
+				}
 
-				// Classify round economy based on round number patterns
-				// In pro VALORANT:
-				// - Rounds 1, 13 = Pistol (eco-level)
-				// - Rounds 2, 14 = Usually eco/force after pistol
-				// - Rounds 3, 15 = Usually force if lost pistol, full buy if won
-				// - Other rounds = Typically full buy in pro play
-				economyType := classifyRoundEconomy(roundNum)
-
-				switch economyType {
-				case "eco":
-					ecoRounds++
-					mapAgg.ecoRounds++
-					if won {
-						ecoWins++
-						mapAgg.ecoWins++
-					}
-				case "force":
-					forceRounds++
-					mapAgg.forceRounds++
-					if won {
-						forceWins++
-						mapAgg.forceWins++
-					}
-				case "full":
-					fullBuyRounds++
-					mapAgg.fullBuyRounds++
-					if won {
-						fullBuyWins++
-						mapAgg.fullBuyWins++
+				ourLoadout, ok := gameEconomy[roundNum][ourTeam.ID]
+				if !ok {
+					rounds.unclassified++
+					continue
+				}
+				theirBuy := ""
+				if theirSegTeam != nil {
+					if theirLoadout, ok := gameEconomy[roundNum][theirSegTeam.ID]; ok {
+						theirBuy = classifyRoundEconomy(theirLoadout)
 					}
 				}
-			}
-		}
-	}
-
-	// Calculate overall rates
-	if ecoRounds > 0 {
-		analysis.EcoRoundWinRate = float64(ecoWins) / float64(ecoRounds)
-	}
-	analysis.EcoRounds = ecoRounds
-
-	if forceRounds > 0 {
-		analysis.ForceWinRate = float64(forceWins) / float64(forceRounds)
-	}
-	analysis.ForceRounds = forceRounds
-
-	if fullBuyRounds > 0 {
-		analysis.FullBuyWinRate = float64(fullBuyWins) / float64(fullBuyRounds)
-	}
-	analysis.FullBuyRounds = fullBuyRounds
-
-	// Build per-map stats
-	for mapName, agg := range mapEcoData {
-		mapStats := &MapEconomyStats{
-			MapName:   mapName,
-			EcoRounds: agg.ecoRounds,
-			ForceRounds: agg.forceRounds,
-			FullBuyRounds: agg.fullBuyRounds,
-		}
 
-		if agg.ecoRounds > 0 {
-			mapStats.EcoWinRate = float64(agg.ecoWins) / float64(agg.ecoRounds)
-		}
-		if agg.forceRounds > 0 {
-			mapStats.ForceWinRate = float64(agg.forceWins) / float64(agg.forceRounds)
-		}
-		if agg.fullBuyRounds > 0 {
-			mapStats.FullBuyWinRate = float64(agg.fullBuyWins) / float64(agg.fullBuyRounds)
+				if _, exists := rounds.byMap[mapName]; !exists {
+					rounds.byMap[mapName] = &mapEconomyAggregator{mapName: mapName}
+				}
+				ourBuy := classifyRoundEconomy(ourLoadout)
+				won := ourSegTeam.Won
+				rounds.total.add(ourBuy, theirBuy, won)
+				rounds.byMap[mapName].add(ourBuy, theirBuy, won)
+				rounds.totalLoadout += int64(ourLoadout)
+			}
 		}
-
-		analysis.ByMap[mapName] = mapStats
 	}
 
-	return analysis
+	return rounds
 }
 
 // GenerateEconomyInsights generates hackathon-format economy insights
@@ -232,8 +312,33 @@ func (e *EconomyAnalyzer) GenerateEconomyInsights(analysis *EconomyAnalysis) []E
 		})
 	}
 
+	// Anti-eco losses: dropping full buys to an eco
+	if analysis.AntiEcoRounds >= 5 && analysis.AntiEcoLossRate > 0.20 {
+		insights = append(insights, EconomyInsight{
+			Text: fmt.Sprintf("They lose %.0f%% of full buys against an eco - stack a site and hunt picks on your saves",
+				analysis.AntiEcoLossRate*100),
+			Type:       "anti_eco_weak",
+			Value:      analysis.AntiEcoLossRate,
+			SampleSize: analysis.AntiEcoRounds,
+			Impact:     "MEDIUM",
+		})
+	}
+
+	// Force-buy upsets: winning forces into a full buy
+	if analysis.ForceVsFullRounds >= 3 && analysis.ForceUpsetRate > 0.35 {
+		insights = append(insights, EconomyInsight{
+			Text: fmt.Sprintf("They win %.0f%% of force buys into a full buy - play for trades, not early picks, when they force",
+				analysis.ForceUpsetRate*100),
+			Type:       "force_upset",
+			Value:      analysis.ForceUpsetRate,
+			SampleSize: analysis.ForceVsFullRounds,
+			Impact:     "MEDIUM",
+		})
+	}
+
 	// Per-map insights for significant differences
-	for mapName, mapStats := range analysis.ByMap {
+	for _, mapName := range sortedKeys(analysis.ByMap) {
+		mapStats := analysis.ByMap[mapName]
 		// Map-specific eco weakness
 		if mapStats.EcoRounds >= 2 && mapStats.EcoWinRate < 0.10 {
 			insights = append(insights, EconomyInsight{
@@ -266,36 +371,107 @@ func (e *EconomyAnalyzer) GenerateEconomyInsights(analysis *EconomyAnalysis) []E
 
 // Helper types
 type mapEconomyAggregator struct {
-	mapName       string
-	ecoRounds     int
-	ecoWins       int
-	forceRounds   int
-	forceWins     int
-	fullBuyRounds int
-	fullBuyWins   int
+	mapName           string
+	ecoRounds         int
+	ecoWins           int
+	forceRounds       int
+	forceWins         int
+	fullBuyRounds     int
+	fullBuyWins       int
+	antiEcoRounds     int // our full buy against their eco
+	antiEcoLosses     int
+	forceVsFullRounds int // our force against their full buy
+	forceUpsets       int
 }
 
-// classifyRoundEconomy classifies a round's economy type based on round number
-// In pro VALORANT, economy follows predictable patterns based on round number
-func classifyRoundEconomy(roundNum int) string {
-	// Pistol rounds (rounds 1 and 13)
-	if roundNum == 1 || roundNum == 13 {
-		return "eco"
+// add records one classified round; theirBuy is empty when the opponent's buy is unknown
+func (m *mapEconomyAggregator) add(ourBuy, theirBuy string, won bool) {
+	switch ourBuy {
+	case "eco":
+		m.ecoRounds++
+		if won {
+			m.ecoWins++
+		}
+	case "force":
+		m.forceRounds++
+		if won {
+			m.forceWins++
+		}
+		if theirBuy == "full" {
+			m.forceVsFullRounds++
+			if won {
+				m.forceUpsets++
+			}
+		}
+	case "full":
+		m.fullBuyRounds++
+		if won {
+			m.fullBuyWins++
+		}
+		if theirBuy == "eco" {
+			m.antiEcoRounds++
+			if !won {
+				m.antiEcoLosses++
+			}
+		}
 	}
+}
 
-	// Post-pistol rounds (rounds 2, 3, 14, 15) - typically eco or force
-	if roundNum == 2 || roundNum == 14 {
-		return "eco" // Usually full eco after pistol loss
+// classifyRoundEconomy classifies a team's buy from its loadout value in the round
+func classifyRoundEconomy(teamLoadout int) string {
+	switch {
+	case teamLoadout < ecoLoadoutMax:
+		return "eco"
+	case teamLoadout < forceLoadoutMax:
+		return "force"
+	default:
+		return "full"
 	}
+}
+
+// roundEconomyIndex holds team loadout values by game (or map) and round
+type roundEconomyIndex struct {
+	byGame map[string]map[int]map[string]int
+	byMap  map[string]map[int]map[string]int
+}
 
-	if roundNum == 3 || roundNum == 15 {
-		return "force" // Usually force buy or bonus round
+// indexRoundEconomy indexes the per-round economy snapshots of a series
+func indexRoundEconomy(events *grid.VALEventData) roundEconomyIndex {
+	index := roundEconomyIndex{
+		byGame: make(map[string]map[int]map[string]int),
+		byMap:  make(map[string]map[int]map[string]int),
+	}
+	if events == nil {
+		return index
 	}
 
-	// All other rounds are typically full buys in pro play
-	return "full"
+	put := func(m map[string]map[int]map[string]int, key string, econ grid.RoundEconomy) {
+		if key == "" {
+			return
+		}
+		if m[key] == nil {
+			m[key] = make(map[int]map[string]int)
+		}
+		if m[key][econ.RoundNum] == nil {
+			m[key][econ.RoundNum] = make(map[string]int)
+		}
+		m[key][econ.RoundNum][econ.TeamID] = econ.LoadoutValue
+	}
+	for _, econ := range events.Economy {
+		put(index.byGame, econ.GameID, econ)
+		put(index.byMap, strings.ToLower(econ.MapName), econ)
+	}
+	return index
 }
 
+// forGame returns the round economy of one game, matched by game ID and
+// otherwise by map name
+func (idx roundEconomyIndex) forGame(gameID, mapName string) map[int]map[string]int {
+	if rounds, ok := idx.byGame[gameID]; ok && gameID != "" {
+		return rounds
+	}
+	return idx.byMap[strings.ToLower(mapName)]
+}
 
 // =============================================================================
 // SITE WEAKNESS IDENTIFICATION
@@ -316,8 +492,10 @@ type SiteWeakness struct {
 func IdentifySiteWeaknesses(siteAnalysis map[string]*SiteAnalysis) []SiteWeakness {
 	weaknesses := make([]SiteWeakness, 0)
 
-	for mapName, analysis := range siteAnalysis {
-		for siteName, stats := range analysis.Sites {
+	for _, mapName := range sortedKeys(siteAnalysis) {
+		analysis := siteAnalysis[mapName]
+		for _, siteName := range sortedKeys(analysis.Sites) {
+			stats := analysis.Sites[siteName]
 			// Defense weakness: <40% win rate with ≥3 attempts
 			if stats.DefenseAttempts >= 3 && stats.DefenseWinRate < 0.40 {
 				weaknesses = append(weaknesses, SiteWeakness{
@@ -521,7 +521,8 @@ func (ea *EventAnalyzer) GetMostUsedAbility(analysis *AbilityAnalysis, playerID
 	var maxAbility string
 	var maxCount int
 
-	for abilityID, count := range abilities {
+	for _, abilityID := range sortedKeys(abilities) {
+		count := abilities[abilityID]
 		if count > maxCount {
 			maxCount = count
 			maxAbility = abilityID
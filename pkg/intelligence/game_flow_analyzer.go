@@ -0,0 +1,290 @@
+package intelligence
+
+import (
+	"fmt"
+	"sort"
+
+	"scout9/pkg/grid"
+)
+
+// GameFlowAnalyzer measures how a VALORANT team plays across the game state:
+// halves, overtime, score differential and match points
+type GameFlowAnalyzer struct{}
+
+// NewGameFlowAnalyzer creates a new game flow analyzer
+func NewGameFlowAnalyzer() *GameFlowAnalyzer {
+	return &GameFlowAnalyzer{}
+}
+
+// Regulation format: first to 13, sides swap after round 12, win by two in overtime
+const (
+	halfRounds       = 12
+	regulationRounds = 24
+	roundsToWin      = 13
+
+	// comebackMargin is the round deficit a team must overturn to count as a comeback
+	comebackMargin = 4
+	maxScoreDiff   = 5
+)
+
+func (r *RoundRecord) add(won bool) {
+	r.Rounds++
+	if won {
+		r.Wins++
+	}
+}
+
+func (r *RoundRecord) finish() {
+	if r.Rounds > 0 {
+		r.WinRate = float64(r.Wins) / float64(r.Rounds)
+	}
+}
+
+// winsGame reports whether taking the next round at this score ends the game
+func winsGame(score, other int) bool {
+	return score+1 >= roundsToWin && score+1-other >= 2
+}
+
+// AnalyzeGameFlow replays each game's rounds in sequence with the running score
+func (g *GameFlowAnalyzer) AnalyzeGameFlow(
+	teamID string,
+	teamName string,
+	seriesStates []*grid.SeriesState,
+	events map[string]*grid.VALEventData,
+) *GameFlowAnalysis {
+	analysis := &GameFlowAnalysis{
+		SecondHalfBySide: make(map[string]*RoundRecord),
+		ByScoreDiff:      make(map[int]*RoundRecord),
+	}
+
+	// Rounds of each game in order
+	var games [][]valRound
+	gameIndex := make(map[string]int)
+	for _, round := range collectVALRounds(teamID, teamName, seriesStates, events) {
+		key := round.gameID + "|" + round.mapName
+		i, ok := gameIndex[key]
+		if !ok {
+			i = len(games)
+			gameIndex[key] = i
+			games = append(games, nil)
+		}
+		games[i] = append(games[i], round)
+	}
+
+	for _, rounds := range games {
+		sort.SliceStable(rounds, func(i, j int) bool { return rounds[i].roundNum < rounds[j].roundNum })
+
+		ours, theirs := 0, 0
+		trailed, led := false, false
+		for _, round := range rounds {
+			switch {
+			case round.roundNum <= halfRounds:
+				analysis.FirstHalf.add(round.won)
+			case round.roundNum <= regulationRounds:
+				analysis.SecondHalf.add(round.won)
+				if round.side != "" {
+					if analysis.SecondHalfBySide[round.side] == nil {
+						analysis.SecondHalfBySide[round.side] = &RoundRecord{}
+					}
+					analysis.SecondHalfBySide[round.side].add(round.won)
+				}
+			default:
+				analysis.Overtime.add(round.won)
+			}
+
+			diff := max(-maxScoreDiff, min(maxScoreDiff, ours-theirs))
+			if analysis.ByScoreDiff[diff] == nil {
+				analysis.ByScoreDiff[diff] = &RoundRecord{}
+			}
+			analysis.ByScoreDiff[diff].add(round.won)
+			if winsGame(ours, theirs) {
+				analysis.MatchPoint.add(round.won)
+			}
+			if winsGame(theirs, ours) {
+				analysis.MatchPointDefense.add(round.won)
+			}
+			trailed = trailed || ours-theirs <= -comebackMargin
+			led = led || ours-theirs >= comebackMargin
+
+			if round.won {
+				ours++
+			} else {
+				theirs++
+			}
+		}
+
+		// Game-level results need the full round sequence
+		if max(ours, theirs) < roundsToWin || max(ours, theirs)-min(ours, theirs) < 2 {
+			continue
+		}
+		analysis.Games++
+		won := ours > theirs
+		if ours+theirs > regulationRounds {
+			analysis.OvertimeGames++
+			if won {
+				analysis.OvertimeWins++
+			}
+		}
+		if trailed {
+			analysis.Deficits++
+			if won {
+				analysis.Comebacks++
+			}
+		}
+		if led {
+			analysis.Leads++
+			if !won {
+				analysis.BlownLeads++
+			}
+		}
+	}
+
+	for _, r := range []*RoundRecord{&analysis.FirstHalf, &analysis.SecondHalf, &analysis.Overtime, &analysis.MatchPoint, &analysis.MatchPointDefense} {
+		r.finish()
+	}
+	for _, r := range analysis.SecondHalfBySide {
+		r.finish()
+	}
+	for _, r := range analysis.ByScoreDiff {
+		r.finish()
+	}
+	if analysis.FirstHalf.Rounds > 0 && analysis.SecondHalf.Rounds > 0 {
+		analysis.HalfTimeDelta = analysis.SecondHalf.WinRate - analysis.FirstHalf.WinRate
+	}
+	if analysis.OvertimeGames > 0 {
+		analysis.OvertimeWinRate = float64(analysis.OvertimeWins) / float64(analysis.OvertimeGames)
+	}
+	if analysis.Deficits > 0 {
+		analysis.ComebackRate = float64(analysis.Comebacks) / float64(analysis.Deficits)
+	}
+	if analysis.Leads > 0 {
+		analysis.BlownLeadRate = float64(analysis.BlownLeads) / float64(analysis.Leads)
+	}
+
+	return analysis
+}
+
+// scoreState merges the score differential buckets between from and to, inclusive
+func scoreState(analysis *GameFlowAnalysis, from, to int) RoundRecord {
+	var r RoundRecord
+	for diff := from; diff <= to; diff++ {
+		if b := analysis.ByScoreDiff[diff]; b != nil {
+			r.Rounds += b.Rounds
+			r.Wins += b.Wins
+		}
+	}
+	r.finish()
+	return r
+}
+
+// ComebackTrait labels how a team handles big deficits and big leads.
+// Returns nil until there are at least 3 such games.
+func (f *GameFlowAnalysis) ComebackTrait() *ComebackTrait {
+	if f == nil || f.Deficits+f.Leads < 3 {
+		return nil
+	}
+	trait := &ComebackTrait{
+		Label:         "neutral",
+		ComebackRate:  f.ComebackRate,
+		BlownLeadRate: f.BlownLeadRate,
+		SampleSize:    f.Deficits + f.Leads,
+		Evidence: fmt.Sprintf("Won %d of %d games after trailing by %d+, lost %d of %d after leading by %d+",
+			f.Comebacks, f.Deficits, comebackMargin, f.BlownLeads, f.Leads, comebackMargin),
+	}
+
+	switch {
+	case f.Deficits >= 3 && f.ComebackRate >= 0.3:
+		trait.IsComebackTeam = true
+		trait.Label = "comeback team"
+	case f.Leads >= 3 && f.BlownLeadRate >= 0.3:
+		trait.Label = "fragile leads"
+	case f.Deficits >= 3 && f.ComebackRate < 0.15 && f.BlownLeadRate <= 0.1:
+		trait.Label = "front-runner"
+	}
+	return trait
+}
+
+// GenerateGameFlowInsights turns game-state performance into timing patterns
+// Example: "When down 3+ rounds they still win 48% of rounds - a comeback team, never relax with a lead"
+func GenerateGameFlowInsights(analysis *GameFlowAnalysis) []StrategyInsight {
+	insights := make([]StrategyInsight, 0)
+	if analysis == nil {
+		return insights
+	}
+
+	if analysis.FirstHalf.Rounds >= 24 && analysis.SecondHalf.Rounds >= 24 {
+		delta := analysis.HalfTimeDelta
+		if delta >= 0.08 || delta <= -0.08 {
+			verdict := "they adapt well at half time"
+			if delta < 0 {
+				verdict = "their half-time adjustments don't land"
+			}
+			insights = append(insights, StrategyInsight{
+				Text: fmt.Sprintf("Second half: %.0f%% round win rate vs %.0f%% in the first half - %s",
+					analysis.SecondHalf.WinRate*100, analysis.FirstHalf.WinRate*100, verdict),
+				Metric:     "half_time_delta",
+				Value:      delta,
+				SampleSize: analysis.SecondHalf.Rounds,
+				Context:    "half time",
+			})
+		}
+	}
+
+	if analysis.OvertimeGames >= 2 {
+		insights = append(insights, StrategyInsight{
+			Text: fmt.Sprintf("Overtime: won %d of %d games (%.0f%% of overtime rounds)",
+				analysis.OvertimeWins, analysis.OvertimeGames, analysis.Overtime.WinRate*100),
+			Metric:     "overtime_win_rate",
+			Value:      analysis.OvertimeWinRate,
+			SampleSize: analysis.OvertimeGames,
+			Context:    "overtime",
+		})
+	}
+
+	if trailing := scoreState(analysis, -maxScoreDiff, -3); trailing.Rounds >= 10 {
+		verdict := "they fold when behind"
+		if trailing.WinRate >= 0.45 {
+			verdict = "a comeback team, never relax with a lead"
+		} else if trailing.WinRate >= 0.3 {
+			verdict = "they keep fighting when behind"
+		}
+		insights = append(insights, StrategyInsight{
+			Text:       fmt.Sprintf("When down 3+ rounds they still win %.0f%% of rounds - %s", trailing.WinRate*100, verdict),
+			Metric:     "trailing_win_rate",
+			Value:      trailing.WinRate,
+			SampleSize: trailing.Rounds,
+			Context:    "trailing by 3+",
+		})
+	}
+
+	if leading := scoreState(analysis, 3, maxScoreDiff); leading.Rounds >= 10 && leading.WinRate < 0.55 {
+		insights = append(insights, StrategyInsight{
+			Text:       fmt.Sprintf("When up 3+ rounds they win only %.0f%% of rounds - they ease off with a lead", leading.WinRate*100),
+			Metric:     "leading_win_rate",
+			Value:      leading.WinRate,
+			SampleSize: leading.Rounds,
+			Context:    "leading by 3+",
+		})
+	}
+
+	if analysis.MatchPoint.Rounds >= 5 {
+		insights = append(insights, StrategyInsight{
+			Text:       fmt.Sprintf("Match point: they convert %.0f%% of rounds that would close the map", analysis.MatchPoint.WinRate*100),
+			Metric:     "match_point_conversion",
+			Value:      analysis.MatchPoint.WinRate,
+			SampleSize: analysis.MatchPoint.Rounds,
+			Context:    "match point",
+		})
+	}
+	if analysis.MatchPointDefense.Rounds >= 5 {
+		insights = append(insights, StrategyInsight{
+			Text:       fmt.Sprintf("Facing match point: they save %.0f%% of rounds", analysis.MatchPointDefense.WinRate*100),
+			Metric:     "match_point_defense",
+			Value:      analysis.MatchPointDefense.WinRate,
+			SampleSize: analysis.MatchPointDefense.Rounds,
+			Context:    "opponent match point",
+		})
+	}
+
+	return insights
+}
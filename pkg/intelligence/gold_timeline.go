@@ -0,0 +1,267 @@
+package intelligence
+
+import (
+	"fmt"
+	"sort"
+
+	"scout9/pkg/grid"
+)
+
+// GoldTimelineAnalyzer builds per-minute gold and kill difference curves for LoL
+// games from the net worth snapshots in the event feed
+type GoldTimelineAnalyzer struct{}
+
+// NewGoldTimelineAnalyzer creates a new gold timeline analyzer
+func NewGoldTimelineAnalyzer() *GoldTimelineAnalyzer {
+	return &GoldTimelineAnalyzer{}
+}
+
+const (
+	clearGoldLead      = 1500 // gold diff at 15 that counts as a lead or a deficit
+	goldPerRatingPoint = 80   // a 4000 gold swing spans half the 0-100 rating scale
+	midGameEnd         = 25   // minute the mid game ends, as in ClassifyGamePhase
+)
+
+// AnalyzeTimelines turns each finished game's state samples into a curve from the
+// team's side and aggregates the curves
+func (g *GoldTimelineAnalyzer) AnalyzeTimelines(
+	teamID string,
+	teamName string,
+	seriesStates []*grid.SeriesState,
+	events map[string]*grid.LoLEventData,
+) *GoldTimelineAnalysis {
+	analysis := &GoldTimelineAnalysis{
+		Curve:     make([]TimelineAverage, 0),
+		Timelines: make([]GameTimeline, 0),
+	}
+
+	for _, series := range seriesStates {
+		eventData := events[series.ID]
+		if eventData == nil || len(eventData.GameStates) == 0 {
+			continue
+		}
+		samples := make(map[string][]grid.GameStateSample)
+		for _, sample := range eventData.GameStates {
+			samples[sample.GameID] = append(samples[sample.GameID], sample)
+		}
+
+		for _, game := range series.Games {
+			if !game.Finished || len(samples[game.ID]) == 0 {
+				continue
+			}
+			var ourTeam, enemyTeam *grid.GameTeam
+			for i := range game.Teams {
+				if game.Teams[i].ID == teamID || game.Teams[i].Name == teamName {
+					ourTeam = &game.Teams[i]
+				} else {
+					enemyTeam = &game.Teams[i]
+				}
+			}
+			if ourTeam == nil || enemyTeam == nil {
+				continue
+			}
+
+			if timeline := buildGameTimeline(series.ID, game.ID, ourTeam, enemyTeam, samples[game.ID]); timeline != nil {
+				analysis.Timelines = append(analysis.Timelines, *timeline)
+			}
+		}
+	}
+
+	analysis.aggregate()
+	return analysis
+}
+
+// buildGameTimeline computes the gold and kill difference at each sampled minute
+func buildGameTimeline(seriesID, gameID string, ourTeam, enemyTeam *grid.GameTeam, samples []grid.GameStateSample) *GameTimeline {
+	timeline := &GameTimeline{
+		SeriesID: seriesID,
+		GameID:   gameID,
+		Side:     ourTeam.Side,
+		Won:      ourTeam.Won,
+		Points:   make([]TimelinePoint, 0, len(samples)),
+	}
+
+	sort.SliceStable(samples, func(i, j int) bool { return samples[i].Minute < samples[j].Minute })
+	for _, sample := range samples {
+		var ours, theirs *grid.TeamStateSample
+		for i := range sample.Teams {
+			switch sample.Teams[i].TeamID {
+			case ourTeam.ID:
+				ours = &sample.Teams[i]
+			case enemyTeam.ID:
+				theirs = &sample.Teams[i]
+			}
+		}
+		if ours == nil || theirs == nil {
+			continue
+		}
+		if timeline.Side == "" {
+			timeline.Side = ours.Side
+		}
+
+		point := TimelinePoint{
+			Minute:   sample.Minute,
+			GoldDiff: ours.NetWorth - theirs.NetWorth,
+			KillDiff: ours.Kills - theirs.Kills,
+		}
+		timeline.Points = append(timeline.Points, point)
+		timeline.MaxLead = max(timeline.MaxLead, point.GoldDiff)
+		timeline.MaxDeficit = max(timeline.MaxDeficit, -point.GoldDiff)
+	}
+
+	if len(timeline.Points) == 0 {
+		return nil
+	}
+	return timeline
+}
+
+// at returns the timeline's point at the minute, if the game reached it
+func (t *GameTimeline) at(minute int) (TimelinePoint, bool) {
+	for _, point := range t.Points {
+		if point.Minute == minute {
+			return point, true
+		}
+	}
+	return TimelinePoint{}, false
+}
+
+// aggregate fills the averages, lead conversion, comebacks and phase ratings
+func (a *GoldTimelineAnalysis) aggregate() {
+	a.Games = len(a.Timelines)
+
+	type minuteSum struct {
+		gold, kills float64
+		games       int
+	}
+	var sums []minuteSum
+	var leadWins, deficitWins int
+	var midSwing, lateSwing float64
+
+	for i := range a.Timelines {
+		t := &a.Timelines[i]
+		for _, point := range t.Points {
+			for len(sums) <= point.Minute {
+				sums = append(sums, minuteSum{})
+			}
+			sums[point.Minute].gold += float64(point.GoldDiff)
+			sums[point.Minute].kills += float64(point.KillDiff)
+			sums[point.Minute].games++
+		}
+
+		at15, ok := t.at(15)
+		if !ok {
+			continue
+		}
+		switch {
+		case at15.GoldDiff >= clearGoldLead:
+			a.LeadGames++
+			if t.Won {
+				leadWins++
+			}
+		case at15.GoldDiff <= -clearGoldLead:
+			a.DeficitGames++
+			if t.Won {
+				deficitWins++
+			}
+		}
+
+		at25, ok := t.at(midGameEnd)
+		if !ok {
+			continue
+		}
+		a.MidGames++
+		midSwing += float64(at25.GoldDiff - at15.GoldDiff)
+		if last := t.Points[len(t.Points)-1]; last.Minute > midGameEnd {
+			a.LateGames++
+			lateSwing += float64(last.GoldDiff - at25.GoldDiff)
+		}
+	}
+
+	for minute, sum := range sums {
+		if sum.games == 0 {
+			continue
+		}
+		a.Curve = append(a.Curve, TimelineAverage{
+			Minute:   minute,
+			GoldDiff: sum.gold / float64(sum.games),
+			KillDiff: sum.kills / float64(sum.games),
+			Games:    sum.games,
+		})
+	}
+	for _, avg := range a.Curve {
+		switch avg.Minute {
+		case 10:
+			a.GoldDiff10, a.KillDiff10, a.Games10 = avg.GoldDiff, avg.KillDiff, avg.Games
+		case 15:
+			a.GoldDiff15, a.KillDiff15, a.Games15 = avg.GoldDiff, avg.KillDiff, avg.Games
+		case 20:
+			a.GoldDiff20, a.KillDiff20, a.Games20 = avg.GoldDiff, avg.KillDiff, avg.Games
+		}
+	}
+
+	if a.LeadGames > 0 {
+		a.LeadConversionRate = float64(leadWins) / float64(a.LeadGames)
+	}
+	if a.DeficitGames > 0 {
+		a.ComebackRate = float64(deficitWins) / float64(a.DeficitGames)
+	}
+	if a.Games15 > 0 {
+		a.EarlyGameRating = goldRating(a.GoldDiff15)
+	}
+	if a.MidGames > 0 {
+		a.MidGameRating = goldRating(midSwing / float64(a.MidGames))
+	}
+	if a.LateGames > 0 {
+		a.LateGameRating = goldRating(lateSwing / float64(a.LateGames))
+	}
+}
+
+// goldRating maps a gold difference onto 0-100, with 50 for even
+func goldRating(goldDiff float64) float64 {
+	rating := 50 + goldDiff/goldPerRatingPoint
+	if rating < 0 {
+		return 0
+	}
+	if rating > 100 {
+		return 100
+	}
+	return rating
+}
+
+// GenerateGoldTimelineInsights describes lead conversion and comebacks
+func GenerateGoldTimelineInsights(analysis *GoldTimelineAnalysis) []StrategyInsight {
+	insights := make([]StrategyInsight, 0)
+	if analysis == nil || analysis.Games15 == 0 {
+		return insights
+	}
+
+	insights = append(insights, StrategyInsight{
+		Text: fmt.Sprintf("Average gold difference %+.0f at 10, %+.0f at 15, %+.0f at 20 minutes",
+			analysis.GoldDiff10, analysis.GoldDiff15, analysis.GoldDiff20),
+		Metric:     "gold_diff_15",
+		Value:      analysis.GoldDiff15,
+		SampleSize: analysis.Games15,
+		Context:    "early game",
+	})
+	if analysis.LeadGames >= 3 {
+		insights = append(insights, StrategyInsight{
+			Text: fmt.Sprintf("Converted %.0f%% of games where they led by %d+ gold at 15 minutes",
+				analysis.LeadConversionRate*100, clearGoldLead),
+			Metric:     "lead_conversion",
+			Value:      analysis.LeadConversionRate,
+			SampleSize: analysis.LeadGames,
+			Context:    "mid-late game",
+		})
+	}
+	if analysis.DeficitGames >= 3 {
+		insights = append(insights, StrategyInsight{
+			Text: fmt.Sprintf("Came back to win %.0f%% of games where they trailed by %d+ gold at 15 minutes",
+				analysis.ComebackRate*100, clearGoldLead),
+			Metric:     "comeback_rate",
+			Value:      analysis.ComebackRate,
+			SampleSize: analysis.DeficitGames,
+			Context:    "mid-late game",
+		})
+	}
+	return insights
+}
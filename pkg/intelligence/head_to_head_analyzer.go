@@ -10,12 +10,14 @@ import (
 // HeadToHeadAnalyzer compares two teams
 type HeadToHeadAnalyzer struct {
 	gridClient *grid.Client
+	winModel   *WinProbabilityModel
 }
 
 // NewHeadToHeadAnalyzer creates a new head-to-head analyzer
 func NewHeadToHeadAnalyzer(client *grid.Client) *HeadToHeadAnalyzer {
 	return &HeadToHeadAnalyzer{
 		gridClient: client,
+		winModel:   NewWinProbabilityModel(),
 	}
 }
 
@@ -227,6 +229,32 @@ func (h *HeadToHeadAnalyzer) GenerateMatchupInsights(
 	return insights
 }
 
+// PredictMatchup attaches a pre-match win probability to the report.
+// seriesList and states should cover both teams' recent series; only finished
+// series are used.
+func (h *HeadToHeadAnalyzer) PredictMatchup(
+	report *HeadToHeadReport,
+	format string,
+	seriesList []grid.Series,
+	states []*grid.SeriesState,
+) *WinProbability {
+	title := "lol"
+	if report.Title == "6" || report.Title == "valorant" {
+		title = "valorant"
+	}
+	history := BuildMatchResults(seriesList, states)
+	prediction := h.winModel.Predict(report.Team1ID, report.Team2ID, title, format, history)
+	report.WinProbability = prediction
+
+	if prediction.UpperBound-prediction.LowerBound > 0.4 {
+		report.Warnings = append(report.Warnings,
+			fmt.Sprintf("Win probability is uncertain (%.0f%%-%.0f%%) - limited match history",
+				prediction.LowerBound*100, prediction.UpperBound*100))
+	}
+
+	return prediction
+}
+
 // Helper types and functions
 
 type h2hMatch struct {
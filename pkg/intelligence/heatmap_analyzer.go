@@ -0,0 +1,318 @@
+package intelligence
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"scout9/pkg/grid"
+)
+
+// HeatmapAnalyzer bins VALORANT kill, death and plant positions on a per-map grid
+type HeatmapAnalyzer struct {
+	CellSize float64 // map units per grid cell
+	Hotspots int     // busiest cells reported per layer
+}
+
+// NewHeatmapAnalyzer creates a heatmap analyzer with a 512 unit grid
+func NewHeatmapAnalyzer() *HeatmapAnalyzer {
+	return &HeatmapAnalyzer{
+		CellSize: 512,
+		Hotspots: 3,
+	}
+}
+
+// Heatmap layers, in the order they are returned
+const (
+	HeatmapKills       = "kills"       // where the team's players got kills
+	HeatmapFirstKills  = "firstKills"  // where they won the round's opening duel
+	HeatmapDeaths      = "deaths"      // where they died
+	HeatmapFirstDeaths = "firstDeaths" // where they lost the opening duel
+	HeatmapPlants      = "plants"      // where they planted
+)
+
+var heatmapLayers = []string{HeatmapKills, HeatmapFirstKills, HeatmapDeaths, HeatmapFirstDeaths, HeatmapPlants}
+
+// BuildHeatmaps bins the team's positions on one map. Without a map name the map
+// with the most positional data is used. The grid covers every position seen on
+// the map, so heatmaps with different filters line up.
+func (h *HeatmapAnalyzer) BuildHeatmaps(
+	teamID string,
+	teamName string,
+	seriesStates []*grid.SeriesState,
+	events map[string]*grid.VALEventData,
+	mapName string,
+	filter HeatmapFilter,
+) *MapHeatmaps {
+	filter.Side = normalizeVALSide(filter.Side)
+	filter.RoundType = strings.ToLower(filter.RoundType)
+	filter.Weapon = strings.ToLower(filter.Weapon)
+
+	result := &MapHeatmaps{
+		TeamID:     teamID,
+		TeamName:   teamName,
+		MapName:    mapName,
+		Filter:     filter,
+		CellSize:   h.CellSize,
+		Sides:      make(map[string]int),
+		RoundTypes: make(map[string]int),
+		Layers:     make([]*HeatmapLayer, 0),
+		Players:    make([]HeatmapPlayer, 0),
+		Maps:       make([]string, 0),
+	}
+
+	// Rounds with positions, by map
+	byMap := make(map[string][]valRound)
+	names := make(map[string]string)
+	for _, round := range collectVALRounds(teamID, teamName, seriesStates, events) {
+		if !hasPositions(round) {
+			continue
+		}
+		key := strings.ToLower(round.mapName)
+		byMap[key] = append(byMap[key], round)
+		names[key] = round.mapName
+	}
+	for _, key := range sortedKeys(byMap) {
+		result.Maps = append(result.Maps, names[key])
+	}
+
+	key := strings.ToLower(strings.TrimSpace(mapName))
+	if key == "" {
+		for _, k := range sortedKeys(byMap) {
+			if key == "" || len(byMap[k]) > len(byMap[key]) {
+				key = k
+			}
+		}
+	}
+	rounds := byMap[key]
+	if len(rounds) == 0 {
+		return result
+	}
+	result.MapName = names[key]
+	h.fitGrid(result, rounds)
+
+	points := make(map[string][]grid.Position)
+	players := make(map[string]*HeatmapPlayer)
+
+	for _, round := range rounds {
+		roundType := heatmapRoundType(round)
+		if round.side != "" {
+			result.Sides[round.side]++
+		}
+		result.RoundTypes[roundType]++
+
+		if (filter.Side != "" && round.side != filter.Side) ||
+			(filter.RoundType != "" && roundType != filter.RoundType) {
+			continue
+		}
+		result.Rounds++
+
+		for i, kill := range round.kills {
+			first := i == 0
+			switch round.ourID {
+			case kill.KillerTeamID:
+				player := heatmapPlayer(players, kill.KillerID, kill.KillerName)
+				player.Kills++
+				if first {
+					player.FirstKills++
+				}
+				if kill.Weapon != "" {
+					player.Weapons[kill.Weapon]++
+				}
+
+				if kill.KillerPosition == nil || !matchesPlayer(filter, kill.KillerID, kill.KillerName) ||
+					(filter.Weapon != "" && kill.Weapon != filter.Weapon) {
+					continue
+				}
+				points[HeatmapKills] = append(points[HeatmapKills], *kill.KillerPosition)
+				if first {
+					points[HeatmapFirstKills] = append(points[HeatmapFirstKills], *kill.KillerPosition)
+				}
+			case kill.VictimTeamID:
+				heatmapPlayer(players, kill.VictimID, kill.VictimName).Deaths++
+
+				if kill.VictimPosition == nil || !matchesPlayer(filter, kill.VictimID, kill.VictimName) {
+					continue
+				}
+				points[HeatmapDeaths] = append(points[HeatmapDeaths], *kill.VictimPosition)
+				if first {
+					points[HeatmapFirstDeaths] = append(points[HeatmapFirstDeaths], *kill.VictimPosition)
+				}
+			}
+		}
+
+		plant := round.plant
+		if plant != nil && plant.Position != nil && matchesPlayer(filter, plant.PlayerID, plant.PlayerName) &&
+			(plant.TeamID == round.ourID || (plant.TeamID == "" && round.side == "attack")) {
+			points[HeatmapPlants] = append(points[HeatmapPlants], *plant.Position)
+		}
+	}
+
+	geometry := grid.CurrentSiteGeometry().ForMap(result.MapName)
+	for _, name := range heatmapLayers {
+		result.Layers = append(result.Layers, h.bin(name, points[name], result, geometry))
+	}
+
+	for _, player := range players {
+		result.Players = append(result.Players, *player)
+	}
+	sort.Slice(result.Players, func(i, j int) bool {
+		if result.Players[i].Kills != result.Players[j].Kills {
+			return result.Players[i].Kills > result.Players[j].Kills
+		}
+		return result.Players[i].Name < result.Players[j].Name
+	})
+
+	return result
+}
+
+// Layer returns the named layer, or nil
+func (m *MapHeatmaps) Layer(name string) *HeatmapLayer {
+	for _, layer := range m.Layers {
+		if strings.EqualFold(layer.Name, name) {
+			return layer
+		}
+	}
+	return nil
+}
+
+// fitGrid sizes the grid to cover every kill and plant position in the rounds,
+// with the origin snapped to the cell size
+func (h *HeatmapAnalyzer) fitGrid(m *MapHeatmaps, rounds []valRound) {
+	minX, minY := math.MaxFloat64, math.MaxFloat64
+	maxX, maxY := -math.MaxFloat64, -math.MaxFloat64
+	extend := func(pos *grid.Position) {
+		if pos == nil {
+			return
+		}
+		minX, maxX = math.Min(minX, pos.X), math.Max(maxX, pos.X)
+		minY, maxY = math.Min(minY, pos.Y), math.Max(maxY, pos.Y)
+	}
+	for _, round := range rounds {
+		for _, kill := range round.kills {
+			extend(kill.KillerPosition)
+			extend(kill.VictimPosition)
+		}
+		if round.plant != nil {
+			extend(round.plant.Position)
+		}
+	}
+
+	m.OriginX = math.Floor(minX/h.CellSize) * h.CellSize
+	m.OriginY = math.Floor(minY/h.CellSize) * h.CellSize
+	m.Cols = int((maxX-m.OriginX)/h.CellSize) + 1
+	m.Rows = int((maxY-m.OriginY)/h.CellSize) + 1
+}
+
+// bin counts positions per grid cell and picks the layer's hotspots
+func (h *HeatmapAnalyzer) bin(name string, points []grid.Position, m *MapHeatmaps, geometry *grid.MapGeometry) *HeatmapLayer {
+	layer := &HeatmapLayer{
+		Name:  name,
+		Total: len(points),
+		Cells: make([]HeatmapCell, 0),
+	}
+
+	counts := make(map[[2]int]int)
+	for _, p := range points {
+		col := int((p.X - m.OriginX) / h.CellSize)
+		row := int((p.Y - m.OriginY) / h.CellSize)
+		col = max(0, col)
+		row = max(0, row)
+		if col >= m.Cols {
+			col = m.Cols - 1
+		}
+		if row >= m.Rows {
+			row = m.Rows - 1
+		}
+		counts[[2]int{col, row}]++
+	}
+
+	for cell, count := range counts {
+		layer.Cells = append(layer.Cells, HeatmapCell{
+			Col:   cell[0],
+			Row:   cell[1],
+			X:     m.OriginX + (float64(cell[0])+0.5)*h.CellSize,
+			Y:     m.OriginY + (float64(cell[1])+0.5)*h.CellSize,
+			Count: count,
+		})
+		layer.MaxCount = max(layer.MaxCount, count)
+	}
+	for i := range layer.Cells {
+		layer.Cells[i].Intensity = float64(layer.Cells[i].Count) / float64(layer.MaxCount)
+	}
+
+	// Busiest first for the hotspots, then back to grid order
+	sort.Slice(layer.Cells, func(i, j int) bool {
+		a, b := layer.Cells[i], layer.Cells[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Row != b.Row {
+			return a.Row < b.Row
+		}
+		return a.Col < b.Col
+	})
+	for _, cell := range layer.Cells {
+		if len(layer.Hotspots) >= h.Hotspots {
+			break
+		}
+		layer.Hotspots = append(layer.Hotspots, HeatmapHotspot{
+			X:      cell.X,
+			Y:      cell.Y,
+			Count:  cell.Count,
+			Share:  float64(cell.Count) / float64(layer.Total),
+			Region: geometry.Region(&grid.Position{X: cell.X, Y: cell.Y}),
+		})
+	}
+	sort.Slice(layer.Cells, func(i, j int) bool {
+		a, b := layer.Cells[i], layer.Cells[j]
+		if a.Row != b.Row {
+			return a.Row < b.Row
+		}
+		return a.Col < b.Col
+	})
+
+	return layer
+}
+
+// heatmapRoundType is "pistol" for the first round of each half, otherwise the
+// team's buy, or "unknown" without an economy snapshot
+func heatmapRoundType(round valRound) string {
+	for _, n := range pistolRounds {
+		if round.roundNum == n {
+			return "pistol"
+		}
+	}
+	if round.ourLoadout < 0 {
+		return "unknown"
+	}
+	return classifyRoundEconomy(round.ourLoadout)
+}
+
+// hasPositions reports whether any of the round's events carries a position
+func hasPositions(round valRound) bool {
+	if round.plant != nil && round.plant.Position != nil {
+		return true
+	}
+	for _, kill := range round.kills {
+		if kill.KillerPosition != nil || kill.VictimPosition != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPlayer reports whether a player passes the filter, by name or ID
+func matchesPlayer(filter HeatmapFilter, id, name string) bool {
+	return filter.Player == "" || strings.EqualFold(filter.Player, id) || strings.EqualFold(filter.Player, name)
+}
+
+func heatmapPlayer(players map[string]*HeatmapPlayer, id, name string) *HeatmapPlayer {
+	if name == "" {
+		name = id
+	}
+	if players[name] == nil {
+		players[name] = &HeatmapPlayer{Name: name, Weapons: make(map[string]int)}
+	}
+	return players[name]
+}
@@ -0,0 +1,360 @@
+package intelligence
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"scout9/pkg/grid"
+)
+
+const (
+	campSpawnTime   = 90000  // ms; jungle camps spawn at 1:30
+	campRadius      = 1000.0 // a jungler this close to a camp is clearing it
+	campDwell       = 8000   // ms at a camp before it counts as cleared
+	invadeRadius    = 2000.0 // players this close to an enemy camp before spawn are invading
+	invadePlayers   = 3      // players needed for a level 1 invade
+	firstGankWindow = 600000 // ms; kills after this are not a first gank
+	minClearGames   = 3      // games before invade and counter-jungle rates are reported
+)
+
+// jungleCamp is a camp of the first clear and whose jungle it is in
+type jungleCamp struct {
+	name string
+	side string // "blue" or "red"; "" for the river scuttles
+	pos  grid.Position
+}
+
+// jungleCamps are the Summoner's Rift camps a first clear visits
+var jungleCamps = []jungleCamp{
+	{"blue", "blue", grid.Position{X: 3872, Y: 7900}},
+	{"gromp", "blue", grid.Position{X: 2112, Y: 8372}},
+	{"wolves", "blue", grid.Position{X: 3800, Y: 6500}},
+	{"raptors", "blue", grid.Position{X: 6950, Y: 5400}},
+	{"red", "blue", grid.Position{X: 7800, Y: 4000}},
+	{"krugs", "blue", grid.Position{X: 8400, Y: 2650}},
+	{"blue", "red", grid.Position{X: 10950, Y: 7000}},
+	{"gromp", "red", grid.Position{X: 12700, Y: 6450}},
+	{"wolves", "red", grid.Position{X: 11000, Y: 8400}},
+	{"raptors", "red", grid.Position{X: 7850, Y: 9450}},
+	{"red", "red", grid.Position{X: 7100, Y: 10900}},
+	{"krugs", "red", grid.Position{X: 6400, Y: 12250}},
+	{"scuttle", "", grid.Position{X: 4400, Y: 9600}},
+	{"scuttle", "", grid.Position{X: 10500, Y: 5100}},
+}
+
+// nearestCamp returns the camp within radius of a position, or nil
+func nearestCamp(pos grid.Position, radius float64) *jungleCamp {
+	var nearest *jungleCamp
+	best := radius
+	for i := range jungleCamps {
+		camp := &jungleCamps[i]
+		if d := math.Hypot(pos.X-camp.pos.X, pos.Y-camp.pos.Y); d <= best {
+			nearest, best = camp, d
+		}
+	}
+	return nearest
+}
+
+// campVisit is one camp of a reconstructed clear
+type campVisit struct {
+	camp       *jungleCamp
+	start, end int // ms
+}
+
+// clearPathAggregator counts the games of one first clear path
+type clearPathAggregator struct {
+	games, wins int
+	endSides    map[string]int
+	endTimes    []float64
+}
+
+// gankAggregator counts the games whose first gank hit one lane
+type gankAggregator struct {
+	games int
+	times []float64
+}
+
+// firstClearTracker accumulates first clears, invades and first ganks across games
+type firstClearTracker struct {
+	clearGames     int
+	invades        int
+	counterJungles int
+	paths          map[string]*clearPathAggregator
+	gankGames      int
+	ganks          map[string]*gankAggregator
+}
+
+func newFirstClearTracker() *firstClearTracker {
+	return &firstClearTracker{
+		paths: make(map[string]*clearPathAggregator),
+		ganks: make(map[string]*gankAggregator),
+	}
+}
+
+// gamePositions returns each of the team's players' position samples in a game, in time order
+func gamePositions(eventData *grid.LoLEventData, gameID, teamID string) map[string][]grid.PlayerPositionSample {
+	byPlayer := make(map[string][]grid.PlayerPositionSample)
+	for _, sample := range eventData.PlayerPositions {
+		if sample.GameID == gameID && sample.TeamID == teamID {
+			byPlayer[sample.PlayerID] = append(byPlayer[sample.PlayerID], sample)
+		}
+	}
+	for _, samples := range byPlayer {
+		sort.SliceStable(samples, func(i, j int) bool { return samples[i].GameTime < samples[j].GameTime })
+	}
+	return byPlayer
+}
+
+// junglerFromPositions returns the player seen most at jungle camps after they
+// spawn, or "" without position data
+func junglerFromPositions(positions map[string][]grid.PlayerPositionSample) string {
+	jungler, most := "", 0
+	for _, playerID := range sortedKeys(positions) {
+		seen := 0
+		for _, sample := range positions[playerID] {
+			if sample.GameTime < campSpawnTime || sample.GameTime > grid.EarlyPositionWindow {
+				continue
+			}
+			if camp := nearestCamp(sample.Position, campRadius); camp != nil && camp.side != "" {
+				seen++
+			}
+		}
+		if seen > most {
+			jungler, most = playerID, seen
+		}
+	}
+	return jungler
+}
+
+// reconstructClear turns a jungler's position samples into the camps they cleared.
+// A position holds until the next sample, so the camp they stand at when camps
+// spawn starts the clear.
+func reconstructClear(samples []grid.PlayerPositionSample) []campVisit {
+	visits := make([]campVisit, 0)
+	var current *campVisit
+	closeVisit := func(at int) {
+		if current != nil && at-current.start >= campDwell {
+			if n := len(visits); n > 0 && visits[n-1].camp == current.camp {
+				visits[n-1].end = at
+			} else {
+				current.end = at
+				visits = append(visits, *current)
+			}
+		}
+		current = nil
+	}
+
+	for i, sample := range samples {
+		if sample.GameTime > grid.EarlyPositionWindow {
+			break
+		}
+		at := sample.GameTime
+		if at < campSpawnTime {
+			if i+1 < len(samples) && samples[i+1].GameTime <= campSpawnTime {
+				continue
+			}
+			at = campSpawnTime
+		}
+
+		camp := nearestCamp(sample.Position, campRadius)
+		if current != nil && camp == current.camp {
+			continue
+		}
+		closeVisit(at)
+		if camp != nil {
+			current = &campVisit{camp: camp, start: at}
+		}
+	}
+	closeVisit(grid.EarlyPositionWindow)
+	return visits
+}
+
+// trackFirstClear adds one game's first clear, level 1 invade, counter-jungle
+// entries and first gank to the tracker
+func (a *TimingAnalyzerEngine) trackFirstClear(
+	t *firstClearTracker,
+	game *grid.Game,
+	team *grid.GameTeam,
+	junglerID string,
+	positions map[string][]grid.PlayerPositionSample,
+	eventData *grid.LoLEventData,
+) {
+	side := team.Side
+	if side == "" {
+		side = sideFromPositions(positions)
+	}
+
+	if samples := positions[junglerID]; len(samples) > 0 && side != "" {
+		t.clearGames++
+
+		// Level 1 invade: several players near the enemy's camps before they spawn
+		invaders := 0
+		for _, playerSamples := range positions {
+			for _, sample := range playerSamples {
+				if sample.GameTime >= campSpawnTime {
+					break
+				}
+				if a.inEnemyJungle(sample.Position, side, invadeRadius) {
+					invaders++
+					break
+				}
+			}
+		}
+		if invaders >= invadePlayers {
+			t.invades++
+		}
+
+		// Counter-jungle entry: the jungler at an enemy camp once camps are up
+		for _, sample := range samples {
+			if sample.GameTime >= campSpawnTime && sample.GameTime <= grid.EarlyPositionWindow &&
+				a.inEnemyJungle(sample.Position, side, campRadius) {
+				t.counterJungles++
+				break
+			}
+		}
+
+		visits := reconstructClear(samples)
+		if len(visits) > 0 {
+			names := make([]string, 0, len(visits))
+			for _, visit := range visits {
+				name := visit.camp.name
+				if visit.camp.side != "" && visit.camp.side != side {
+					name = "enemy " + name
+				}
+				names = append(names, name)
+			}
+			path := strings.Join(names, "-")
+			agg := t.paths[path]
+			if agg == nil {
+				agg = &clearPathAggregator{endSides: make(map[string]int)}
+				t.paths[path] = agg
+			}
+			agg.games++
+			if team.Won {
+				agg.wins++
+			}
+			last := visits[len(visits)-1]
+			agg.endSides[a.clearEndSide(last.camp, side)]++
+			agg.endTimes = append(agg.endTimes, float64(last.end)/60000)
+		}
+	}
+
+	// First gank: the first kill in a lane the jungler took part in
+	for _, kill := range eventData.Kills {
+		if kill.GameID != game.ID || kill.GameTime >= firstGankWindow {
+			continue
+		}
+		involved := kill.KillerID == junglerID
+		for _, assistID := range kill.AssistIDs {
+			involved = involved || assistID == junglerID
+		}
+		pos := kill.VictimPosition
+		if pos == nil {
+			pos = kill.KillerPosition
+		}
+		if !involved || pos == nil {
+			continue
+		}
+		lane := determineLaneFromPosition(pos)
+		if lane != "top" && lane != "mid" && lane != "bot" {
+			continue
+		}
+		agg := t.ganks[lane]
+		if agg == nil {
+			agg = &gankAggregator{}
+			t.ganks[lane] = agg
+		}
+		agg.games++
+		agg.times = append(agg.times, float64(kill.GameTime)/60000)
+		t.gankGames++
+		break
+	}
+}
+
+// inEnemyJungle reports whether a position is near a jungle camp on the enemy's
+// side of the river
+func (a *TimingAnalyzerEngine) inEnemyJungle(pos grid.Position, side string, radius float64) bool {
+	if camp := nearestCamp(pos, radius); camp == nil || camp.side == "" {
+		return false
+	}
+	return strings.HasPrefix(a.laneDetector.GetJungleSide(&pos, side), "enemy")
+}
+
+// clearEndSide names where a clear ends: "top side", "bot side", or "enemy top
+// side" and "enemy bot side" in the enemy jungle. The scuttles sit on the river,
+// so they only have a top or bot side.
+func (a *TimingAnalyzerEngine) clearEndSide(camp *jungleCamp, side string) string {
+	pos := camp.pos
+	jungleSide := a.laneDetector.GetJungleSide(&pos, side)
+	if camp.side == "" || strings.HasPrefix(jungleSide, "own") {
+		jungleSide = strings.TrimPrefix(strings.TrimPrefix(jungleSide, "own_"), "enemy_")
+	}
+	return strings.ReplaceAll(jungleSide, "_", " ") + " side"
+}
+
+// sideFromPositions infers a team's side from which half of the map its
+// players spawn in
+func sideFromPositions(positions map[string][]grid.PlayerPositionSample) string {
+	for _, playerID := range sortedKeys(positions) {
+		if samples := positions[playerID]; len(samples) > 0 {
+			if samples[0].Position.X+samples[0].Position.Y < lolMapSize {
+				return "blue"
+			}
+			return "red"
+		}
+	}
+	return ""
+}
+
+// fill writes the tracked clears, invades and first ganks to the analysis
+func (t *firstClearTracker) fill(analysis *JunglePathingAnalysis) {
+	analysis.FirstClearGames = t.clearGames
+	if t.clearGames > 0 {
+		analysis.Level1InvadeRate = float64(t.invades) / float64(t.clearGames)
+		analysis.CounterJungleRate = float64(t.counterJungles) / float64(t.clearGames)
+	}
+
+	for _, path := range sortedKeys(t.paths) {
+		agg := t.paths[path]
+		endSide, most := "", 0
+		for _, s := range sortedKeys(agg.endSides) {
+			if agg.endSides[s] > most {
+				endSide, most = s, agg.endSides[s]
+			}
+		}
+		analysis.FirstClearPatterns = append(analysis.FirstClearPatterns, ClearPattern{
+			Path:        path,
+			Frequency:   float64(agg.games) / float64(t.clearGames),
+			EndLocation: endSide,
+			EndTime:     average(agg.endTimes),
+			Games:       agg.games,
+			WinRate:     float64(agg.wins) / float64(agg.games),
+		})
+	}
+	sort.SliceStable(analysis.FirstClearPatterns, func(i, j int) bool {
+		return analysis.FirstClearPatterns[i].Games > analysis.FirstClearPatterns[j].Games
+	})
+
+	for _, lane := range sortedKeys(t.ganks) {
+		agg := t.ganks[lane]
+		sort.Float64s(agg.times)
+		analysis.FirstGanks = append(analysis.FirstGanks, FirstGankTiming{
+			Lane:     lane,
+			Games:    agg.games,
+			Rate:     float64(agg.games) / float64(t.gankGames),
+			AvgTime:  average(agg.times),
+			Earliest: agg.times[0],
+		})
+	}
+	sort.SliceStable(analysis.FirstGanks, func(i, j int) bool {
+		return analysis.FirstGanks[i].Games > analysis.FirstGanks[j].Games
+	})
+}
+
+// formatGameClock formats minutes as m:ss
+func formatGameClock(minutes float64) string {
+	seconds := int(math.Round(minutes * 60))
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
@@ -350,31 +350,21 @@ func (d *StatisticalLaneDetector) LearnThresholdsFromData(positions []grid.Posit
 	}
 }
 
-// GetJungleSide determines which side of the jungle a position is on relative to a team
+// GetJungleSide determines which side of the jungle a position is on relative to a team.
+// The river splits the map between the teams and mid lane splits each half into
+// its top and bot side.
 func (d *StatisticalLaneDetector) GetJungleSide(pos *grid.Position, teamSide string) string {
 	if pos == nil {
 		return "unknown"
 	}
 
-	// Blue side jungle is bottom-left, Red side jungle is top-right
-	if teamSide == "blue" {
-		if pos.X < lolMapCenter && pos.Y < lolMapCenter {
-			return "own_bot" // Own bot-side jungle
-		} else if pos.X < lolMapCenter && pos.Y > lolMapCenter {
-			return "own_top" // Own top-side jungle
-		} else if pos.X > lolMapCenter && pos.Y < lolMapCenter {
-			return "enemy_bot" // Enemy bot-side jungle
-		}
-		return "enemy_top" // Enemy top-side jungle
+	// Blue side is below the river (bottom-left), red side above it (top-right)
+	half := "enemy"
+	if (pos.X+pos.Y < lolMapSize) == (teamSide == "blue") {
+		half = "own"
 	}
-
-	// Red team's jungle (inverted)
-	if pos.X > lolMapCenter && pos.Y > lolMapCenter {
-		return "own_top" // Own top-side jungle
-	} else if pos.X > lolMapCenter && pos.Y < lolMapCenter {
-		return "own_bot" // Own bot-side jungle
-	} else if pos.X < lolMapCenter && pos.Y > lolMapCenter {
-		return "enemy_top" // Enemy top-side jungle
+	if pos.Y > pos.X {
+		return half + "_top"
 	}
-	return "enemy_bot" // Enemy bot-side jungle
+	return half + "_bot"
 }
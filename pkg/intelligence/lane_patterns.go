@@ -0,0 +1,451 @@
+package intelligence
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"scout9/pkg/grid"
+)
+
+const (
+	laneSwapEnd        = 300000 // ms; the lane a laner holds until 5:00 is their lane for the game
+	roamWindowEnd      = 840000 // ms; roams are counted until plates fall at 14:00
+	roamMinDuration    = 10000  // ms in another lane before leaving lane counts as a roam
+	roamMaxDuration    = 90000  // ms; a laner away longer has changed lanes, not roamed
+	groupRadius        = 2000.0 // players this close to each other are grouped
+	groupPlayers       = 4      // players needed for a group
+	groupCheckInterval = 5000   // ms between group checks
+)
+
+// roleLanes is the lane each laning role starts in
+var roleLanes = map[string]string{
+	"Top":     "top",
+	"Mid":     "mid",
+	"Bot":     "bot",
+	"Support": "bot",
+}
+
+// roam is a laner leaving their lane for another one
+type roam struct {
+	destination string
+	left        int // ms
+}
+
+// roamAggregator counts one role's roams to one lane
+type roamAggregator struct {
+	roams, games int
+	firstTimes   []float64
+}
+
+// lanePatternTracker accumulates lane swaps, roams and first groups across games
+type lanePatternTracker struct {
+	games      int
+	swaps      map[string]int
+	roams      map[string]map[string]*roamAggregator // role -> destination
+	roamGames  map[string]int                        // role -> games with a roam
+	firstRoams map[string][]float64                  // role -> first roam of each game, minutes
+	groupTimes []float64
+	groupSpots map[string]int
+}
+
+func newLanePatternTracker() *lanePatternTracker {
+	return &lanePatternTracker{
+		swaps:      make(map[string]int),
+		roams:      make(map[string]map[string]*roamAggregator),
+		roamGames:  make(map[string]int),
+		firstRoams: make(map[string][]float64),
+		groupSpots: make(map[string]int),
+	}
+}
+
+// AnalyzeLanePatterns reads lane swaps, mid and support roams and the first 4-5
+// player group of each game from player positions in the laning phase
+func (a *TimingAnalyzerEngine) AnalyzeLanePatterns(
+	teamID string,
+	seriesStates []*grid.SeriesState,
+	events map[string]*grid.LoLEventData,
+) *LanePatternAnalysis {
+	analysis := &LanePatternAnalysis{
+		LanePriority:   make(map[string]float64),
+		GroupLocations: make(map[string]float64),
+	}
+	playerRoles := lolPlayerRoles(teamID, seriesStates)
+	tracker := newLanePatternTracker()
+
+	for _, seriesID := range sortedKeys(events) {
+		eventData := events[seriesID]
+		if eventData == nil {
+			continue
+		}
+		var series *grid.SeriesState
+		for _, s := range seriesStates {
+			if s.ID == seriesID {
+				series = s
+				break
+			}
+		}
+		if series == nil {
+			continue
+		}
+
+		for _, game := range series.Games {
+			if !game.Finished {
+				continue
+			}
+			for i := range game.Teams {
+				team := &game.Teams[i]
+				if team.ID != teamID {
+					continue
+				}
+				positions := gamePositions(eventData, game.ID, teamID)
+				if len(positions) == 0 {
+					continue
+				}
+				a.trackLanePatterns(tracker, team, playerRoles, positions)
+			}
+		}
+	}
+
+	tracker.fill(analysis)
+	return analysis
+}
+
+// trackLanePatterns adds one game's lane swap, roams and first group to the tracker
+func (a *TimingAnalyzerEngine) trackLanePatterns(
+	t *lanePatternTracker,
+	team *grid.GameTeam,
+	playerRoles map[string]string,
+	positions map[string][]grid.PlayerPositionSample,
+) {
+	t.games++
+	side := team.Side
+	if side == "" {
+		side = sideFromPositions(positions)
+	}
+
+	// Each laner's role is their usual one; the lane they hold shows a swap
+	jungler := junglerFromPositions(positions)
+	swapped := make(map[string]string)
+	for _, player := range team.Players {
+		role := playerRoles[player.ID]
+		if role == "" {
+			role = GetChampionRole(player.Character)
+		}
+		if player.ID == jungler || roleLanes[role] == "" {
+			continue
+		}
+		home := a.mainLane(positions[player.ID], campSpawnTime, laneSwapEnd)
+		if home == "" {
+			continue
+		}
+		if home != roleLanes[role] {
+			swapped[role] = home
+		}
+
+		if role == "Mid" || role == "Support" {
+			roams := a.detectRoams(positions[player.ID], home)
+			if len(roams) > 0 {
+				t.addRoams(strings.ToLower(role), roams)
+			}
+		}
+	}
+	if len(swapped) > 0 {
+		t.swaps[describeLaneSwap(swapped)]++
+	}
+
+	if at, spot := a.firstGroup(positions, side); at > 0 {
+		t.groupTimes = append(t.groupTimes, float64(at)/60000)
+		t.groupSpots[spot]++
+	}
+}
+
+// laneAt returns the lane, "base" or "" (jungle and river) a position is in
+func (a *TimingAnalyzerEngine) laneAt(pos grid.Position) string {
+	switch lane := a.laneDetector.ClassifyPosition(&pos).Lane; lane {
+	case "top", "mid", "bot", "base":
+		return lane
+	}
+	return ""
+}
+
+// mainLane returns the lane a player spent most time in between from and to.
+// A position holds until the player's next sample.
+func (a *TimingAnalyzerEngine) mainLane(samples []grid.PlayerPositionSample, from, to int) string {
+	spent := make(map[string]int)
+	for i, sample := range samples {
+		start, end := sample.GameTime, to
+		if i+1 < len(samples) && samples[i+1].GameTime < end {
+			end = samples[i+1].GameTime
+		}
+		if start < from {
+			start = from
+		}
+		if end <= start {
+			continue
+		}
+		if lane := a.laneAt(sample.Position); lane != "" && lane != "base" {
+			spent[lane] += end - start
+		}
+	}
+
+	best, most := "", 0
+	for _, lane := range sortedKeys(spent) {
+		if spent[lane] > most {
+			best, most = lane, spent[lane]
+		}
+	}
+	return best
+}
+
+// detectRoams finds the times a laner left their lane, stayed in another lane for
+// roamMinDuration and came back to lane or recalled within roamMaxDuration
+func (a *TimingAnalyzerEngine) detectRoams(samples []grid.PlayerPositionSample, home string) []roam {
+	roams := make([]roam, 0)
+	left := -1        // when they left their lane
+	destination := "" // the lane they are in away from their own
+	arrived := -1     // when they reached it
+	var pending *roam // this trip's roam, once they stayed long enough
+
+	for _, sample := range samples {
+		if sample.GameTime < campSpawnTime {
+			continue
+		}
+		if sample.GameTime > roamWindowEnd {
+			break
+		}
+
+		// A position holds until the next sample, so the stay so far may be a roam
+		if destination != "" && pending == nil && sample.GameTime-arrived >= roamMinDuration {
+			pending = &roam{destination: destination, left: left}
+		}
+
+		lane := a.laneAt(sample.Position)
+		if lane == home || lane == "base" {
+			if pending != nil && sample.GameTime-left <= roamMaxDuration {
+				roams = append(roams, *pending)
+			}
+			left, destination, arrived, pending = -1, "", -1, nil
+			continue
+		}
+		if left < 0 {
+			left = sample.GameTime
+		}
+		if lane != destination {
+			destination, arrived = lane, sample.GameTime
+		}
+	}
+	return roams
+}
+
+// firstGroup returns when and where 4+ of the team's players first stand
+// together after the first clear, or 0 when they never do
+func (a *TimingAnalyzerEngine) firstGroup(positions map[string][]grid.PlayerPositionSample, side string) (int, string) {
+	cursors := make(map[string]int)
+	players := sortedKeys(positions)
+
+	for at := grid.EarlyPositionWindow; at <= grid.LaningPositionWindow; at += groupCheckInterval {
+		current := make([]grid.Position, 0, len(players))
+		for _, playerID := range players {
+			samples := positions[playerID]
+			i := cursors[playerID]
+			for i+1 < len(samples) && samples[i+1].GameTime <= at {
+				i++
+			}
+			cursors[playerID] = i
+			if len(samples) == 0 || samples[i].GameTime > at {
+				continue
+			}
+			if pos := samples[i].Position; a.laneAt(pos) != "base" {
+				current = append(current, pos)
+			}
+		}
+
+		for _, pos := range current {
+			group := make([]grid.Point, 0, len(current))
+			for _, other := range current {
+				if math.Hypot(pos.X-other.X, pos.Y-other.Y) <= groupRadius {
+					group = append(group, grid.Point{X: other.X, Y: other.Y})
+				}
+			}
+			if len(group) >= groupPlayers {
+				center := centroid(group)
+				return at, a.groupLocation(grid.Position{X: center.X, Y: center.Y}, side)
+			}
+		}
+	}
+	return 0, ""
+}
+
+// groupLocation names where a group stands: a lane, "dragon", "baron", "river"
+// or a side of the team's own or the enemy jungle
+func (a *TimingAnalyzerEngine) groupLocation(pos grid.Position, side string) string {
+	classification := a.laneDetector.ClassifyPosition(&pos)
+	switch {
+	case strings.HasPrefix(classification.SubRegion, "dragon"):
+		return "dragon"
+	case strings.HasPrefix(classification.SubRegion, "baron"):
+		return "baron"
+	case classification.Lane == "jungle" && side != "":
+		return strings.ReplaceAll(a.laneDetector.GetJungleSide(&pos, side), "_", " ") + " jungle"
+	}
+	return classification.Lane
+}
+
+// describeLaneSwap names the lanes swapped laners held, e.g. "duo top, top laner bot"
+func describeLaneSwap(swapped map[string]string) string {
+	parts := make([]string, 0, len(swapped))
+	if duo := swapped["Bot"]; duo != "" && duo == swapped["Support"] {
+		parts = append(parts, "duo "+duo)
+	} else {
+		if lane := swapped["Bot"]; lane != "" {
+			parts = append(parts, "ADC "+lane)
+		}
+		if lane := swapped["Support"]; lane != "" {
+			parts = append(parts, "support "+lane)
+		}
+	}
+	if lane := swapped["Top"]; lane != "" {
+		parts = append(parts, "top laner "+lane)
+	}
+	if lane := swapped["Mid"]; lane != "" {
+		parts = append(parts, "mid laner "+lane)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// addRoams records one game's roams of a role
+func (t *lanePatternTracker) addRoams(role string, roams []roam) {
+	t.roamGames[role]++
+	t.firstRoams[role] = append(t.firstRoams[role], float64(roams[0].left)/60000)
+	if t.roams[role] == nil {
+		t.roams[role] = make(map[string]*roamAggregator)
+	}
+	seen := make(map[string]bool)
+	for _, r := range roams {
+		agg := t.roams[role][r.destination]
+		if agg == nil {
+			agg = &roamAggregator{}
+			t.roams[role][r.destination] = agg
+		}
+		agg.roams++
+		if !seen[r.destination] {
+			seen[r.destination] = true
+			agg.games++
+			agg.firstTimes = append(agg.firstTimes, float64(r.left)/60000)
+		}
+	}
+}
+
+// fill writes the tracked lane swaps, roams and groups to the analysis
+func (t *lanePatternTracker) fill(analysis *LanePatternAnalysis) {
+	analysis.PositionGames = t.games
+	if t.games == 0 {
+		return
+	}
+	games := float64(t.games)
+
+	analysis.MidRoamRate = float64(t.roamGames["mid"]) / games
+	analysis.SupportRoamRate = float64(t.roamGames["support"]) / games
+	analysis.MidFirstRoamTime = average(t.firstRoams["mid"])
+	analysis.SupportFirstRoamTime = average(t.firstRoams["support"])
+	for _, role := range sortedKeys(t.roams) {
+		for _, destination := range sortedKeys(t.roams[role]) {
+			agg := t.roams[role][destination]
+			analysis.Roams = append(analysis.Roams, RoamPattern{
+				Role:        role,
+				Destination: destination,
+				Roams:       agg.roams,
+				Games:       agg.games,
+				Rate:        float64(agg.games) / games,
+				AvgTime:     average(agg.firstTimes),
+			})
+		}
+	}
+	sort.SliceStable(analysis.Roams, func(i, j int) bool { return analysis.Roams[i].Games > analysis.Roams[j].Games })
+
+	swapGames := 0
+	for _, swap := range sortedKeys(t.swaps) {
+		swapGames += t.swaps[swap]
+		analysis.LaneSwaps = append(analysis.LaneSwaps, LaneSwapPattern{
+			Swap:  swap,
+			Games: t.swaps[swap],
+			Rate:  float64(t.swaps[swap]) / games,
+		})
+	}
+	analysis.LaneSwapRate = float64(swapGames) / games
+	sort.SliceStable(analysis.LaneSwaps, func(i, j int) bool { return analysis.LaneSwaps[i].Games > analysis.LaneSwaps[j].Games })
+
+	if len(t.groupTimes) > 0 {
+		analysis.FirstGroupTime = average(t.groupTimes)
+		analysis.GroupRate = float64(len(t.groupTimes)) / games
+		most := 0
+		for _, spot := range sortedKeys(t.groupSpots) {
+			analysis.GroupLocations[spot] = float64(t.groupSpots[spot]) / float64(len(t.groupTimes))
+			if t.groupSpots[spot] > most {
+				analysis.GroupLocation, most = spot, t.groupSpots[spot]
+			}
+		}
+	}
+}
+
+// GenerateLanePatternStrategies turns laning phase tendencies into in-game plans
+func (a *TimingAnalyzerEngine) GenerateLanePatternStrategies(lanePatterns *LanePatternAnalysis) []InGameStrategyInsight {
+	strategies := make([]InGameStrategyInsight, 0)
+	if lanePatterns == nil || lanePatterns.PositionGames < minClearGames {
+		return strategies
+	}
+
+	// Roams: the most common destination of each roaming role
+	for _, role := range []string{"mid", "support"} {
+		for _, r := range lanePatterns.Roams {
+			if r.Role != role || r.Rate < 0.4 {
+				continue
+			}
+			strategies = append(strategies, InGameStrategyInsight{
+				Strategy: fmt.Sprintf("Track their %s from %s - ward the %s lane approach and ping when they leave lane",
+					role, formatGameClock(r.AvgTime), r.Destination),
+				Timing: fmt.Sprintf("~%s", formatGameClock(r.AvgTime)),
+				Reason: fmt.Sprintf("Their %s roams to %s lane in %.0f%% of games, first at ~%s on average",
+					role, r.Destination, r.Rate*100, formatGameClock(r.AvgTime)),
+				Impact: "HIGH",
+			})
+			break
+		}
+	}
+
+	if lanePatterns.LaneSwapRate >= 0.3 && len(lanePatterns.LaneSwaps) > 0 {
+		swap := lanePatterns.LaneSwaps[0]
+		strategies = append(strategies, InGameStrategyInsight{
+			Strategy: fmt.Sprintf("Prepare a lane swap answer (%s) - plan which lane takes the 2v1 and which gives up farm", swap.Swap),
+			Timing:   "Level 1",
+			Reason:   fmt.Sprintf("They lane swap in %.0f%% of games", lanePatterns.LaneSwapRate*100),
+			Impact:   "MEDIUM",
+		})
+	}
+
+	if lanePatterns.GroupRate >= 0.5 && lanePatterns.GroupLocation != "" {
+		strategies = append(strategies, InGameStrategyInsight{
+			Strategy: fmt.Sprintf("Expect them to group %s around %s - match the group or trade the opposite side",
+				groupPlace(lanePatterns.GroupLocation), formatGameClock(lanePatterns.FirstGroupTime)),
+			Timing: fmt.Sprintf("~%s", formatGameClock(lanePatterns.FirstGroupTime)),
+			Reason: fmt.Sprintf("They first group 4+ players at ~%s on average, most often %s (%.0f%% of games)",
+				formatGameClock(lanePatterns.FirstGroupTime), groupPlace(lanePatterns.GroupLocation), lanePatterns.GroupRate*100),
+			Impact: "MEDIUM",
+		})
+	}
+
+	return strategies
+}
+
+// groupPlace phrases a group location for text: "at dragon", "in bot lane", "in own top jungle"
+func groupPlace(location string) string {
+	switch location {
+	case "dragon", "baron":
+		return "at " + location
+	case "top", "mid", "bot":
+		return "in " + location + " lane"
+	}
+	return "in " + location
+}
@@ -36,15 +36,13 @@ func (a *LoLAnalyzer) AnalyzeTeam(ctx context.Context, teamID string, teamName s
 
 	// Aggregate stats across all games
 	var (
-		totalGames      int
-		totalWins       int
-		totalDragons    int
-		totalBarons     int
-		totalHeralds    int
-		totalVoidGrubs  int
-		totalGoldDiff15 float64
-		goldDiff15Count int
-		totalDuration   float64
+		totalGames     int
+		totalWins      int
+		totalDragons   int
+		totalBarons    int
+		totalHeralds   int
+		totalVoidGrubs int
+		totalDuration  float64
 	)
 
 	// Collect all event data for EventAnalyzer
@@ -75,12 +73,9 @@ func (a *LoLAnalyzer) AnalyzeTeam(ctx context.Context, teamID string, teamName s
 
 			// Find our team in this game
 			var ourTeam *grid.GameTeam
-			var enemyTeam *grid.GameTeam
 			for i := range game.Teams {
 				if game.Teams[i].ID == teamID || game.Teams[i].Name == teamName {
 					ourTeam = &game.Teams[i]
-				} else {
-					enemyTeam = &game.Teams[i]
 				}
 			}
 
@@ -109,14 +104,6 @@ func (a *LoLAnalyzer) AnalyzeTeam(ctx context.Context, teamID string, teamName s
 					totalVoidGrubs += obj.CompletionCount
 				}
 			}
-
-			// Gold diff at 15 (if we have netWorth data)
-			if ourTeam.NetWorth > 0 && enemyTeam != nil && enemyTeam.NetWorth > 0 {
-				// This is end-game netWorth, we'd need event data for 15-min snapshot
-				// For now, use as proxy
-				goldDiff15Count++
-				totalGoldDiff15 += float64(ourTeam.NetWorth - enemyTeam.NetWorth)
-			}
 		}
 	}
 
@@ -167,20 +154,38 @@ func (a *LoLAnalyzer) AnalyzeTeam(ctx context.Context, teamID string, teamName s
 			}
 		}
 
-		if goldDiff15Count > 0 {
-			analysis.LoLMetrics.GoldDiff15 = totalGoldDiff15 / float64(goldDiff15Count)
-		}
+		// Gold and kill difference curves from the net worth snapshots
+		timelines := NewGoldTimelineAnalyzer().AnalyzeTimelines(teamID, teamName, seriesStates, events)
+		analysis.LoLMetrics.GoldTimeline = timelines
+		analysis.LoLMetrics.GoldDiff10 = timelines.GoldDiff10
+		analysis.LoLMetrics.GoldDiff15 = timelines.GoldDiff15
+		analysis.LoLMetrics.GoldDiff20 = timelines.GoldDiff20
+		analysis.LoLMetrics.LeadConversionRate = timelines.LeadConversionRate
+		analysis.LoLMetrics.ComebackRate = timelines.ComebackRate
+
+		// Teamfights clustered from the kill feed
+		analysis.LoLMetrics.Teamfights = NewTeamfightAnalyzer().AnalyzeTeamfights(teamID, events)
+
+		// Setup, contest and trades around each objective
+		analysis.LoLMetrics.ObjectiveSetups = NewTimingAnalyzer().AnalyzeObjectiveSetups(teamID, events)
+
+		// Dragon order, souls and elders
+		dragonRace := NewDragonRaceAnalyzer().AnalyzeDragonRaces(teamID, teamName, seriesStates, events)
+		analysis.LoLMetrics.DragonRace = dragonRace
+		analysis.LoLMetrics.ElderDragonRate = dragonRace.Elders.TakeRate
 
-		// Aggregate phase analysis for game phase ratings
+		// Blue and red side splits
+		analysis.LoLMetrics.Sides = NewSideAnalyzer().AnalyzeSides(teamID, teamName, seriesStates, events, timelines)
+
+		// Game phase ratings from the gold swing in each phase, falling back to the
+		// kill difference per phase when the events carry no net worth snapshots
+		var phases *PhaseAnalysis
 		if len(allPhaseAnalyses) > 0 {
-			aggregatedPhases := eventAnalyzer.AggregatePhases(allPhaseAnalyses)
-			analysis.LoLMetrics.EarlyGameRating = aggregatedPhases.EarlyGameRating
-			analysis.LoLMetrics.MidGameRating = aggregatedPhases.MidGameRating
-			analysis.LoLMetrics.LateGameRating = aggregatedPhases.LateGameRating
-		} else {
-			// Fallback to old calculation if no event data
-			analysis.LoLMetrics.EarlyGameRating = calculateEarlyGameRating(analysis.LoLMetrics)
+			phases = eventAnalyzer.AggregatePhases(allPhaseAnalyses)
 		}
+		analysis.LoLMetrics.EarlyGameRating = phaseRating(timelines.EarlyGameRating, timelines.Games15, phases, func(p *PhaseAnalysis) float64 { return p.EarlyGameRating })
+		analysis.LoLMetrics.MidGameRating = phaseRating(timelines.MidGameRating, timelines.MidGames, phases, func(p *PhaseAnalysis) float64 { return p.MidGameRating })
+		analysis.LoLMetrics.LateGameRating = phaseRating(timelines.LateGameRating, timelines.LateGames, phases, func(p *PhaseAnalysis) float64 { return p.LateGameRating })
 
 		analysis.LoLMetrics.AggressionScore = calculateAggressionScore(analysis.LoLMetrics)
 
@@ -327,7 +332,10 @@ func (a *LoLAnalyzer) AnalyzePlayers(ctx context.Context, teamID string, seriesS
 
 		// Sort character pool by games played
 		sort.Slice(profile.CharacterPool, func(i, j int) bool {
-			return profile.CharacterPool[i].GamesPlayed > profile.CharacterPool[j].GamesPlayed
+			if profile.CharacterPool[i].GamesPlayed != profile.CharacterPool[j].GamesPlayed {
+				return profile.CharacterPool[i].GamesPlayed > profile.CharacterPool[j].GamesPlayed
+			}
+			return profile.CharacterPool[i].Character < profile.CharacterPool[j].Character
 		})
 
 		// Identify signature picks (top 3)
@@ -372,7 +380,10 @@ func (a *LoLAnalyzer) AnalyzePlayers(ctx context.Context, teamID string, seriesS
 			}
 			// Sort by assist count
 			sort.Slice(profile.SynergyPartners, func(i, j int) bool {
-				return profile.SynergyPartners[i].AssistCount > profile.SynergyPartners[j].AssistCount
+				if profile.SynergyPartners[i].AssistCount != profile.SynergyPartners[j].AssistCount {
+					return profile.SynergyPartners[i].AssistCount > profile.SynergyPartners[j].AssistCount
+				}
+				return profile.SynergyPartners[i].PlayerID < profile.SynergyPartners[j].PlayerID
 			})
 			// Keep top 3
 			if len(profile.SynergyPartners) > 3 {
@@ -397,7 +408,10 @@ func (a *LoLAnalyzer) AnalyzePlayers(ctx context.Context, teamID string, seriesS
 			}
 			// Sort by usage count
 			sort.Slice(profile.AbilityUsage, func(i, j int) bool {
-				return profile.AbilityUsage[i].UsageCount > profile.AbilityUsage[j].UsageCount
+				if profile.AbilityUsage[i].UsageCount != profile.AbilityUsage[j].UsageCount {
+					return profile.AbilityUsage[i].UsageCount > profile.AbilityUsage[j].UsageCount
+				}
+				return profile.AbilityUsage[i].AbilityID < profile.AbilityUsage[j].AbilityID
 			})
 			// Keep top 10
 			if len(profile.AbilityUsage) > 10 {
@@ -417,7 +431,10 @@ func (a *LoLAnalyzer) AnalyzePlayers(ctx context.Context, teamID string, seriesS
 			}
 			// Sort by build count
 			sort.Slice(profile.ItemBuilds, func(i, j int) bool {
-				return profile.ItemBuilds[i].BuildCount > profile.ItemBuilds[j].BuildCount
+				if profile.ItemBuilds[i].BuildCount != profile.ItemBuilds[j].BuildCount {
+					return profile.ItemBuilds[i].BuildCount > profile.ItemBuilds[j].BuildCount
+				}
+				return profile.ItemBuilds[i].ItemName < profile.ItemBuilds[j].ItemName
 			})
 			// Keep top 10
 			if len(profile.ItemBuilds) > 10 {
@@ -486,7 +503,10 @@ func (a *LoLAnalyzer) AnalyzePlayers(ctx context.Context, teamID string, seriesS
 
 	// Sort by threat level
 	sort.Slice(profiles, func(i, j int) bool {
-		return profiles[i].ThreatLevel > profiles[j].ThreatLevel
+		if profiles[i].ThreatLevel != profiles[j].ThreatLevel {
+			return profiles[i].ThreatLevel > profiles[j].ThreatLevel
+		}
+		return profiles[i].PlayerID < profiles[j].PlayerID
 	})
 
 	return profiles, nil
@@ -542,10 +562,15 @@ func containsTeamID(id1, id2 string) bool {
 	return id1 == id2
 }
 
-func calculateEarlyGameRating(metrics *LoLTeamMetrics) float64 {
-	// Weight: First blood 30%, First dragon 35%, First tower 35%
-	rating := metrics.FirstBloodRate*30 + metrics.FirstDragonRate*35 + metrics.FirstTowerRate*35
-	return rating
+// phaseRating prefers the gold timeline rating when games reached the phase
+func phaseRating(goldRating float64, games int, phases *PhaseAnalysis, killRating func(*PhaseAnalysis) float64) float64 {
+	if games > 0 {
+		return goldRating
+	}
+	if phases != nil {
+		return killRating(phases)
+	}
+	return 0
 }
 
 func calculateAggressionScore(metrics *LoLTeamMetrics) float64 {
@@ -607,7 +632,16 @@ func generateLoLStrengths(analysis *TeamAnalysis) []Insight {
 			Title:       "Early Gold Lead",
 			Description: "Consistently builds gold advantages",
 			Value:       m.GoldDiff15,
-			SampleSize:  analysis.GamesAnalyzed,
+			SampleSize:  m.GoldTimeline.Games15,
+		})
+	}
+
+	if t := m.GoldTimeline; t != nil && t.DeficitGames >= 3 && t.ComebackRate >= 0.4 {
+		strengths = append(strengths, Insight{
+			Title:       "Comes Back From Deficits",
+			Description: "Wins games despite trailing in gold at 15 minutes",
+			Value:       t.ComebackRate * 100,
+			SampleSize:  t.DeficitGames,
 		})
 	}
 
@@ -641,7 +675,16 @@ func generateLoLWeaknesses(analysis *TeamAnalysis) []Insight {
 			Title:       "Early Gold Deficit",
 			Description: "Often falls behind in gold early",
 			Value:       m.GoldDiff15,
-			SampleSize:  analysis.GamesAnalyzed,
+			SampleSize:  m.GoldTimeline.Games15,
+		})
+	}
+
+	if t := m.GoldTimeline; t != nil && t.LeadGames >= 3 && t.LeadConversionRate < 0.6 {
+		weaknesses = append(weaknesses, Insight{
+			Title:       "Throws Leads",
+			Description: "Fails to close out games despite leading in gold at 15 minutes",
+			Value:       t.LeadConversionRate * 100,
+			SampleSize:  t.LeadGames,
 		})
 	}
 
@@ -816,8 +859,8 @@ func determineLoLRole(characterPool []CharacterStats) string {
 
 	maxRole := ""
 	maxCount := 0
-	for role, count := range roleCounts {
-		if count > maxCount {
+	for _, role := range sortedKeys(roleCounts) {
+		if count := roleCounts[role]; count > maxCount {
 			maxCount = count
 			maxRole = role
 		}
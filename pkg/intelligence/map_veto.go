@@ -0,0 +1,386 @@
+package intelligence
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"scout9/pkg/grid"
+)
+
+// MapVetoAnalyzer reads VALORANT map vetoes from series drafts and predicts the next veto
+type MapVetoAnalyzer struct{}
+
+// NewMapVetoAnalyzer creates a new map veto analyzer
+func NewMapVetoAnalyzer() *MapVetoAnalyzer {
+	return &MapVetoAnalyzer{}
+}
+
+// vetoTurn is one slot of a veto format
+type vetoTurn struct {
+	first  bool // taken by the team that starts the veto
+	action string
+}
+
+// vetoFormats are the standard veto orders; the last map left is the decider
+var vetoFormats = map[string][]vetoTurn{
+	"bo1": {{true, "ban"}, {false, "ban"}, {true, "ban"}, {false, "ban"}, {true, "ban"}, {false, "ban"}},
+	"bo3": {{true, "ban"}, {false, "ban"}, {true, "pick"}, {false, "pick"}, {true, "ban"}, {false, "ban"}},
+	"bo5": {{true, "ban"}, {false, "ban"}, {true, "pick"}, {false, "pick"}, {true, "pick"}, {false, "pick"}},
+}
+
+// vetoTrustGames is how many games on a map it takes to fully trust its win rate
+const vetoTrustGames = 5
+
+// vetoMapKey is the key maps are matched by across vetoes and games
+func vetoMapKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// vetoMapName returns a map's display name
+func vetoMapName(name string) string {
+	if config := mapSiteConfigs[vetoMapKey(name)]; config != nil {
+		return config.MapName
+	}
+	return name
+}
+
+// isMapDraft reports whether a draft action bans or picks a map rather than an agent
+func isMapDraft(action grid.DraftAction) bool {
+	if action.DraftableType != "" {
+		return action.DraftableType == "map"
+	}
+	return mapSiteConfigs[vetoMapKey(action.CharacterName)] != nil
+}
+
+// ExtractMapVeto returns a series' map veto from the analyzed team's point of view,
+// or nil when the series has no map draft
+func ExtractMapVeto(series *grid.SeriesState, teamID, teamName string) *SeriesVeto {
+	actions := make([]grid.DraftAction, 0)
+	for _, action := range series.DraftActions {
+		if isMapDraft(action) {
+			actions = append(actions, action)
+		}
+	}
+	// Some feeds only attach the veto to the games
+	if len(actions) == 0 {
+		for _, game := range series.Games {
+			for _, action := range game.DraftActions {
+				if isMapDraft(action) {
+					actions = append(actions, action)
+				}
+			}
+		}
+	}
+	if len(actions) == 0 {
+		return nil
+	}
+	sort.SliceStable(actions, func(i, j int) bool { return actions[i].Sequence < actions[j].Sequence })
+
+	isUs := func(id, name string) bool {
+		return id != "" && (id == teamID || (teamName != "" && name == teamName))
+	}
+	names := make(map[string]string)
+	veto := &SeriesVeto{
+		SeriesID: series.ID,
+		Format:   series.Format,
+		Steps:    make([]VetoStep, 0, len(actions)),
+	}
+	for _, t := range series.Teams {
+		names[t.ID] = t.Name
+		if !isUs(t.ID, t.Name) {
+			veto.Opponent = t.Name
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, a := range actions {
+		action, key := a.Action, vetoMapKey(a.CharacterName)
+		if (action != "ban" && action != "pick") || key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		if a.TeamID == "" && action == "pick" {
+			action = "decider"
+		}
+		name := a.TeamName
+		if name == "" {
+			name = names[a.TeamID]
+		}
+		veto.Steps = append(veto.Steps, VetoStep{
+			Sequence: len(veto.Steps) + 1,
+			TeamID:   a.TeamID,
+			TeamName: name,
+			Action:   action,
+			MapName:  vetoMapName(a.CharacterName),
+			Ours:     isUs(a.TeamID, name),
+		})
+	}
+	if len(veto.Steps) == 0 {
+		return nil
+	}
+	for _, step := range veto.Steps {
+		if step.TeamID != "" {
+			veto.WentFirst = step.Ours
+			break
+		}
+	}
+
+	return veto
+}
+
+// AnalyzeVetoes collects a team's vetoes and how often it bans and picks each map
+func (v *MapVetoAnalyzer) AnalyzeVetoes(teamID, teamName string, seriesStates []*grid.SeriesState) *MapVetoProfile {
+	profile := &MapVetoProfile{
+		Maps:    make([]*MapVetoStats, 0),
+		History: make([]SeriesVeto, 0),
+	}
+	stats := make(map[string]*MapVetoStats)
+
+	for _, series := range seriesStates {
+		veto := ExtractMapVeto(series, teamID, teamName)
+		if veto == nil {
+			continue
+		}
+		profile.SeriesAnalyzed++
+		profile.History = append(profile.History, *veto)
+
+		firstBan := true
+		for _, step := range veto.Steps {
+			if !step.Ours {
+				continue
+			}
+			key := vetoMapKey(step.MapName)
+			if stats[key] == nil {
+				stats[key] = &MapVetoStats{MapName: step.MapName}
+			}
+			switch step.Action {
+			case "ban":
+				stats[key].Bans++
+				if firstBan {
+					stats[key].FirstBans++
+					firstBan = false
+				}
+			case "pick":
+				stats[key].Picks++
+			}
+		}
+	}
+
+	for _, key := range sortedKeys(stats) {
+		s := stats[key]
+		s.BanRate = float64(s.Bans) / float64(profile.SeriesAnalyzed)
+		s.PickRate = float64(s.Picks) / float64(profile.SeriesAnalyzed)
+		profile.Maps = append(profile.Maps, s)
+	}
+	sort.SliceStable(profile.Maps, func(i, j int) bool {
+		return profile.Maps[i].Bans+profile.Maps[i].Picks > profile.Maps[j].Bans+profile.Maps[j].Picks
+	})
+
+	return profile
+}
+
+// vetoSide is what we know about one team going into a veto, keyed by vetoMapKey
+type vetoSide struct {
+	names    map[string]string
+	winRate  map[string]float64
+	games    map[string]int
+	banRate  map[string]float64
+	pickRate map[string]float64
+}
+
+func newVetoSide(analysis *TeamAnalysis) *vetoSide {
+	side := &vetoSide{
+		names:    make(map[string]string),
+		winRate:  make(map[string]float64),
+		games:    make(map[string]int),
+		banRate:  make(map[string]float64),
+		pickRate: make(map[string]float64),
+	}
+	if analysis == nil || analysis.VALMetrics == nil {
+		return side
+	}
+	for _, entry := range analysis.VALMetrics.MapPool {
+		key := vetoMapKey(entry.MapName)
+		if key == "" || key == "unknown" {
+			continue
+		}
+		side.names[key] = vetoMapName(entry.MapName)
+		side.winRate[key] = entry.WinRate
+		side.games[key] = entry.GamesPlayed
+	}
+	if analysis.VALMetrics.Veto != nil {
+		for _, s := range analysis.VALMetrics.Veto.Maps {
+			key := vetoMapKey(s.MapName)
+			side.names[key] = s.MapName
+			side.banRate[key] = s.BanRate
+			side.pickRate[key] = s.PickRate
+		}
+	}
+	return side
+}
+
+// edge is the map win rate above 50%, shrunk toward zero on small samples
+func (s *vetoSide) edge(key string) float64 {
+	games := s.games[key]
+	if games == 0 {
+		return 0
+	}
+	return (s.winRate[key] - 0.5) * float64(min(games, vetoTrustGames)) / vetoTrustGames
+}
+
+// record describes a team's results on a map
+func (s *vetoSide) record(key string) string {
+	if s.games[key] == 0 {
+		return "untested"
+	}
+	return fmt.Sprintf("%.0f%% over %d games", s.winRate[key]*100, s.games[key])
+}
+
+// likelihoods turns scores into shares of the remaining maps, most likely first
+func likelihoods(keys []string, score func(string) float64) []MapLikelihood {
+	if len(keys) == 0 {
+		return nil
+	}
+	floor := score(keys[0])
+	for _, key := range keys {
+		if v := score(key); v < floor {
+			floor = v
+		}
+	}
+	total := 0.0
+	shares := make(map[string]float64, len(keys))
+	for _, key := range keys {
+		shares[key] = score(key) - floor + 0.05
+		total += shares[key]
+	}
+	result := make([]MapLikelihood, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, MapLikelihood{MapName: key, Likelihood: shares[key] / total})
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Likelihood > result[j].Likelihood })
+	return result
+}
+
+// PredictVeto simulates a veto between us and an opponent: the suggested ban and pick
+// at each of our turns, and the most likely ban or pick at each of theirs
+func (v *MapVetoAnalyzer) PredictVeto(ours, theirs *TeamAnalysis, format string, weStart bool) *VetoPrediction {
+	turns, ok := vetoFormats[format]
+	if !ok {
+		format = "bo3"
+		turns = vetoFormats[format]
+	}
+	us, them := newVetoSide(ours), newVetoSide(theirs)
+
+	prediction := &VetoPrediction{
+		Format:  format,
+		WeStart: weStart,
+		Steps:   make([]PredictedVetoStep, 0, len(turns)+1),
+		Maps:    make([]string, 0),
+	}
+	if ours != nil {
+		prediction.OurTeam = ours.TeamName
+		if ours.VALMetrics != nil {
+			prediction.OurProfile = ours.VALMetrics.Veto
+		}
+	}
+	if theirs != nil {
+		prediction.TheirTeam = theirs.TeamName
+		if theirs.VALMetrics != nil {
+			prediction.TheirProfile = theirs.VALMetrics.Veto
+		}
+	}
+
+	// The pool is every map either team has played or vetoed
+	names := make(map[string]string)
+	for _, side := range []*vetoSide{us, them} {
+		for key, name := range side.names {
+			names[key] = name
+		}
+	}
+	remaining := sortedKeys(names)
+
+	theirBan := func(key string) float64 { return them.banRate[key] + 0.5*(us.edge(key)-them.edge(key)) }
+	theirPick := func(key string) float64 { return them.pickRate[key] + 0.5*(them.edge(key)-us.edge(key)) }
+	ourBan := func(key string) float64 { return them.edge(key) - us.edge(key) + 0.5*them.pickRate[key] }
+	ourPick := func(key string) float64 { return us.edge(key) - them.edge(key) + 0.3*them.banRate[key] }
+
+	reason := func(key string, theirTurn bool, action string) string {
+		records := fmt.Sprintf("us %s, them %s", us.record(key), them.record(key))
+		switch {
+		case theirTurn && action == "ban" && them.banRate[key] > 0:
+			return fmt.Sprintf("They ban it in %.0f%% of vetoes; %s", them.banRate[key]*100, records)
+		case theirTurn && action == "pick" && them.pickRate[key] > 0:
+			return fmt.Sprintf("They pick it in %.0f%% of vetoes; %s", them.pickRate[key]*100, records)
+		case !theirTurn && action == "ban" && them.pickRate[key] > 0:
+			return fmt.Sprintf("Their pick in %.0f%% of vetoes; %s", them.pickRate[key]*100, records)
+		case !theirTurn && action == "pick" && them.banRate[key] > 0:
+			return fmt.Sprintf("They ban it in %.0f%% of vetoes; %s", them.banRate[key]*100, records)
+		}
+		return records
+	}
+	named := func(list []MapLikelihood, action string) []MapLikelihood {
+		for i := range list {
+			key := list[i].MapName
+			list[i].MapName = names[key]
+			list[i].Reason = reason(key, true, action)
+		}
+		return list
+	}
+	prediction.TheirLikelyBans = named(likelihoods(remaining, theirBan), "ban")
+	prediction.TheirLikelyPicks = named(likelihoods(remaining, theirPick), "pick")
+
+	for _, turn := range turns {
+		if len(remaining) <= 1 {
+			break
+		}
+		theirTurn := turn.first != weStart
+		score := ourPick
+		switch {
+		case theirTurn && turn.action == "ban":
+			score = theirBan
+		case theirTurn:
+			score = theirPick
+		case turn.action == "ban":
+			score = ourBan
+		}
+
+		choice := likelihoods(remaining, score)[0]
+		team := "us"
+		if theirTurn {
+			team = "them"
+		}
+		prediction.Steps = append(prediction.Steps, PredictedVetoStep{
+			Sequence:   len(prediction.Steps) + 1,
+			Team:       team,
+			Action:     turn.action,
+			MapName:    names[choice.MapName],
+			Reason:     reason(choice.MapName, theirTurn, turn.action),
+			Confidence: choice.Likelihood,
+		})
+		if turn.action == "pick" {
+			prediction.Maps = append(prediction.Maps, names[choice.MapName])
+		}
+		for i, key := range remaining {
+			if key == choice.MapName {
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				break
+			}
+		}
+	}
+
+	// The decider is the map neither side wants to ban
+	if len(remaining) > 0 {
+		decider := likelihoods(remaining, func(key string) float64 { return -(theirBan(key) + ourBan(key)) })[0]
+		prediction.Steps = append(prediction.Steps, PredictedVetoStep{
+			Sequence:   len(prediction.Steps) + 1,
+			Action:     "decider",
+			MapName:    names[decider.MapName],
+			Reason:     reason(decider.MapName, false, "decider"),
+			Confidence: decider.Likelihood,
+		})
+		prediction.Maps = append(prediction.Maps, names[decider.MapName])
+	}
+
+	return prediction
+}
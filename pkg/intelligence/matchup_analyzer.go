@@ -142,7 +142,8 @@ func (a *MatchupAnalyzer) AnalyzeLoLPlayerMatchups(
 
 	// Convert VS class data to MatchupStats
 	// This answers: "How does this player perform AGAINST assassins vs AGAINST mages?"
-	for vsClass, agg := range vsClassData {
+	for _, vsClass := range sortedKeys(vsClassData) {
+		agg := vsClassData[vsClass]
 		if agg.games < 2 {
 			continue
 		}
@@ -180,13 +181,22 @@ func (a *MatchupAnalyzer) AnalyzeLoLPlayerMatchups(
 
 	// Sort by games played
 	sort.Slice(profile.Matchups, func(i, j int) bool {
-		return profile.Matchups[i].GamesPlayed > profile.Matchups[j].GamesPlayed
+		if profile.Matchups[i].GamesPlayed != profile.Matchups[j].GamesPlayed {
+			return profile.Matchups[i].GamesPlayed > profile.Matchups[j].GamesPlayed
+		}
+		return profile.Matchups[i].VsCharacter < profile.Matchups[j].VsCharacter
 	})
 	sort.Slice(profile.StrongAgainst, func(i, j int) bool {
-		return profile.StrongAgainst[i].KDA > profile.StrongAgainst[j].KDA
+		if profile.StrongAgainst[i].KDA != profile.StrongAgainst[j].KDA {
+			return profile.StrongAgainst[i].KDA > profile.StrongAgainst[j].KDA
+		}
+		return profile.StrongAgainst[i].VsCharacter < profile.StrongAgainst[j].VsCharacter
 	})
 	sort.Slice(profile.WeakAgainst, func(i, j int) bool {
-		return profile.WeakAgainst[i].KDA < profile.WeakAgainst[j].KDA
+		if profile.WeakAgainst[i].KDA != profile.WeakAgainst[j].KDA {
+			return profile.WeakAgainst[i].KDA < profile.WeakAgainst[j].KDA
+		}
+		return profile.WeakAgainst[i].VsCharacter < profile.WeakAgainst[j].VsCharacter
 	})
 
 	return profile
@@ -253,7 +263,8 @@ func (a *MatchupAnalyzer) AnalyzeLoLPlayerClassPerformance(
 
 	// Convert to ClassPerformance
 	result := make(map[string]*ClassPerformance)
-	for className, agg := range classData {
+	for _, className := range sortedKeys(classData) {
+		agg := classData[className]
 		if agg.games < 2 {
 			continue
 		}
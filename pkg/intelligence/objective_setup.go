@@ -0,0 +1,320 @@
+package intelligence
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"scout9/pkg/grid"
+)
+
+const (
+	setupWindowMs     = 60000  // kills this long before an objective count as its setup
+	contestAfterMs    = 15000  // kills this long after an objective still count as contesting it
+	contestRadius     = 3000.0 // map units from the objective a contesting kill must be
+	tradeLeadMs       = 30000  // the other team's takes from this long before an objective
+	tradeFollowUpMs   = 60000  // to this long after it count as a trade
+	powerPlayWindowMs = 180000 // structures this long after baron count towards its power play
+	grubSpawnGapMs    = 60000  // void grubs taken this close together are one spawn
+)
+
+// AnalyzeObjectiveSetups puts each neutral objective take in context: the kills in
+// the minute before it, whether it was contested, what the other team took in
+// response, and for barons the structures destroyed in the power play
+func (a *TimingAnalyzerEngine) AnalyzeObjectiveSetups(teamID string, events map[string]*grid.LoLEventData) *ObjectiveSetupAnalysis {
+	analysis := &ObjectiveSetupAnalysis{
+		ByObjective: make(map[string]*ObjectiveControl),
+		Takes:       make([]ObjectiveTake, 0),
+	}
+
+	for _, seriesID := range sortedKeys(events) {
+		eventData := events[seriesID]
+		if eventData == nil {
+			continue
+		}
+
+		games := make(map[string]bool)
+		for _, kill := range eventData.Kills {
+			games[kill.GameID] = true
+		}
+		playerTeams := lolPlayerTeams(eventData)
+		structures := make([]lolObjective, 0, len(eventData.TowerDestroys))
+		for _, tower := range eventData.TowerDestroys {
+			structures = append(structures, lolObjective{tower.GameID, "tower", towerTeam(tower, playerTeams), tower.GameTime, nil})
+			games[tower.GameID] = true
+		}
+
+		objectives := groupGrubSpawns(collectLoLObjectives(eventData))
+		for _, objective := range objectives {
+			games[objective.gameID] = true
+		}
+		analysis.Games += len(games)
+		takes := append(append(make([]lolObjective, 0, len(objectives)+len(structures)), objectives...), structures...)
+
+		for _, objective := range objectives {
+			if objective.teamID == "" {
+				continue
+			}
+			take := ObjectiveTake{
+				SeriesID:  seriesID,
+				GameID:    objective.gameID,
+				Objective: objective.name,
+				GameTime:  objective.gameTime / 1000,
+				TakenBy:   "them",
+			}
+			if objective.teamID == teamID {
+				take.TakenBy = "us"
+			}
+
+			// Setup kills and fights at the objective
+			for _, kill := range eventData.Kills {
+				if kill.GameID != objective.gameID {
+					continue
+				}
+				if kill.GameTime >= objective.gameTime-setupWindowMs && kill.GameTime < objective.gameTime {
+					if kill.KillerTeamID == teamID {
+						take.OurSetupKills++
+					} else if kill.VictimTeamID == teamID {
+						take.TheirSetupKills++
+					}
+				}
+				if kill.GameTime >= objective.gameTime-setupWindowMs && kill.GameTime <= objective.gameTime+contestAfterMs &&
+					nearObjective(kill, objective) {
+					take.Contested = true
+				}
+			}
+
+			// The other team's first take around it, neutral or structure
+			trade := -1
+			for _, other := range takes {
+				if other.gameID != objective.gameID || other.teamID == "" || other.teamID == objective.teamID ||
+					other.gameTime < objective.gameTime-tradeLeadMs || other.gameTime > objective.gameTime+tradeFollowUpMs {
+					continue
+				}
+				if trade < 0 || other.gameTime < trade {
+					trade = other.gameTime
+					take.Trade = other.name
+				}
+			}
+
+			if objective.name == "baron" {
+				for _, structure := range structures {
+					if structure.gameID == objective.gameID && structure.teamID == objective.teamID &&
+						structure.gameTime > objective.gameTime && structure.gameTime <= objective.gameTime+powerPlayWindowMs {
+						take.Structures++
+					}
+				}
+			}
+
+			analysis.Takes = append(analysis.Takes, take)
+		}
+	}
+
+	sort.SliceStable(analysis.Takes, func(i, j int) bool {
+		a, b := analysis.Takes[i], analysis.Takes[j]
+		if a.SeriesID != b.SeriesID {
+			return a.SeriesID < b.SeriesID
+		}
+		if a.GameID != b.GameID {
+			return a.GameID < b.GameID
+		}
+		return a.GameTime < b.GameTime
+	})
+	analysis.aggregate()
+	return analysis
+}
+
+// lolPlayerTeams maps each player in a series' kill feed to their team
+func lolPlayerTeams(eventData *grid.LoLEventData) map[string]string {
+	playerTeams := make(map[string]string)
+	for _, kill := range eventData.Kills {
+		playerTeams[kill.KillerID] = kill.KillerTeamID
+		playerTeams[kill.VictimID] = kill.VictimTeamID
+	}
+	return playerTeams
+}
+
+// towerTeam is the team that destroyed a tower; towers credited to a player are
+// attributed through the kill feed
+func towerTeam(tower grid.TowerDestroyEvent, playerTeams map[string]string) string {
+	if tower.TeamID != "" {
+		return tower.TeamID
+	}
+	return playerTeams[tower.PlayerID]
+}
+
+// nearObjective reports whether a kill happened at the objective. Without
+// positions, any kill in the 30 seconds either side of the take counts.
+func nearObjective(kill grid.KillEvent, objective lolObjective) bool {
+	pos := kill.VictimPosition
+	if pos == nil {
+		pos = kill.KillerPosition
+	}
+	if pos == nil || objective.position == nil {
+		gap := kill.GameTime - objective.gameTime
+		return gap >= -30000 && gap <= 30000
+	}
+	return math.Hypot(pos.X-objective.position.X, pos.Y-objective.position.Y) <= contestRadius
+}
+
+// groupGrubSpawns merges the void grubs one team takes in quick succession into a
+// single take
+func groupGrubSpawns(objectives []lolObjective) []lolObjective {
+	sort.SliceStable(objectives, func(i, j int) bool { return objectives[i].gameTime < objectives[j].gameTime })
+	grouped := make([]lolObjective, 0, len(objectives))
+	last := make(map[string]int) // game and team -> index of its last grub take
+	for _, objective := range objectives {
+		if objective.name != "voidGrub" {
+			grouped = append(grouped, objective)
+			continue
+		}
+		key := objective.gameID + "|" + objective.teamID
+		if i, ok := last[key]; ok && objective.gameTime-grouped[i].gameTime <= grubSpawnGapMs {
+			continue
+		}
+		last[key] = len(grouped)
+		grouped = append(grouped, objective)
+	}
+	return grouped
+}
+
+// aggregate fills the per-objective control records and the baron power play
+func (a *ObjectiveSetupAnalysis) aggregate() {
+	setupDiffs := make(map[string]int)
+	var ourStructures int
+
+	for _, take := range a.Takes {
+		control := a.ByObjective[take.Objective]
+		if control == nil {
+			control = &ObjectiveControl{TradedFor: make(map[string]int)}
+			a.ByObjective[take.Objective] = control
+		}
+
+		if take.TakenBy == "us" {
+			control.Taken++
+			setupDiffs[take.Objective] += take.OurSetupKills - take.TheirSetupKills
+			if take.Contested {
+				control.ContestedTakes++
+			}
+			if take.Trade != "" {
+				control.TradedAway++
+			}
+			if take.Objective == "baron" {
+				a.BaronPlay.Barons++
+				ourStructures += take.Structures
+				if take.Structures > 0 {
+					a.BaronPlay.Converted++
+				}
+			}
+			continue
+		}
+
+		control.Conceded++
+		if take.Trade != "" {
+			control.Trades++
+			control.TradedFor[take.Trade]++
+		}
+		if take.Objective == "baron" {
+			a.BaronPlay.EnemyBarons++
+			if take.Structures > 0 {
+				a.BaronPlay.EnemyConverted++
+			}
+		}
+	}
+
+	for name, control := range a.ByObjective {
+		if total := control.Taken + control.Conceded; total > 0 {
+			control.ControlRate = float64(control.Taken) / float64(total)
+		}
+		if control.Taken > 0 {
+			control.ContestedRate = float64(control.ContestedTakes) / float64(control.Taken)
+			control.AvgSetupKillDiff = float64(setupDiffs[name]) / float64(control.Taken)
+		}
+		if control.Conceded > 0 {
+			control.TradeRate = float64(control.Trades) / float64(control.Conceded)
+		}
+	}
+
+	if a.BaronPlay.Barons > 0 {
+		a.BaronPlay.ConversionRate = float64(a.BaronPlay.Converted) / float64(a.BaronPlay.Barons)
+		a.BaronPlay.AvgStructures = float64(ourStructures) / float64(a.BaronPlay.Barons)
+	}
+	if a.BaronPlay.EnemyBarons > 0 {
+		a.BaronPlay.EnemyConversionRate = float64(a.BaronPlay.EnemyConverted) / float64(a.BaronPlay.EnemyBarons)
+	}
+}
+
+// objectiveLabels names objectives in insight text
+var objectiveLabels = map[string]string{
+	"dragon":   "Drake",
+	"elder":    "Elder Drake",
+	"baron":    "Baron",
+	"herald":   "Herald",
+	"voidGrub": "Void Grubs",
+}
+
+// GenerateObjectiveSetupInsights describes objective setups, trades and baron conversion
+// Example: "Gives up Drake for a cross-map tower 67% of the time (6 of 9 conceded)"
+func GenerateObjectiveSetupInsights(analysis *ObjectiveSetupAnalysis) []StrategyInsight {
+	insights := make([]StrategyInsight, 0)
+	if analysis == nil {
+		return insights
+	}
+
+	for _, name := range []string{"dragon", "herald", "voidGrub", "baron", "elder"} {
+		control := analysis.ByObjective[name]
+		if control == nil {
+			continue
+		}
+		label := objectiveLabels[name]
+
+		if control.Taken >= 3 {
+			setup := "uncontested"
+			if control.ContestedRate >= 0.5 {
+				setup = "through fights"
+			}
+			insights = append(insights, StrategyInsight{
+				Text: fmt.Sprintf("Takes %s %s: %.0f%% of %d takes contested, %+.1f kill difference in the minute before",
+					label, setup, control.ContestedRate*100, control.Taken, control.AvgSetupKillDiff),
+				Metric:     name + "_contested_rate",
+				Value:      control.ContestedRate,
+				SampleSize: control.Taken,
+				Context:    "objective setup",
+			})
+		}
+
+		if control.Conceded >= 3 && control.Trades > 0 {
+			trade, count := "", 0
+			for _, other := range sortedKeys(control.TradedFor) {
+				if control.TradedFor[other] > count {
+					trade, count = other, control.TradedFor[other]
+				}
+			}
+			tradeLabel := objectiveLabels[trade]
+			if tradeLabel == "" {
+				tradeLabel = "a " + trade
+			}
+			insights = append(insights, StrategyInsight{
+				Text: fmt.Sprintf("Trades cross-map for conceded %s %.0f%% of the time (%d of %d), usually for %s",
+					label, control.TradeRate*100, control.Trades, control.Conceded, tradeLabel),
+				Metric:     name + "_trade_rate",
+				Value:      control.TradeRate,
+				SampleSize: control.Conceded,
+				Context:    "objective trades",
+			})
+		}
+	}
+
+	if play := analysis.BaronPlay; play.Barons >= 2 {
+		insights = append(insights, StrategyInsight{
+			Text: fmt.Sprintf("Converts %.0f%% of Barons into structures within 3 minutes (%.1f structures per Baron)",
+				play.ConversionRate*100, play.AvgStructures),
+			Metric:     "baron_conversion_rate",
+			Value:      play.ConversionRate,
+			SampleSize: play.Barons,
+			Context:    "baron power play",
+		})
+	}
+
+	return insights
+}
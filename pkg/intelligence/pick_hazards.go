@@ -0,0 +1,338 @@
+package intelligence
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"scout9/pkg/grid"
+)
+
+const (
+	pocketMaxGames   = 3    // a pocket pick is played at most this often
+	pocketMaxShare   = 0.2  // and in at most this share of the player's games
+	pocketMinWinRate = 0.66 // and wins at least this often
+	firstTimeSeries  = 2    // picks first seen in this many latest series are new
+	firstTimeHistory = 5    // games the player needs before them for a pick to count as new
+	flexHazardGames  = 2    // games a flex needs to be reported as a hazard
+)
+
+// detectedRoles maps role detector roles to champion data positions
+var detectedRoles = map[string]string{
+	"top":     "Top",
+	"jungle":  "Jungle",
+	"mid":     "Mid",
+	"adc":     "Bot",
+	"support": "Support",
+}
+
+// lolDetectedRoles runs the role detector on each of the team's games and gives
+// every player the role detected most often. The detector falls back to a
+// champion's usual role, so a single game cannot show a flex; the player's role
+// across games can.
+func (a *CompositionAnalyzer) lolDetectedRoles(teamID string, seriesStates []*grid.SeriesState) (map[string]string, map[string]map[string]string) {
+	votes := make(map[string]map[string]float64)
+	perGame := make(map[string]map[string]string) // game -> player -> detected role
+
+	for _, series := range seriesStates {
+		for _, game := range series.Games {
+			if !game.Finished {
+				continue
+			}
+			for _, team := range game.Teams {
+				if team.ID != teamID {
+					continue
+				}
+				players := make([]*PlayerStats, 0, len(team.Players))
+				kills := 0
+				for _, p := range team.Players {
+					players = append(players, &PlayerStats{
+						PlayerID:     p.ID,
+						PlayerName:   p.Name,
+						Character:    p.Character,
+						Kills:        p.Kills,
+						Deaths:       p.Deaths,
+						Assists:      p.Assists,
+						NetWorth:     p.NetWorth,
+						GameDuration: game.Duration,
+					})
+					kills += p.Kills
+				}
+
+				perGame[game.ID] = make(map[string]string)
+				for playerID, detection := range a.roleDetector.DetectTeamRoles(players, kills) {
+					role := detectedRoles[detection.Role]
+					if role == "" {
+						continue
+					}
+					perGame[game.ID][playerID] = role
+					if votes[playerID] == nil {
+						votes[playerID] = make(map[string]float64)
+					}
+					votes[playerID][role] += detection.Confidence
+				}
+			}
+		}
+	}
+
+	roles := make(map[string]string)
+	for playerID, byRole := range votes {
+		best := 0.0
+		for _, role := range sortedKeys(byRole) {
+			if byRole[role] > best {
+				roles[playerID], best = role, byRole[role]
+			}
+		}
+	}
+	return roles, perGame
+}
+
+// flexAggregator counts a champion's games in one role
+type flexAggregator struct {
+	games, wins, confirmed int
+	players                map[string]bool
+}
+
+// detectFlexPicks finds the champions played by two or more roles on the team
+func (a *CompositionAnalyzer) detectFlexPicks(teamID string, seriesStates []*grid.SeriesState) []FlexPick {
+	playerRoles, perGame := a.lolDetectedRoles(teamID, seriesStates)
+	byChampion := make(map[string]map[string]*flexAggregator)
+
+	for _, series := range seriesStates {
+		for _, game := range series.Games {
+			if !game.Finished {
+				continue
+			}
+			for _, team := range game.Teams {
+				if team.ID != teamID {
+					continue
+				}
+				for _, p := range team.Players {
+					role := playerRoles[p.ID]
+					if p.Character == "" || role == "" {
+						continue
+					}
+					if byChampion[p.Character] == nil {
+						byChampion[p.Character] = make(map[string]*flexAggregator)
+					}
+					agg := byChampion[p.Character][role]
+					if agg == nil {
+						agg = &flexAggregator{players: make(map[string]bool)}
+						byChampion[p.Character][role] = agg
+					}
+					agg.games++
+					if team.Won {
+						agg.wins++
+					}
+					if perGame[game.ID][p.ID] == role {
+						agg.confirmed++
+					}
+					agg.players[p.Name] = true
+				}
+			}
+		}
+	}
+
+	flexes := make([]FlexPick, 0)
+	for _, champion := range sortedKeys(byChampion) {
+		roles := byChampion[champion]
+		flex := FlexPick{Character: champion, Roles: make([]FlexRole, 0, len(roles))}
+		wins := 0
+		for _, role := range sortedKeys(roles) {
+			agg := roles[role]
+			flex.Roles = append(flex.Roles, FlexRole{
+				Role:      role,
+				Players:   sortedKeys(agg.players),
+				Games:     agg.games,
+				WinRate:   float64(agg.wins) / float64(agg.games),
+				Confirmed: agg.confirmed,
+			})
+			flex.Games += agg.games
+			wins += agg.wins
+		}
+		if len(flex.Roles) < 2 {
+			continue
+		}
+		flex.WinRate = float64(wins) / float64(flex.Games)
+		sort.SliceStable(flex.Roles, func(i, j int) bool { return flex.Roles[i].Games > flex.Roles[j].Games })
+		flexes = append(flexes, flex)
+	}
+
+	sort.SliceStable(flexes, func(i, j int) bool { return flexes[i].Games > flexes[j].Games })
+	return flexes
+}
+
+// rarePickAggregator is one player's record on one champion
+type rarePickAggregator struct {
+	games, wins int
+	first       int // index of the first game played on it
+	seriesID    string
+}
+
+// AnalyzeRarePicks finds pocket picks (rarely played, mostly won) and first-time
+// picks (first played in the latest series after a run of games without them).
+// Series info orders the series by start time.
+func (a *CompositionAnalyzer) AnalyzeRarePicks(
+	analysis *CompositionAnalysis,
+	teamID string,
+	seriesStates []*grid.SeriesState,
+	seriesInfo []grid.Series,
+) {
+	if analysis == nil {
+		return
+	}
+	analysis.PocketPicks = make([]RarePick, 0)
+	analysis.FirstTimePicks = make([]RarePick, 0)
+
+	startTimes := make(map[string]time.Time, len(seriesInfo))
+	for _, s := range seriesInfo {
+		startTimes[s.ID] = s.StartTime
+	}
+	ordered := append([]*grid.SeriesState{}, seriesStates...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return startTimes[ordered[i].ID].Before(startTimes[ordered[j].ID])
+	})
+	recentFrom := len(ordered) - firstTimeSeries
+
+	playerRoles, _ := a.lolDetectedRoles(teamID, seriesStates)
+	picks := make(map[string]map[string]*rarePickAggregator) // player -> champion
+	names := make(map[string]string)
+	playerGames := make(map[string]int)
+	firstRecentGame := make(map[string]int) // player -> their game index where the recent series start
+
+	for i, series := range ordered {
+		for _, game := range series.Games {
+			if !game.Finished {
+				continue
+			}
+			for _, team := range game.Teams {
+				if team.ID != teamID {
+					continue
+				}
+				for _, p := range team.Players {
+					if p.Character == "" {
+						continue
+					}
+					names[p.ID] = p.Name
+					if i >= recentFrom {
+						if _, ok := firstRecentGame[p.ID]; !ok {
+							firstRecentGame[p.ID] = playerGames[p.ID]
+						}
+					}
+					if picks[p.ID] == nil {
+						picks[p.ID] = make(map[string]*rarePickAggregator)
+					}
+					agg := picks[p.ID][p.Character]
+					if agg == nil {
+						agg = &rarePickAggregator{first: playerGames[p.ID], seriesID: series.ID}
+						picks[p.ID][p.Character] = agg
+					}
+					agg.games++
+					if team.Won {
+						agg.wins++
+					}
+					playerGames[p.ID]++
+				}
+			}
+		}
+	}
+
+	for _, playerID := range sortedKeys(picks) {
+		total := playerGames[playerID]
+		recent, hasRecent := firstRecentGame[playerID]
+		for _, champion := range sortedKeys(picks[playerID]) {
+			agg := picks[playerID][champion]
+			pick := RarePick{
+				Character:   champion,
+				Player:      names[playerID],
+				Role:        playerRoles[playerID],
+				Games:       agg.games,
+				Wins:        agg.wins,
+				WinRate:     float64(agg.wins) / float64(agg.games),
+				PlayerGames: total,
+			}
+
+			if hasRecent && agg.first >= recent && recent >= firstTimeHistory {
+				pick.SeriesID = agg.seriesID
+				analysis.FirstTimePicks = append(analysis.FirstTimePicks, pick)
+				continue
+			}
+			if agg.games >= 2 && agg.games <= pocketMaxGames &&
+				float64(agg.games) <= pocketMaxShare*float64(total) && pick.WinRate >= pocketMinWinRate {
+				analysis.PocketPicks = append(analysis.PocketPicks, pick)
+			}
+		}
+	}
+
+	sort.SliceStable(analysis.PocketPicks, func(i, j int) bool {
+		return analysis.PocketPicks[i].WinRate > analysis.PocketPicks[j].WinRate
+	})
+}
+
+// GenerateDraftHazards turns flex, pocket and first-time picks into draft warnings
+// Example: "Flex: Gragas played Top (3 games, 67% win rate) and Jungle (2 games, 100% win rate)"
+func GenerateDraftHazards(analysis *CompositionAnalysis) []DraftInsight {
+	hazards := make([]DraftInsight, 0)
+	if analysis == nil {
+		return hazards
+	}
+
+	for _, flex := range analysis.FlexDetails {
+		if flex.Games < flexHazardGames {
+			continue
+		}
+		roles := make([]string, 0, len(flex.Roles))
+		for _, role := range flex.Roles {
+			roles = append(roles, fmt.Sprintf("%s (%d games, %.0f%% win rate)", role.Role, role.Games, role.WinRate*100))
+		}
+		hazards = append(hazards, DraftInsight{
+			Text: fmt.Sprintf("Flex: %s played %s - an early pick does not reveal the role",
+				flex.Character, joinList(roles)),
+			Character:  flex.Character,
+			WinRate:    flex.WinRate,
+			SampleSize: flex.Games,
+			Priority:   1,
+		})
+	}
+
+	for _, pick := range analysis.PocketPicks {
+		hazards = append(hazards, DraftInsight{
+			Text: fmt.Sprintf("Pocket pick: %s on %s - won %d of %d, only %d of their %d games",
+				pick.Player, pick.Character, pick.Wins, pick.Games, pick.Games, pick.PlayerGames),
+			Character:  pick.Character,
+			PlayerName: pick.Player,
+			WinRate:    pick.WinRate,
+			SampleSize: pick.Games,
+			Priority:   2,
+		})
+	}
+
+	for _, pick := range analysis.FirstTimePicks {
+		result := "lost"
+		if pick.Wins == pick.Games {
+			result = "won"
+		} else if pick.Wins > 0 {
+			result = fmt.Sprintf("won %d of %d", pick.Wins, pick.Games)
+		}
+		hazards = append(hazards, DraftInsight{
+			Text: fmt.Sprintf("New pick: %s brought out %s for the first time in their last %d series (%s) - expect more untested picks",
+				pick.Player, pick.Character, firstTimeSeries, result),
+			Character:  pick.Character,
+			PlayerName: pick.Player,
+			WinRate:    pick.WinRate,
+			SampleSize: pick.Games,
+			Priority:   2,
+		})
+	}
+
+	return hazards
+}
+
+// joinList joins items as "a", "a and b" or "a, b and c"
+func joinList(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
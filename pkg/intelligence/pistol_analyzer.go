@@ -0,0 +1,225 @@
+package intelligence
+
+import (
+	"fmt"
+
+	"scout9/pkg/grid"
+)
+
+// PistolAnalyzer looks at the two rounds after each VALORANT pistol round
+type PistolAnalyzer struct{}
+
+// NewPistolAnalyzer creates a new pistol follow-up analyzer
+func NewPistolAnalyzer() *PistolAnalyzer {
+	return &PistolAnalyzer{}
+}
+
+// pistolRounds are the first rounds of each half
+var pistolRounds = []int{1, 13}
+
+func (f *PistolFollowUp) add(pistol valRound, second, bonus *valRound) {
+	f.PistolRounds++
+	if pistol.won {
+		f.PistolWins++
+		if second != nil {
+			f.SecondRounds++
+			if second.won {
+				f.Conversions++
+			}
+		}
+		// The bonus round only follows a won conversion; after a lost one both
+		// teams are back on a normal buy
+		if bonus != nil && second != nil && second.won {
+			f.BonusRounds++
+			if bonus.won {
+				f.BonusWins++
+			}
+		}
+		return
+	}
+
+	f.PistolLosses++
+	if second != nil {
+		f.BrokenRounds++
+		if second.won {
+			f.Breaks++
+		}
+		if second.ourLoadout >= 0 {
+			f.KnownBuys++
+			if classifyRoundEconomy(second.ourLoadout) != "eco" {
+				f.ForceBuys++
+				if second.won {
+					f.ForceWins++
+				}
+			}
+		}
+	}
+	// A thrifty win is a round won on a cheaper loadout than the opponent's
+	for _, r := range []*valRound{second, bonus} {
+		if r != nil && r.won && r.ourLoadout >= 0 && r.theirLoadout >= 0 && r.ourLoadout < r.theirLoadout {
+			f.ThriftyWins++
+		}
+	}
+}
+
+func (f *PistolFollowUp) finish() {
+	if f.SecondRounds > 0 {
+		f.ConversionRate = float64(f.Conversions) / float64(f.SecondRounds)
+	}
+	if f.BonusRounds > 0 {
+		f.BonusWinRate = float64(f.BonusWins) / float64(f.BonusRounds)
+	}
+	if f.BrokenRounds > 0 {
+		f.BreakRate = float64(f.Breaks) / float64(f.BrokenRounds)
+	}
+	if f.KnownBuys > 0 {
+		f.ForceRate = float64(f.ForceBuys) / float64(f.KnownBuys)
+	}
+	if f.ForceBuys > 0 {
+		f.ForceWinRate = float64(f.ForceWins) / float64(f.ForceBuys)
+	}
+	if f.PistolLosses > 0 {
+		f.ThriftyRate = float64(f.ThriftyWins) / float64(f.PistolLosses)
+	}
+}
+
+// AnalyzePistolFollowUps looks at rounds 2-3 and 14-15 given the pistol result:
+// conversions and bonus rounds after a pistol win, force buys, breaks and thrifty
+// wins after a pistol loss. Buys come from loadout snapshots when they exist.
+func (p *PistolAnalyzer) AnalyzePistolFollowUps(
+	teamID string,
+	teamName string,
+	seriesStates []*grid.SeriesState,
+	events map[string]*grid.VALEventData,
+) *PistolAnalysis {
+	analysis := &PistolAnalysis{
+		ByMap:  make(map[string]*PistolFollowUp),
+		BySide: make(map[string]*PistolFollowUp),
+	}
+
+	// Rounds of each game by number
+	var games []map[int]valRound
+	gameIndex := make(map[string]int)
+	for _, round := range collectVALRounds(teamID, teamName, seriesStates, events) {
+		key := round.gameID + "|" + round.mapName
+		i, ok := gameIndex[key]
+		if !ok {
+			i = len(games)
+			gameIndex[key] = i
+			games = append(games, make(map[int]valRound))
+		}
+		games[i][round.roundNum] = round
+	}
+
+	for _, rounds := range games {
+		for _, n := range pistolRounds {
+			pistol, ok := rounds[n]
+			if !ok {
+				continue
+			}
+			var second, bonus *valRound
+			if r, ok := rounds[n+1]; ok {
+				second = &r
+			}
+			if r, ok := rounds[n+2]; ok {
+				bonus = &r
+			}
+
+			analysis.Overall.add(pistol, second, bonus)
+			if analysis.ByMap[pistol.mapName] == nil {
+				analysis.ByMap[pistol.mapName] = &PistolFollowUp{}
+			}
+			analysis.ByMap[pistol.mapName].add(pistol, second, bonus)
+			if pistol.side != "" {
+				if analysis.BySide[pistol.side] == nil {
+					analysis.BySide[pistol.side] = &PistolFollowUp{}
+				}
+				analysis.BySide[pistol.side].add(pistol, second, bonus)
+			}
+		}
+	}
+
+	analysis.Overall.finish()
+	for _, m := range []map[string]*PistolFollowUp{analysis.ByMap, analysis.BySide} {
+		for _, f := range m {
+			f.finish()
+		}
+	}
+
+	return analysis
+}
+
+// sideSplit describes a rate on attack and defense when both sides have a sample
+func sideSplit(analysis *PistolAnalysis, rate func(*PistolFollowUp) (float64, int)) string {
+	attack, defense := analysis.BySide["attack"], analysis.BySide["defense"]
+	if attack == nil || defense == nil {
+		return ""
+	}
+	ar, an := rate(attack)
+	dr, dn := rate(defense)
+	if an < 3 || dn < 3 {
+		return ""
+	}
+	return fmt.Sprintf(" (%.0f%% on attack, %.0f%% on defense)", ar*100, dr*100)
+}
+
+// GeneratePistolStrategies turns pistol follow-up tendencies into in-game strategies
+// Example: "After losing pistol they force round 2 78% of the time - buy armor and hold close angles"
+func GeneratePistolStrategies(analysis *PistolAnalysis) []InGameStrategyInsight {
+	strategies := make([]InGameStrategyInsight, 0)
+	if analysis == nil {
+		return strategies
+	}
+	o := analysis.Overall
+
+	if o.KnownBuys >= 4 {
+		split := sideSplit(analysis, func(f *PistolFollowUp) (float64, int) { return f.ForceRate, f.KnownBuys })
+		switch {
+		case o.ForceRate >= 0.6:
+			strategies = append(strategies, InGameStrategyInsight{
+				Strategy: "Buy armor and hold close angles in the round after a pistol win",
+				Timing:   "Rounds 2 and 14",
+				Reason: fmt.Sprintf("After losing pistol they force round 2 %.0f%% of the time%s and win %.0f%% of those forces",
+					o.ForceRate*100, split, o.ForceWinRate*100),
+				Impact: "HIGH",
+			})
+		case o.ForceRate <= 0.3:
+			strategies = append(strategies, InGameStrategyInsight{
+				Strategy: "Take free map control in the round after a pistol win - they save",
+				Timing:   "Rounds 2 and 14",
+				Reason:   fmt.Sprintf("After losing pistol they save round 2 %.0f%% of the time%s", (1-o.ForceRate)*100, split),
+				Impact:   "MEDIUM",
+			})
+		}
+	}
+
+	if o.SecondRounds >= 4 && o.ConversionRate < 0.65 {
+		split := sideSplit(analysis, func(f *PistolFollowUp) (float64, int) { return f.ConversionRate, f.SecondRounds })
+		strategies = append(strategies, InGameStrategyInsight{
+			Strategy: "Force buy after losing pistol - their anti-eco is beatable",
+			Timing:   "Rounds 2 and 14",
+			Reason:   fmt.Sprintf("They convert only %.0f%% of pistol wins into round 2%s", o.ConversionRate*100, split),
+			Impact:   "HIGH",
+		})
+	}
+
+	if o.BonusRounds >= 4 && o.BonusWinRate >= 0.5 {
+		strategies = append(strategies, InGameStrategyInsight{
+			Strategy: "Respect their bonus round - stack utility and avoid wide swings",
+			Timing:   "Rounds 3 and 15",
+			Reason:   fmt.Sprintf("They win %.0f%% of bonus rounds after a pistol win", o.BonusWinRate*100),
+			Impact:   "MEDIUM",
+		})
+	}
+
+	if o.PistolLosses >= 4 && o.ThriftyRate >= 0.25 {
+		strategies = append(strategies, InGameStrategyInsight{
+			Strategy: "Don't over-peek on anti-eco rounds - play for trades",
+			Timing:   "Rounds 2-3 and 14-15",
+			Reason:   fmt.Sprintf("They steal a round on a cheaper buy after %.0f%% of pistol losses", o.ThriftyRate*100),
+			Impact:   "MEDIUM",
+		})
+	}
+
+	return strategies
+}
@@ -0,0 +1,330 @@
+package intelligence
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"scout9/pkg/grid"
+)
+
+// PostPlantAnalyzer reconstructs what happens after the spike goes down: how
+// well a team converts its own plants and whether it retakes or saves on defense
+type PostPlantAnalyzer struct{}
+
+// NewPostPlantAnalyzer creates a new post-plant analyzer
+func NewPostPlantAnalyzer() *PostPlantAnalyzer {
+	return &PostPlantAnalyzer{}
+}
+
+// Plant timing buckets, in time since the round started
+const (
+	earlyPlantBefore = 45 * time.Second
+	latePlantAfter   = 75 * time.Second
+)
+
+// plantTiming buckets a plant as "early", "default" or "late"
+func plantTiming(gameTimeMs int) string {
+	t := time.Duration(gameTimeMs) * time.Millisecond
+	switch {
+	case t < earlyPlantBefore:
+		return "early"
+	case t > latePlantAfter:
+		return "late"
+	}
+	return "default"
+}
+
+// numbersState describes a player count from our side
+func numbersState(ours, theirs int) string {
+	switch {
+	case ours > theirs:
+		return "advantage"
+	case ours < theirs:
+		return "disadvantage"
+	}
+	return "even"
+}
+
+// sitePostPlantAggregator accumulates one site's post-plant rounds
+type sitePostPlantAggregator struct {
+	stats          *SitePostPlant
+	aliveAtPlant   int
+	enemiesAtPlant int
+	defenders      int
+	attackers      int
+	retakeTime     time.Duration
+	timedRetakes   int
+}
+
+func addPostPlant(buckets map[string]*PostPlantStats, key string, won bool) {
+	if buckets[key] == nil {
+		buckets[key] = &PostPlantStats{}
+	}
+	buckets[key].Rounds++
+	if won {
+		buckets[key].Wins++
+	}
+}
+
+func (s *PostPlantStats) finish() {
+	if s.Rounds > 0 {
+		s.WinRate = float64(s.Wins) / float64(s.Rounds)
+	}
+}
+
+// AnalyzePostPlants splits every planted round by who planted. After our plants it
+// tracks conversion by site, plant timing and player count; after the opponent's
+// plants it tracks retakes vs saves, retake success and time to defuse.
+func (p *PostPlantAnalyzer) AnalyzePostPlants(
+	teamID string,
+	teamName string,
+	seriesStates []*grid.SeriesState,
+	events map[string]*grid.VALEventData,
+) *PostPlantAnalysis {
+	sites := make(map[string]*sitePostPlantAggregator)
+	analysis := &PostPlantAnalysis{
+		Sites: make([]*SitePostPlant, 0),
+	}
+
+	for _, round := range collectVALRounds(teamID, teamName, seriesStates, events) {
+		plant := round.plant
+		if plant == nil {
+			continue
+		}
+		site := plant.Site
+		if site == "" && plant.Position != nil {
+			site = inferSiteFromPositionEnhanced(plant.Position, round.mapName)
+		}
+		site = normalizeSiteName(site)
+		if site == "" {
+			continue
+		}
+
+		key := round.mapName + "|" + site
+		agg, ok := sites[key]
+		if !ok {
+			agg = &sitePostPlantAggregator{stats: &SitePostPlant{
+				MapName:         round.mapName,
+				Site:            site,
+				ByTiming:        make(map[string]*PostPlantStats),
+				ByNumbers:       make(map[string]*PostPlantStats),
+				RetakeByNumbers: make(map[string]*PostPlantStats),
+			}}
+			sites[key] = agg
+		}
+		s := agg.stats
+
+		// Replay the kill feed up to the plant for the player counts
+		oursAlive, theirsAlive := len(round.players), round.enemies
+		if oursAlive == 0 {
+			oursAlive = 5
+		}
+		var afterPlant []grid.VALKillEvent
+		for _, kill := range round.kills {
+			if eventGap(plant.OccurredAt, plant.GameTime, kill) > 0 {
+				afterPlant = append(afterPlant, kill)
+				continue
+			}
+			if kill.VictimTeamID == round.ourID {
+				oursAlive--
+			} else {
+				theirsAlive--
+			}
+		}
+
+		wePlanted := plant.TeamID == round.ourID || (plant.TeamID == "" && round.side == "attack")
+		if wePlanted {
+			s.Plants++
+			analysis.Plants++
+			if round.won {
+				s.PlantWins++
+				analysis.PlantWins++
+			}
+			addPostPlant(s.ByTiming, plantTiming(plant.GameTime), round.won)
+			addPostPlant(s.ByNumbers, numbersState(oursAlive, theirsAlive), round.won)
+			agg.aliveAtPlant += oursAlive
+			agg.enemiesAtPlant += theirsAlive
+			continue
+		}
+
+		// The opponent planted: did we go for the retake or save?
+		s.DefendedPlants++
+		analysis.DefendedPlants++
+		agg.defenders += oursAlive
+		agg.attackers += theirsAlive
+
+		defused := round.defuse != nil && (round.defuse.TeamID == round.ourID || round.defuse.TeamID == "")
+		engaged := defused
+		for _, kill := range afterPlant {
+			if kill.KillerTeamID == round.ourID || kill.VictimTeamID == round.ourID {
+				engaged = true
+				break
+			}
+		}
+		if !engaged {
+			s.Saves++
+			analysis.Saves++
+			continue
+		}
+
+		s.Retakes++
+		analysis.Retakes++
+		if round.won {
+			s.RetakeWins++
+			analysis.RetakeWins++
+		}
+		addPostPlant(s.RetakeByNumbers, numbersState(oursAlive, theirsAlive), round.won)
+		if defused {
+			var gap time.Duration
+			if !plant.OccurredAt.IsZero() && !round.defuse.OccurredAt.IsZero() {
+				gap = round.defuse.OccurredAt.Sub(plant.OccurredAt)
+			} else {
+				gap = time.Duration(round.defuse.GameTime-plant.GameTime) * time.Millisecond
+			}
+			if gap > 0 {
+				agg.retakeTime += gap
+				agg.timedRetakes++
+			}
+		}
+	}
+
+	for _, key := range sortedKeys(sites) {
+		agg := sites[key]
+		s := agg.stats
+		if s.Plants > 0 {
+			s.PlantWinRate = float64(s.PlantWins) / float64(s.Plants)
+			s.AvgAliveAtPlant = float64(agg.aliveAtPlant) / float64(s.Plants)
+			s.AvgEnemiesAtPlant = float64(agg.enemiesAtPlant) / float64(s.Plants)
+		}
+		if s.DefendedPlants > 0 {
+			s.SaveRate = float64(s.Saves) / float64(s.DefendedPlants)
+			s.AvgDefendersAtPlant = float64(agg.defenders) / float64(s.DefendedPlants)
+			s.AvgAttackersAtPlant = float64(agg.attackers) / float64(s.DefendedPlants)
+		}
+		if s.Retakes > 0 {
+			s.RetakeWinRate = float64(s.RetakeWins) / float64(s.Retakes)
+		}
+		if agg.timedRetakes > 0 {
+			s.AvgTimeToRetakeSec = agg.retakeTime.Seconds() / float64(agg.timedRetakes)
+		}
+		for _, buckets := range []map[string]*PostPlantStats{s.ByTiming, s.ByNumbers, s.RetakeByNumbers} {
+			for _, b := range buckets {
+				b.finish()
+			}
+		}
+		analysis.Sites = append(analysis.Sites, s)
+	}
+
+	if analysis.Plants > 0 {
+		analysis.PlantWinRate = float64(analysis.PlantWins) / float64(analysis.Plants)
+	}
+	if analysis.Retakes > 0 {
+		analysis.RetakeWinRate = float64(analysis.RetakeWins) / float64(analysis.Retakes)
+	}
+	if analysis.DefendedPlants > 0 {
+		analysis.SaveRate = float64(analysis.Saves) / float64(analysis.DefendedPlants)
+	}
+
+	return analysis
+}
+
+// GenerateRetakeInsights describes how a team defends planted sites
+// Example: "On Defense, they save instead of retaking 55% of B-Site plants on Bind"
+func GenerateRetakeInsights(analysis *PostPlantAnalysis) []StrategyInsight {
+	insights := make([]StrategyInsight, 0)
+	if analysis == nil {
+		return insights
+	}
+
+	for _, s := range analysis.Sites {
+		if s.DefendedPlants < 3 {
+			continue
+		}
+		context := fmt.Sprintf("%s %s-Site", s.MapName, s.Site)
+		if s.SaveRate >= 0.4 {
+			insights = append(insights, StrategyInsight{
+				Text: fmt.Sprintf("On Defense, they save instead of retaking %.0f%% of %s-Site plants on %s",
+					s.SaveRate*100, s.Site, s.MapName),
+				Metric:     "post_plant_save_rate",
+				Value:      s.SaveRate,
+				SampleSize: s.DefendedPlants,
+				Context:    context,
+			})
+			continue
+		}
+		if s.Retakes >= 3 {
+			text := fmt.Sprintf("On Defense, they retake %s-Site on %s after %.0f%% of plants and win %.0f%% (%.1f defenders vs %.1f attackers at plant",
+				s.Site, s.MapName, (1-s.SaveRate)*100, s.RetakeWinRate*100, s.AvgDefendersAtPlant, s.AvgAttackersAtPlant)
+			if s.AvgTimeToRetakeSec > 0 {
+				text += fmt.Sprintf(", ~%.0fs to defuse", s.AvgTimeToRetakeSec)
+			}
+			insights = append(insights, StrategyInsight{
+				Text:       text + ")",
+				Metric:     "retake_win_rate",
+				Value:      s.RetakeWinRate,
+				SampleSize: s.Retakes,
+				Context:    context,
+			})
+		}
+	}
+
+	return insights
+}
+
+// GeneratePostPlantCounters turns post-plant tendencies into counter-strategies:
+// sites where a plant is nearly a round win, and plants they fail to convert
+func GeneratePostPlantCounters(analysis *PostPlantAnalysis) []Strategy {
+	strategies := make([]Strategy, 0)
+	if analysis == nil {
+		return strategies
+	}
+
+	type counter struct {
+		strategy Strategy
+		weight   float64
+	}
+	var counters []counter
+
+	for _, s := range analysis.Sites {
+		switch {
+		case s.DefendedPlants >= 3 && s.SaveRate >= 0.4:
+			counters = append(counters, counter{Strategy{
+				Title:       fmt.Sprintf("Get the plant on %s-Site (%s)", s.Site, s.MapName),
+				Description: fmt.Sprintf("They save on %.0f%% of %s-Site plants - once the spike is down, play for time", s.SaveRate*100, s.Site),
+				Timing:      "Attack rounds",
+				Evidence:    fmt.Sprintf("%d saves from %d plants", s.Saves, s.DefendedPlants),
+			}, s.SaveRate})
+		case s.Retakes >= 3 && s.RetakeWinRate < 0.25:
+			counters = append(counters, counter{Strategy{
+				Title:       fmt.Sprintf("Play post-plant on %s-Site (%s)", s.Site, s.MapName),
+				Description: fmt.Sprintf("Their retakes on %s-Site win only %.0f%% - hold crossfires on the spike", s.Site, s.RetakeWinRate*100),
+				Timing:      "Attack rounds",
+				Evidence:    fmt.Sprintf("%.0f%% retake win rate (n=%d)", s.RetakeWinRate*100, s.Retakes),
+			}, 1 - s.RetakeWinRate})
+		}
+
+		if s.Plants >= 4 && s.PlantWinRate < 0.5 {
+			counters = append(counters, counter{Strategy{
+				Title:       fmt.Sprintf("Retake their %s-Site plants (%s)", s.Site, s.MapName),
+				Description: fmt.Sprintf("They convert only %.0f%% of %s-Site plants - don't give up the round after the spike goes down", s.PlantWinRate*100, s.Site),
+				Timing:      "Defense rounds",
+				Evidence:    fmt.Sprintf("%.0f%% post-plant win rate (n=%d)", s.PlantWinRate*100, s.Plants),
+			}, 1 - s.PlantWinRate})
+		}
+		if late := s.ByTiming["late"]; late != nil && late.Rounds >= 3 && late.WinRate < 0.4 {
+			counters = append(counters, counter{Strategy{
+				Title:       fmt.Sprintf("Stall their late %s-Site executes (%s)", s.Site, s.MapName),
+				Description: fmt.Sprintf("Late plants on %s-Site convert only %.0f%% - delay with utility and retake", s.Site, late.WinRate*100),
+				Timing:      "Defense rounds, last 25 seconds",
+				Evidence:    fmt.Sprintf("%.0f%% win rate on late plants (n=%d)", late.WinRate*100, late.Rounds),
+			}, 1 - late.WinRate})
+		}
+	}
+
+	sort.SliceStable(counters, func(i, j int) bool { return counters[i].weight > counters[j].weight })
+	for _, c := range counters {
+		strategies = append(strategies, c.strategy)
+	}
+	return strategies
+}
@@ -78,8 +78,8 @@ func (d *DataDrivenRoleDetector) DetectRole(player *PlayerStats, teamTotalKills
 		}
 	}
 
-	// 2. Support detection: Low CS, high assists
-	if csPerMin < 3.0 && assistRatio > 0.6 {
+	// 2. Support detection: Low CS, high assists (only when CS was recorded)
+	if player.CS > 0 && csPerMin < 3.0 && assistRatio > 0.6 {
 		evidence = append(evidence, fmt.Sprintf("Low CS (%.1f/min), high assist ratio (%.1f%%)",
 			csPerMin, assistRatio*100))
 		return &RoleDetection{
@@ -266,14 +266,16 @@ func (d *DataDrivenRoleDetector) DetectTeamRoles(players []*PlayerStats, teamTot
 func (d *DataDrivenRoleDetector) resolveRoleConflicts(players []*PlayerStats, results map[string]*RoleDetection) {
 	// Count role assignments
 	roleCounts := make(map[string][]string) // role -> player IDs
-	for playerID, detection := range results {
+	for _, playerID := range sortedKeys(results) {
+		detection := results[playerID]
 		if detection.Role != "unknown" {
 			roleCounts[detection.Role] = append(roleCounts[detection.Role], playerID)
 		}
 	}
 
 	// Resolve duplicates by confidence
-	for role, playerIDs := range roleCounts {
+	for _, role := range sortedKeys(roleCounts) {
+		playerIDs := roleCounts[role]
 		if len(playerIDs) > 1 {
 			// Keep the one with highest confidence
 			var bestPlayer string
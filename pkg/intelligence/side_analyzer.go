@@ -0,0 +1,287 @@
+package intelligence
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"scout9/pkg/grid"
+)
+
+// SideAnalyzer splits a LoL team's games by blue and red side
+type SideAnalyzer struct{}
+
+// NewSideAnalyzer creates a new side analyzer
+func NewSideAnalyzer() *SideAnalyzer {
+	return &SideAnalyzer{}
+}
+
+const (
+	minSideGames   = 3    // games needed on each side before trusting a split
+	clearSideSplit = 0.15 // win rate gap that makes one side clearly better
+)
+
+// sideTotals accumulates one side's games before rates are taken
+type sideTotals struct {
+	stats                                *SideStats
+	bloods, dragons, heralds, towers     int
+	bloodGames, dragonGames, heraldGames int
+	towerGames                           int
+	duration, winDuration, goldDiff15    float64
+}
+
+// AnalyzeSides computes win rate, first objectives, pace and first picks per side.
+// Timelines, when given, supply the gold difference at 15 minutes.
+func (s *SideAnalyzer) AnalyzeSides(
+	teamID string,
+	teamName string,
+	seriesStates []*grid.SeriesState,
+	events map[string]*grid.LoLEventData,
+	timelines *GoldTimelineAnalysis,
+) *SideAnalysis {
+	analysis := &SideAnalysis{
+		Blue: SideStats{Side: "blue", FirstPicks: make(map[string]int)},
+		Red:  SideStats{Side: "red", FirstPicks: make(map[string]int)},
+	}
+	totals := map[string]*sideTotals{
+		"blue": {stats: &analysis.Blue},
+		"red":  {stats: &analysis.Red},
+	}
+
+	goldAt15 := make(map[string]int)
+	if timelines != nil {
+		for i := range timelines.Timelines {
+			if point, ok := timelines.Timelines[i].at(15); ok {
+				goldAt15[timelines.Timelines[i].GameID] = point.GoldDiff
+			}
+		}
+	}
+
+	for _, series := range seriesStates {
+		eventData := events[series.ID]
+		var playerTeams map[string]string
+		if eventData != nil {
+			playerTeams = lolPlayerTeams(eventData)
+		}
+
+		for _, game := range series.Games {
+			if !game.Finished {
+				continue
+			}
+			var ourTeam *grid.GameTeam
+			for i := range game.Teams {
+				if game.Teams[i].ID == teamID || (teamName != "" && game.Teams[i].Name == teamName) {
+					ourTeam = &game.Teams[i]
+				}
+			}
+			if ourTeam == nil {
+				continue
+			}
+			t := totals[strings.ToLower(ourTeam.Side)]
+			if t == nil {
+				continue
+			}
+
+			minutes := float64(game.Duration) / 60.0
+			t.stats.Games++
+			t.duration += minutes
+			if ourTeam.Won {
+				t.stats.Wins++
+				t.winDuration += minutes
+			}
+			if diff, ok := goldAt15[game.ID]; ok {
+				t.stats.Games15++
+				t.goldDiff15 += float64(diff)
+			}
+			if pick := firstPick(game.DraftActions, ourTeam.ID); pick != "" {
+				t.stats.FirstPicks[pick]++
+			}
+
+			if eventData != nil {
+				t.addFirsts(eventData, game.ID, ourTeam.ID, playerTeams)
+			}
+		}
+	}
+
+	for _, t := range totals {
+		t.finish()
+	}
+
+	if analysis.Blue.Games >= minSideGames && analysis.Red.Games >= minSideGames {
+		switch gap := analysis.Blue.WinRate - analysis.Red.WinRate; {
+		case gap >= clearSideSplit:
+			analysis.PreferredSide = "blue"
+		case gap <= -clearSideSplit:
+			analysis.PreferredSide = "red"
+		}
+	}
+
+	return analysis
+}
+
+// addFirsts records which team got first blood, dragon, herald and tower in a game
+func (t *sideTotals) addFirsts(eventData *grid.LoLEventData, gameID, ourID string, playerTeams map[string]string) {
+	type first struct {
+		time int
+		team string
+	}
+	var blood, dragon, herald, tower *first
+	earliest := func(current **first, gameTime int, team string) {
+		if team != "" && (*current == nil || gameTime < (*current).time) {
+			*current = &first{gameTime, team}
+		}
+	}
+
+	for _, kill := range eventData.Kills {
+		if kill.GameID == gameID {
+			earliest(&blood, kill.GameTime, kill.KillerTeamID)
+		}
+	}
+	for _, d := range eventData.DragonKills {
+		if d.GameID == gameID {
+			earliest(&dragon, d.GameTime, d.TeamID)
+		}
+	}
+	for _, h := range eventData.HeraldKills {
+		if h.GameID == gameID {
+			earliest(&herald, h.GameTime, h.TeamID)
+		}
+	}
+	for _, tw := range eventData.TowerDestroys {
+		if tw.GameID == gameID {
+			earliest(&tower, tw.GameTime, towerTeam(tw, playerTeams))
+		}
+	}
+
+	if blood != nil {
+		t.bloodGames++
+		if blood.team == ourID {
+			t.bloods++
+		}
+	}
+	if dragon != nil {
+		t.dragonGames++
+		if dragon.team == ourID {
+			t.dragons++
+		}
+	}
+	if herald != nil {
+		t.heraldGames++
+		if herald.team == ourID {
+			t.heralds++
+		}
+	}
+	if tower != nil {
+		t.towerGames++
+		if tower.team == ourID {
+			t.towers++
+		}
+	}
+}
+
+// finish turns the side's totals into rates and averages
+func (t *sideTotals) finish() {
+	s := t.stats
+	rate := func(n, of int) float64 {
+		if of == 0 {
+			return 0
+		}
+		return float64(n) / float64(of)
+	}
+
+	s.WinRate = rate(s.Wins, s.Games)
+	s.FirstBloodRate = rate(t.bloods, t.bloodGames)
+	s.FirstDragonRate = rate(t.dragons, t.dragonGames)
+	s.FirstHeraldRate = rate(t.heralds, t.heraldGames)
+	s.FirstTowerRate = rate(t.towers, t.towerGames)
+	if s.Games > 0 {
+		s.AvgGameDuration = t.duration / float64(s.Games)
+	}
+	if s.Wins > 0 {
+		s.AvgWinDuration = t.winDuration / float64(s.Wins)
+	}
+	if s.Games15 > 0 {
+		s.GoldDiff15 = t.goldDiff15 / float64(s.Games15)
+	}
+
+	count := 0
+	for _, champion := range sortedKeys(s.FirstPicks) {
+		if s.FirstPicks[champion] > count {
+			s.TopFirstPick, count = champion, s.FirstPicks[champion]
+		}
+	}
+}
+
+// firstPick is the team's earliest pick in a game's draft
+func firstPick(actions []grid.DraftAction, teamID string) string {
+	picks := make([]grid.DraftAction, 0)
+	for _, action := range actions {
+		if action.Action == "pick" && action.TeamID == teamID && action.CharacterName != "" {
+			picks = append(picks, action)
+		}
+	}
+	if len(picks) == 0 {
+		return ""
+	}
+	sort.SliceStable(picks, func(i, j int) bool { return picks[i].Sequence < picks[j].Sequence })
+	return picks[0].CharacterName
+}
+
+// RecommendSide picks our side for when we have side selection: the side that puts
+// the opponent on their weaker side, or blue for first pick when they have no
+// clear preference
+func RecommendSide(analysis *SideAnalysis) *SideRecommendation {
+	if analysis == nil || analysis.Blue.Games+analysis.Red.Games == 0 {
+		return nil
+	}
+	blue, red := analysis.Blue, analysis.Red
+	rec := &SideRecommendation{
+		When:       "Game 1 side selection, or after losing a game",
+		SampleSize: blue.Games + red.Games,
+		Evidence: []string{
+			sideEvidence(blue),
+			sideEvidence(red),
+		},
+	}
+
+	switch analysis.PreferredSide {
+	case "blue":
+		rec.Side = "blue"
+		rec.Reason = fmt.Sprintf("Take blue to put them on red, where they win %.0f%% of games vs %.0f%% on blue",
+			red.WinRate*100, blue.WinRate*100)
+	case "red":
+		rec.Side = "red"
+		rec.Reason = fmt.Sprintf("Take red to put them on blue, where they win %.0f%% of games vs %.0f%% on red",
+			blue.WinRate*100, red.WinRate*100)
+	default:
+		rec.Side = "blue"
+		rec.Reason = "No clear side preference - take blue for first pick"
+		rec.Confidence = 0.5
+		if blue.TopFirstPick != "" && blue.FirstPicks[blue.TopFirstPick]*2 >= blue.Games {
+			rec.Evidence = append(rec.Evidence, fmt.Sprintf("On blue they first-pick %s (%d of %d games); on red they cannot lock it in before your first pick",
+				blue.TopFirstPick, blue.FirstPicks[blue.TopFirstPick], blue.Games))
+		}
+		return rec
+	}
+
+	// Confidence grows with the win rate gap and with games on the thinner side
+	gap := math.Abs(blue.WinRate - red.WinRate)
+	sample := math.Min(1, float64(min(blue.Games, red.Games))/10)
+	rec.Confidence = math.Min(0.9, 0.4+gap*sample)
+	return rec
+}
+
+// sideEvidence summarizes one side's record
+func sideEvidence(s SideStats) string {
+	if s.Games == 0 {
+		return fmt.Sprintf("No games on %s side", s.Side)
+	}
+	text := fmt.Sprintf("%s side: %.0f%% win rate (%d games), first blood %.0f%%, first dragon %.0f%%, first tower %.0f%%, %.0f min average game",
+		strings.ToUpper(s.Side[:1])+s.Side[1:], s.WinRate*100, s.Games,
+		s.FirstBloodRate*100, s.FirstDragonRate*100, s.FirstTowerRate*100, s.AvgGameDuration)
+	if s.Games15 > 0 {
+		text += fmt.Sprintf(", %+.0f gold at 15", s.GoldDiff15)
+	}
+	return text
+}
@@ -14,6 +14,7 @@ import (
 type SiteAnalyzerEngine struct {
 	stats     *StatisticalEngine
 	validator *Validator
+	postPlant *PostPlantAnalyzer
 }
 
 // NewSiteAnalyzer creates a new site analyzer with statistical components
@@ -21,11 +22,12 @@ func NewSiteAnalyzer() *SiteAnalyzerEngine {
 	return &SiteAnalyzerEngine{
 		stats:     NewStatisticalEngine(),
 		validator: NewValidator(),
+		postPlant: NewPostPlantAnalyzer(),
 	}
 }
 
-// VALORANT map site configurations with learned boundaries
-// These are refined from real GRID data analysis and can be updated with more data
+// VALORANT map site configurations with hand-drawn boundaries, used when the
+// learned site geometry (data/site_geometry.json) does not cover a map
 var mapSiteConfigs = map[string]*MapSiteConfig{
 	"ascent": {
 		MapName: "Ascent",
@@ -164,17 +166,40 @@ func (a *SiteAnalyzerEngine) classifyFromPosition(pos *grid.Position, mapName st
 		}
 	}
 
+	// Learned site polygons take priority over the hand-drawn ellipses
+	if site, inside := grid.CurrentSiteGeometry().ForMap(mapName).Site(pos); site != "" {
+		confidence := 0.6
+		if inside {
+			confidence = 0.95
+		}
+		return &SiteClassification{
+			Site:       site,
+			Confidence: confidence,
+			Method:     "learned",
+		}
+	}
+
 	config := mapSiteConfigs[strings.ToLower(mapName)]
 	if config == nil {
 		// Use generic classification for unknown maps
 		return a.genericSiteClassification(pos)
 	}
 
-	// Find closest site center using normalized distance
+	closestSite, confidence := ellipseSite(pos, config)
+	return &SiteClassification{
+		Site:       closestSite,
+		Confidence: confidence,
+		Method:     "position",
+	}
+}
+
+// ellipseSite finds the closest hand-drawn site ellipse to a position
+func ellipseSite(pos *grid.Position, config *MapSiteConfig) (string, float64) {
 	var closestSite string
 	minDist := math.MaxFloat64
 
-	for siteName, boundary := range config.Sites {
+	for _, siteName := range sortedKeys(config.Sites) {
+		boundary := config.Sites[siteName]
 		// Normalized distance (accounts for elliptical boundaries)
 		dx := (pos.X - boundary.CenterX) / boundary.RadiusX
 		dy := (pos.Y - boundary.CenterY) / boundary.RadiusY
@@ -189,13 +214,7 @@ func (a *SiteAnalyzerEngine) classifyFromPosition(pos *grid.Position, mapName st
 	// Calculate confidence based on distance
 	// dist < 1.0 means inside the ellipse, higher confidence
 	// dist > 1.0 means outside, lower confidence
-	confidence := math.Max(0.0, 1.0-minDist/2.0)
-
-	return &SiteClassification{
-		Site:       closestSite,
-		Confidence: confidence,
-		Method:     "position",
-	}
+	return closestSite, math.Max(0.0, 1.0-minDist/2.0)
 }
 
 // genericSiteClassification for unknown maps
@@ -252,7 +271,8 @@ func (a *SiteAnalyzerEngine) AnalyzeSitePatterns(
 ) map[string]*SiteAnalysis {
 	mapAnalysis := make(map[string]*SiteAnalysis)
 
-	for seriesID, eventData := range events {
+	for _, seriesID := range sortedKeys(events) {
+		eventData := events[seriesID]
 		if eventData == nil {
 			continue
 		}
@@ -371,31 +391,16 @@ func (a *SiteAnalyzerEngine) AnalyzeSitePatterns(
 					}
 				}
 			}
+		}
+	}
 
-			// Analyze retakes
-			for _, defuse := range eventData.Defuses {
-				if defuse.TeamID != teamID && defuse.TeamID != ourTeam.ID {
-					continue
-				}
-
-				var plantSite string
-				for _, plant := range eventData.Plants {
-					if plant.RoundNum == defuse.RoundNum {
-						plantSite = plant.Site
-						if plantSite == "" && plant.Position != nil {
-							plantSite = inferSiteFromPositionEnhanced(plant.Position, mapName)
-						}
-						plantSite = normalizeSiteName(plantSite)
-						break
-					}
-				}
-
-				if plantSite != "" {
-					if siteStats, exists := analysis.Sites[plantSite]; exists {
-						siteStats.RetakeAttempts++
-						siteStats.RetakeSuccesses++
-					}
-				}
+	// Retakes come from the post-plant replay: a retake is any round where the
+	// team engaged or defused after the opponent's plant
+	for _, site := range a.postPlant.AnalyzePostPlants(teamID, "", seriesStates, events).Sites {
+		if analysis, ok := mapAnalysis[site.MapName]; ok {
+			if siteStats, ok := analysis.Sites[site.Site]; ok {
+				siteStats.RetakeAttempts = site.Retakes
+				siteStats.RetakeSuccesses = site.RetakeWins
 			}
 		}
 	}
@@ -432,7 +437,8 @@ func (a *SiteAnalyzerEngine) AnalyzeAttackPatterns(
 	// Track which plants we've already processed to avoid duplicates
 	processedPlants := make(map[string]bool)
 
-	for seriesID, eventData := range events {
+	for _, seriesID := range sortedKeys(events) {
+		eventData := events[seriesID]
 		if eventData == nil {
 			continue
 		}
@@ -669,7 +675,8 @@ func (a *SiteAnalyzerEngine) AnalyzePistolRoundPatterns(
 	}
 
 	// Generate insights in HACKATHON FORMAT
-	for mapName, patterns := range patternsByMap {
+	for _, mapName := range sortedKeys(patternsByMap) {
+		patterns := patternsByMap[mapName]
 		total := totalPistolsByMap[mapName]
 		if total < 2 {
 			continue
@@ -709,11 +716,13 @@ func (a *SiteAnalyzerEngine) GenerateSiteInsights(
 ) []StrategyInsight {
 	insights := make([]StrategyInsight, 0)
 
-	for mapName, analysis := range siteAnalysis {
+	for _, mapName := range sortedKeys(siteAnalysis) {
+		analysis := siteAnalysis[mapName]
 		var mostAttackedSite string
 		var maxAttacks int
 
-		for siteName, stats := range analysis.Sites {
+		for _, siteName := range sortedKeys(analysis.Sites) {
+			stats := analysis.Sites[siteName]
 			if stats.AttackAttempts > maxAttacks {
 				maxAttacks = stats.AttackAttempts
 				mostAttackedSite = siteName
@@ -764,11 +773,13 @@ func (a *SiteAnalyzerEngine) GenerateDefenseInsights(
 ) []StrategyInsight {
 	insights := make([]StrategyInsight, 0)
 
-	for mapName, analysis := range siteAnalysis {
+	for _, mapName := range sortedKeys(siteAnalysis) {
+		analysis := siteAnalysis[mapName]
 		var weakestSite string
 		var lowestWinRate float64 = 1.0
 
-		for siteName, stats := range analysis.Sites {
+		for _, siteName := range sortedKeys(analysis.Sites) {
+			stats := analysis.Sites[siteName]
 			if stats.DefenseAttempts >= 3 && stats.DefenseWinRate < lowestWinRate {
 				lowestWinRate = stats.DefenseWinRate
 				weakestSite = siteName
@@ -789,7 +800,8 @@ func (a *SiteAnalyzerEngine) GenerateDefenseInsights(
 		var strongestSite string
 		var highestWinRate float64 = 0.0
 
-		for siteName, stats := range analysis.Sites {
+		for _, siteName := range sortedKeys(analysis.Sites) {
+			stats := analysis.Sites[siteName]
 			if stats.DefenseAttempts >= 3 && stats.DefenseWinRate > highestWinRate {
 				highestWinRate = stats.DefenseWinRate
 				strongestSite = siteName
@@ -817,11 +829,13 @@ func (a *SiteAnalyzerEngine) GenerateSiteCounterStrategies(
 ) []InGameStrategyInsight {
 	strategies := make([]InGameStrategyInsight, 0)
 
-	for mapName, analysis := range siteAnalysis {
+	for _, mapName := range sortedKeys(siteAnalysis) {
+		analysis := siteAnalysis[mapName]
 		var weakestDefenseSite string
 		var lowestDefenseRate float64 = 1.0
 
-		for siteName, stats := range analysis.Sites {
+		for _, siteName := range sortedKeys(analysis.Sites) {
+			stats := analysis.Sites[siteName]
 			if stats.DefenseAttempts >= 3 && stats.DefenseWinRate < lowestDefenseRate {
 				lowestDefenseRate = stats.DefenseWinRate
 				weakestDefenseSite = siteName
@@ -841,7 +855,8 @@ func (a *SiteAnalyzerEngine) GenerateSiteCounterStrategies(
 		var weakestAttackSite string
 		var lowestAttackRate float64 = 1.0
 
-		for siteName, stats := range analysis.Sites {
+		for _, siteName := range sortedKeys(analysis.Sites) {
+			stats := analysis.Sites[siteName]
 			if stats.AttackAttempts >= 3 && stats.AttackWinRate < lowestAttackRate {
 				lowestAttackRate = stats.AttackWinRate
 				weakestAttackSite = siteName
@@ -954,7 +969,8 @@ func (a *SiteAnalyzerEngine) GenerateDefenseSetups(
 
 	// Build defense setup insights
 	setups := make([]DefenseSetup, 0)
-	for setup, count := range setupCounts {
+	for _, setup := range sortedKeys(setupCounts) {
+		count := setupCounts[setup]
 		if count >= 2 {
 			frequency := float64(count) / float64(max(totalDefenseRounds, 1))
 			successRate := float64(setupWins[setup]) / float64(count)
@@ -981,6 +997,14 @@ func inferPositionFromKill(pos *grid.Position, mapName string) string {
 		return ""
 	}
 
+	// Learned regions when the map has been calibrated
+	if region := grid.CurrentSiteGeometry().ForMap(mapName).Region(pos); region != "" {
+		if len(region) == 1 {
+			return region + "-Site"
+		}
+		return region
+	}
+
 	// Determine site/area based on position
 	x := pos.X
 
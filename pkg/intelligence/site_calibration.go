@@ -0,0 +1,563 @@
+package intelligence
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"scout9/pkg/grid"
+)
+
+// SiteCalibrator learns VALORANT site polygons from plant positions and mid and
+// lobby regions from kill positions
+type SiteCalibrator struct {
+	MinPlants int     // plants a map needs before its sites are learned
+	MinKills  int     // kill positions a mid or lobby region needs
+	Padding   float64 // map units added around each learned polygon
+	Folds     int     // hold-out folds for the accuracy report
+}
+
+// NewSiteCalibrator creates a calibrator with conservative sample thresholds
+func NewSiteCalibrator() *SiteCalibrator {
+	return &SiteCalibrator{
+		MinPlants: 20,
+		MinKills:  15,
+		Padding:   400,
+		Folds:     5,
+	}
+}
+
+// calibrationSamples are the positions seen on one map
+type calibrationSamples struct {
+	mapName   string
+	plants    []grid.Point
+	kills     []grid.Point
+	attackers []grid.Point // kill positions of attacking players
+}
+
+// Calibrate learns geometry for every map with enough plants. Maps without enough
+// data keep their geometry from previous, which may be nil.
+func (c *SiteCalibrator) Calibrate(events map[string]*grid.VALEventData, previous *grid.SiteGeometry) (*grid.SiteGeometry, *SiteCalibrationReport) {
+	geometry := &grid.SiteGeometry{
+		Version:     grid.SiteGeometryVersion,
+		Revision:    1,
+		GeneratedAt: time.Now().UTC(),
+		Maps:        make(map[string]*grid.MapGeometry),
+	}
+	if previous != nil {
+		geometry.Revision = previous.Revision + 1
+		for key, m := range previous.Maps {
+			geometry.Maps[key] = m
+		}
+	}
+	report := &SiteCalibrationReport{
+		Revision:    geometry.Revision,
+		GeneratedAt: geometry.GeneratedAt,
+		Maps:        make([]*MapCalibrationReport, 0),
+	}
+
+	samples := collectCalibrationSamples(events)
+	for _, key := range sortedKeys(samples) {
+		m, mapReport := c.calibrateMap(samples[key])
+		report.Maps = append(report.Maps, mapReport)
+		if m != nil {
+			geometry.Maps[key] = m
+		}
+	}
+
+	return geometry, report
+}
+
+// collectCalibrationSamples groups plant and kill positions by map
+func collectCalibrationSamples(events map[string]*grid.VALEventData) map[string]*calibrationSamples {
+	samples := make(map[string]*calibrationSamples)
+	get := func(mapName, fallback string) *calibrationSamples {
+		if mapName == "" {
+			mapName = fallback
+		}
+		key := strings.ToLower(strings.TrimSpace(mapName))
+		if key == "" || key == "unknown" {
+			return nil
+		}
+		if samples[key] == nil {
+			samples[key] = &calibrationSamples{mapName: vetoMapName(mapName)}
+		}
+		return samples[key]
+	}
+	point := func(pos *grid.Position) grid.Point {
+		return grid.Point{X: pos.X, Y: pos.Y}
+	}
+
+	for _, seriesID := range sortedKeys(events) {
+		data := events[seriesID]
+		if data == nil {
+			continue
+		}
+
+		// Attacking team of each round, from round ends and plants
+		attackers := make(map[string]string)
+		roundKey := func(gameID string, round int) string {
+			return fmt.Sprintf("%s|%d", gameID, round)
+		}
+		for _, re := range data.RoundEnds {
+			if re.AttackTeam != "" {
+				attackers[roundKey(re.GameID, re.RoundNum)] = re.AttackTeam
+			}
+		}
+
+		for _, plant := range data.Plants {
+			if plant.TeamID != "" {
+				attackers[roundKey(plant.GameID, plant.RoundNum)] = plant.TeamID
+			}
+			if !IsValidVALPosition(plant.Position) {
+				continue
+			}
+			if s := get(plant.MapName, data.MapName); s != nil {
+				s.plants = append(s.plants, point(plant.Position))
+			}
+		}
+
+		for _, kill := range data.Kills {
+			s := get(kill.MapName, data.MapName)
+			if s == nil {
+				continue
+			}
+			attack := attackers[roundKey(kill.GameID, kill.RoundNum)]
+			if IsValidVALPosition(kill.KillerPosition) {
+				s.kills = append(s.kills, point(kill.KillerPosition))
+				if attack != "" && kill.KillerTeamID == attack {
+					s.attackers = append(s.attackers, point(kill.KillerPosition))
+				}
+			}
+			if IsValidVALPosition(kill.VictimPosition) {
+				s.kills = append(s.kills, point(kill.VictimPosition))
+				if attack != "" && kill.VictimTeamID == attack {
+					s.attackers = append(s.attackers, point(kill.VictimPosition))
+				}
+			}
+		}
+	}
+
+	return samples
+}
+
+// calibrateMap learns one map's geometry and measures it
+func (c *SiteCalibrator) calibrateMap(s *calibrationSamples) (*grid.MapGeometry, *MapCalibrationReport) {
+	report := &MapCalibrationReport{
+		MapName: s.mapName,
+		Plants:  len(s.plants),
+		Kills:   len(s.kills),
+	}
+	if len(s.plants) < c.MinPlants {
+		report.Skipped = fmt.Sprintf("%d plants, need %d", len(s.plants), c.MinPlants)
+		return nil, report
+	}
+
+	// Known maps keep their site count; new maps get whichever of 2 or 3 sites separates best
+	config := mapSiteConfigs[strings.ToLower(s.mapName)]
+	var centers []grid.Point
+	var assign []int
+	if config != nil {
+		centers, assign = kMeans(s.plants, len(config.Sites))
+		report.Silhouette = silhouette(s.plants, assign, len(centers))
+	} else {
+		for _, k := range []int{2, 3} {
+			ck, ak := kMeans(s.plants, k)
+			if score := silhouette(s.plants, ak, k); centers == nil || score > report.Silhouette {
+				centers, assign, report.Silhouette = ck, ak, score
+			}
+		}
+	}
+	labels := c.labelClusters(s, centers, assign)
+
+	m := &grid.MapGeometry{
+		MapName: s.mapName,
+		Sites:   make(map[string]*grid.Region),
+		Lobbies: make(map[string]*grid.Region),
+		Plants:  len(s.plants),
+		Kills:   len(s.kills),
+	}
+	report.Sites = make(map[string]int)
+	for cluster, label := range labels {
+		var points []grid.Point
+		for i, a := range assign {
+			if a == cluster {
+				points = append(points, s.plants[i])
+			}
+		}
+		report.Sites[label] = len(points)
+		if region := c.region(label, points); region != nil {
+			m.Sites[label] = region
+		}
+	}
+	if len(m.Sites) < 2 {
+		report.Skipped = "site clusters too small to draw"
+		return nil, report
+	}
+
+	// Mid: kills outside the sites, near the middle of the site centers
+	var siteCenter grid.Point
+	var spread float64
+	siteNames := sortedKeys(m.Sites)
+	for _, name := range siteNames {
+		siteCenter.X += m.Sites[name].Center.X / float64(len(siteNames))
+		siteCenter.Y += m.Sites[name].Center.Y / float64(len(siteNames))
+	}
+	for i, a := range siteNames {
+		for _, b := range siteNames[i+1:] {
+			spread = math.Max(spread, pointDistance(m.Sites[a].Center, m.Sites[b].Center))
+		}
+	}
+	inSite := func(p grid.Point) bool {
+		for _, site := range m.Sites {
+			if site.Contains(p) {
+				return true
+			}
+		}
+		return false
+	}
+	var mid []grid.Point
+	for _, p := range s.kills {
+		if !inSite(p) && pointDistance(p, siteCenter) <= 0.35*spread {
+			mid = append(mid, p)
+		}
+	}
+	if len(mid) >= c.MinKills {
+		m.Mid = c.region("Mid", mid)
+		report.MidSamples = len(mid)
+	}
+
+	// Lobbies: attacker positions outside the sites and mid, by nearest site
+	lobbies := make(map[string][]grid.Point)
+	for _, p := range s.attackers {
+		if inSite(p) || m.Mid.Contains(p) {
+			continue
+		}
+		site, _ := m.Site(&grid.Position{X: p.X, Y: p.Y})
+		lobbies[site] = append(lobbies[site], p)
+	}
+	for _, site := range sortedKeys(lobbies) {
+		if len(lobbies[site]) < c.MinKills {
+			continue
+		}
+		if region := c.region(site+" Lobby", lobbies[site]); region != nil {
+			m.Lobbies[site] = region
+			report.LobbySamples += len(lobbies[site])
+		}
+	}
+
+	c.measure(s, m, centers, assign, labels, report)
+	report.Learned = true
+	return m, report
+}
+
+// labelClusters names site clusters: clusters are ordered by X, then every letter
+// assignment is tried and the one agreeing most with the hand-drawn boundaries wins
+func (c *SiteCalibrator) labelClusters(s *calibrationSamples, centers []grid.Point, assign []int) []string {
+	letters := []string{"A", "B", "C"}
+	if config := mapSiteConfigs[strings.ToLower(s.mapName)]; config != nil && len(config.Sites) == len(centers) {
+		letters = sortedKeys(config.Sites)
+	}
+	letters = letters[:len(centers)]
+
+	order := make([]int, len(centers))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return centers[order[i]].X < centers[order[j]].X })
+
+	legacy := make([]string, len(s.plants))
+	for i, p := range s.plants {
+		legacy[i] = grid.HandDrawnSite(&grid.Position{X: p.X, Y: p.Y}, s.mapName)
+	}
+
+	best, bestScore := make([]string, len(centers)), -1
+	for _, perm := range permutations(len(centers)) {
+		labels := make([]string, len(centers))
+		for rank, cluster := range order {
+			labels[cluster] = letters[perm[rank]]
+		}
+		score := 0
+		for i, a := range assign {
+			if legacy[i] == labels[a] {
+				score++
+			}
+		}
+		if score > bestScore {
+			best, bestScore = labels, score
+		}
+	}
+	return best
+}
+
+// measure fills in the accuracy report for a learned map
+func (c *SiteCalibrator) measure(s *calibrationSamples, m *grid.MapGeometry, centers []grid.Point, assign []int, labels []string, report *MapCalibrationReport) {
+	config := mapSiteConfigs[strings.ToLower(s.mapName)]
+	var inside, gridSeen, gridAgree, ellipseSeen, ellipseAgree, legacySeen, legacyAgree int
+	for _, p := range s.plants {
+		pos := &grid.Position{X: p.X, Y: p.Y}
+		learned, in := m.Site(pos)
+		if in {
+			inside++
+		}
+		handDrawn := grid.HandDrawnSite(pos, s.mapName)
+		if handDrawn != "" {
+			gridSeen++
+			if handDrawn == learned {
+				gridAgree++
+			}
+		}
+		if config != nil {
+			ellipse, _ := ellipseSite(pos, config)
+			ellipseSeen++
+			if ellipse == learned {
+				ellipseAgree++
+			}
+			if handDrawn != "" {
+				legacySeen++
+				if handDrawn == ellipse {
+					legacyAgree++
+				}
+			}
+		}
+	}
+	rate := func(n, d int) float64 {
+		if d == 0 {
+			return 0
+		}
+		return float64(n) / float64(d)
+	}
+	report.Coverage = rate(inside, len(s.plants))
+	report.GridAgreement = rate(gridAgree, gridSeen)
+	report.EllipseAgreement = rate(ellipseAgree, ellipseSeen)
+	report.LegacyAgreement = rate(legacyAgree, legacySeen)
+
+	// Hold-out: re-cluster without each fold and classify the held-out plants
+	correct, tested := 0, 0
+	for fold := 0; fold < c.Folds; fold++ {
+		var train []grid.Point
+		for i, p := range s.plants {
+			if i%c.Folds != fold {
+				train = append(train, p)
+			}
+		}
+		if len(train) < len(centers) {
+			continue
+		}
+		trainCenters, _ := kMeans(train, len(centers))
+		for i, p := range s.plants {
+			if i%c.Folds != fold {
+				continue
+			}
+			// The held-out plant's training cluster, named after the full-data site it matches
+			predicted := labels[nearestPoint(centers, trainCenters[nearestPoint(trainCenters, p)])]
+			tested++
+			if predicted == labels[assign[i]] {
+				correct++
+			}
+		}
+	}
+	report.HoldoutAccuracy = rate(correct, tested)
+}
+
+// region draws a padded convex polygon around points, dropping outliers first
+func (c *SiteCalibrator) region(name string, points []grid.Point) *grid.Region {
+	if len(points) < 3 {
+		return nil
+	}
+	center := centroid(points)
+	dists := make([]float64, len(points))
+	for i, p := range points {
+		dists[i] = pointDistance(p, center)
+	}
+	sorted := append([]float64(nil), dists...)
+	sort.Float64s(sorted)
+	limit := 2.5 * sorted[len(sorted)/2]
+
+	var kept []grid.Point
+	for i, p := range points {
+		if dists[i] <= limit {
+			kept = append(kept, p)
+		}
+	}
+	hull := convexHull(kept)
+	if len(hull) < 3 {
+		return nil
+	}
+	center = centroid(kept)
+	for i, p := range hull {
+		d := pointDistance(p, center)
+		if d > 0 {
+			hull[i] = grid.Point{
+				X: p.X + (p.X-center.X)/d*c.Padding,
+				Y: p.Y + (p.Y-center.Y)/d*c.Padding,
+			}
+		}
+	}
+
+	return &grid.Region{
+		Name:    name,
+		Polygon: hull,
+		Center:  center,
+		Samples: len(kept),
+	}
+}
+
+func pointDistance(a, b grid.Point) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
+
+func centroid(points []grid.Point) grid.Point {
+	var c grid.Point
+	for _, p := range points {
+		c.X += p.X
+		c.Y += p.Y
+	}
+	c.X /= float64(len(points))
+	c.Y /= float64(len(points))
+	return c
+}
+
+// nearestPoint returns the index of the point in points closest to p
+func nearestPoint(points []grid.Point, p grid.Point) int {
+	nearest, best := 0, math.MaxFloat64
+	for i, q := range points {
+		if d := pointDistance(p, q); d < best {
+			nearest, best = i, d
+		}
+	}
+	return nearest
+}
+
+// kMeans clusters points into k groups, seeded deterministically with farthest-point picks
+func kMeans(points []grid.Point, k int) ([]grid.Point, []int) {
+	first := 0
+	for i, p := range points {
+		if p.X < points[first].X {
+			first = i
+		}
+	}
+	centers := []grid.Point{points[first]}
+	for len(centers) < k {
+		far, best := 0, -1.0
+		for i, p := range points {
+			if d := pointDistance(p, centers[nearestPoint(centers, p)]); d > best {
+				far, best = i, d
+			}
+		}
+		centers = append(centers, points[far])
+	}
+
+	assign := make([]int, len(points))
+	for iter := 0; iter < 50; iter++ {
+		changed := iter == 0
+		for i, p := range points {
+			if c := nearestPoint(centers, p); c != assign[i] {
+				assign[i] = c
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+		sums := make([]grid.Point, k)
+		counts := make([]int, k)
+		for i, p := range points {
+			sums[assign[i]].X += p.X
+			sums[assign[i]].Y += p.Y
+			counts[assign[i]]++
+		}
+		for c := range centers {
+			if counts[c] > 0 {
+				centers[c] = grid.Point{X: sums[c].X / float64(counts[c]), Y: sums[c].Y / float64(counts[c])}
+			}
+		}
+	}
+	return centers, assign
+}
+
+// silhouette is the mean silhouette coefficient of a clustering
+func silhouette(points []grid.Point, assign []int, k int) float64 {
+	if len(points) < 2 || k < 2 {
+		return 0
+	}
+	total := 0.0
+	for i, p := range points {
+		sums := make([]float64, k)
+		counts := make([]int, k)
+		for j, q := range points {
+			if i != j {
+				sums[assign[j]] += pointDistance(p, q)
+				counts[assign[j]]++
+			}
+		}
+		if counts[assign[i]] == 0 {
+			continue
+		}
+		a := sums[assign[i]] / float64(counts[assign[i]])
+		b := math.MaxFloat64
+		for c := 0; c < k; c++ {
+			if c != assign[i] && counts[c] > 0 {
+				b = math.Min(b, sums[c]/float64(counts[c]))
+			}
+		}
+		if b == math.MaxFloat64 {
+			continue
+		}
+		total += (b - a) / math.Max(a, b)
+	}
+	return total / float64(len(points))
+}
+
+// convexHull returns the hull of points in counter-clockwise order (monotone chain)
+func convexHull(points []grid.Point) []grid.Point {
+	pts := append([]grid.Point(nil), points...)
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i].X != pts[j].X {
+			return pts[i].X < pts[j].X
+		}
+		return pts[i].Y < pts[j].Y
+	})
+	if len(pts) < 3 {
+		return pts
+	}
+	cross := func(o, a, b grid.Point) float64 {
+		return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+	}
+
+	hull := make([]grid.Point, 0, 2*len(pts))
+	for _, p := range pts {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(pts) - 2; i >= 0; i-- {
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], pts[i]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, pts[i])
+	}
+	return hull[:len(hull)-1]
+}
+
+// permutations lists every ordering of 0..n-1, the identity first
+func permutations(n int) [][]int {
+	if n == 0 {
+		return [][]int{{}}
+	}
+	var result [][]int
+	for _, perm := range permutations(n - 1) {
+		for pos := len(perm); pos >= 0; pos-- {
+			next := make([]int, 0, n)
+			next = append(next, perm[:pos]...)
+			next = append(next, n-1)
+			next = append(next, perm[pos:]...)
+			result = append(result, next)
+		}
+	}
+	return result
+}
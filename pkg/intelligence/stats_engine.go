@@ -1,7 +1,11 @@
 package intelligence
 
 import (
+	"cmp"
+	"maps"
 	"math"
+	"math/rand/v2"
+	"slices"
 	"sort"
 )
 
@@ -261,3 +265,159 @@ func (e *StatisticalEngine) CompareDistributions(a, b *Distribution) *Distributi
 		Significance:   significance,
 	}
 }
+
+// SignificanceLevel is the p-value below which a change is treated as real
+const SignificanceLevel = 0.05
+
+// permutationRounds is the number of shuffles used by the permutation tests
+const permutationRounds = 2000
+
+// BinomialTest returns the two-sided exact p-value of seeing successes out of
+// trials when the true success rate is p
+func (e *StatisticalEngine) BinomialTest(successes, trials int, p float64) float64 {
+	if trials <= 0 {
+		return 1
+	}
+	if p <= 0 || p >= 1 {
+		if (p <= 0 && successes == 0) || (p >= 1 && successes == trials) {
+			return 1
+		}
+		return 0
+	}
+
+	// Sum every outcome at most as likely as the observed one
+	observed := binomialCoefficient(trials, successes) * math.Pow(p, float64(successes)) * math.Pow(1-p, float64(trials-successes))
+	pValue := 0.0
+	for k := 0; k <= trials; k++ {
+		pk := binomialCoefficient(trials, k) * math.Pow(p, float64(k)) * math.Pow(1-p, float64(trials-k))
+		if pk <= observed*(1+1e-7) {
+			pValue += pk
+		}
+	}
+	return math.Min(pValue, 1)
+}
+
+// BinomialTailTest returns the one-sided exact p-value of seeing successes out of
+// trials when the true success rate is p, in the direction of the observed rate:
+// P(X >= successes) when it is above p, P(X <= successes) otherwise
+func (e *StatisticalEngine) BinomialTailTest(successes, trials int, p float64) float64 {
+	if trials <= 0 {
+		return 1
+	}
+	if p <= 0 || p >= 1 {
+		if (p <= 0 && successes == 0) || (p >= 1 && successes == trials) {
+			return 1
+		}
+		return 0
+	}
+
+	above := float64(successes)/float64(trials) > p
+	pValue := 0.0
+	for k := 0; k <= trials; k++ {
+		if (above && k < successes) || (!above && k > successes) {
+			continue
+		}
+		pValue += binomialCoefficient(trials, k) * math.Pow(p, float64(k)) * math.Pow(1-p, float64(trials-k))
+	}
+	return math.Min(pValue, 1)
+}
+
+// ChiSquareTest compares two success rates with a Yates-corrected chi-square
+// test on the 2x2 table and returns the p-value
+func (e *StatisticalEngine) ChiSquareTest(successes1, trials1, successes2, trials2 int) float64 {
+	if trials1 <= 0 || trials2 <= 0 {
+		return 1
+	}
+	n := float64(trials1 + trials2)
+	successes := float64(successes1 + successes2)
+	failures := n - successes
+	if successes == 0 || failures == 0 {
+		return 1
+	}
+
+	a, b := float64(successes1), float64(trials1-successes1)
+	c, d := float64(successes2), float64(trials2-successes2)
+	diff := math.Max(math.Abs(a*d-b*c)-n/2, 0)
+	chi2 := n * diff * diff / (float64(trials1) * float64(trials2) * successes * failures)
+
+	// Survival function of chi-square with one degree of freedom
+	return math.Erfc(math.Sqrt(chi2 / 2))
+}
+
+// SlopePermutationTest returns the least-squares slope of values over their
+// order and a two-sided permutation p-value for it. The shuffle seed is fixed
+// so the same values always give the same p-value.
+func (e *StatisticalEngine) SlopePermutationTest(values []float64) (slope, pValue float64) {
+	if len(values) < 3 {
+		return 0, 1
+	}
+	slope = linearSlope(values)
+	if slope == 0 {
+		return 0, 1
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	shuffled := slices.Clone(values)
+	extreme := 0
+	for i := 0; i < permutationRounds; i++ {
+		rng.Shuffle(len(shuffled), func(a, b int) {
+			shuffled[a], shuffled[b] = shuffled[b], shuffled[a]
+		})
+		if math.Abs(linearSlope(shuffled)) >= math.Abs(slope)-1e-12 {
+			extreme++
+		}
+	}
+
+	return slope, float64(extreme+1) / float64(permutationRounds+1)
+}
+
+// MeanDiffPermutationTest returns a two-sided permutation p-value for the
+// difference in means between two samples, with a fixed shuffle seed
+func (e *StatisticalEngine) MeanDiffPermutationTest(a, b []float64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 1
+	}
+	observed := math.Abs(averageFloat64(a) - averageFloat64(b))
+	if observed == 0 {
+		return 1
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	pooled := append(slices.Clone(a), b...)
+	extreme := 0
+	for i := 0; i < permutationRounds; i++ {
+		rng.Shuffle(len(pooled), func(x, y int) {
+			pooled[x], pooled[y] = pooled[y], pooled[x]
+		})
+		if math.Abs(averageFloat64(pooled[:len(a)])-averageFloat64(pooled[len(a):])) >= observed-1e-12 {
+			extreme++
+		}
+	}
+
+	return float64(extreme+1) / float64(permutationRounds+1)
+}
+
+// linearSlope returns the least-squares slope of values against their index
+func linearSlope(values []float64) float64 {
+	n := float64(len(values))
+	var sumX, sumY, sumXY, sumX2 float64
+	for i, v := range values {
+		x := float64(i)
+		sumX += x
+		sumY += v
+		sumXY += x * v
+		sumX2 += x * x
+	}
+
+	denom := n*sumX2 - sumX*sumX
+	if denom == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denom
+}
+
+// sortedKeys returns map keys in ascending order so that map-derived output
+// (rankings, argmax picks, generated insights) is identical across runs
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	return slices.Sorted(maps.Keys(m))
+}
@@ -0,0 +1,317 @@
+package intelligence
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"scout9/pkg/grid"
+)
+
+// StrategyBacktester checks counter-strategy recommendations against what
+// actually happened in later series against the scouted team
+type StrategyBacktester struct {
+	eventAnalyzer *EventAnalyzer
+}
+
+// NewStrategyBacktester creates a new strategy backtester
+func NewStrategyBacktester() *StrategyBacktester {
+	return &StrategyBacktester{
+		eventAnalyzer: NewEventAnalyzer(),
+	}
+}
+
+// RecommendationOutcome records whether one recommendation was followed by the
+// team facing the scouted opponent, and whether that team won
+type RecommendationOutcome struct {
+	SeriesID       string `json:"seriesId"`
+	GameSequence   int    `json:"gameSequence,omitempty"` // 0 for series-level checks
+	Type           string `json:"type"`                   // "ban", "pick", "target", "weak_site", "phase"
+	Recommendation string `json:"recommendation"`
+	ChallengerID   string `json:"challengerId"`
+	Followed       bool   `json:"followed"`
+	ChallengerWon  bool   `json:"challengerWon"`
+}
+
+// RecommendationHitRate aggregates outcomes for one recommendation type
+type RecommendationHitRate struct {
+	Type                string  `json:"type"`
+	Evaluated           int     `json:"evaluated"`
+	Followed            int     `json:"followed"`
+	WinsWhenFollowed    int     `json:"winsWhenFollowed"`
+	WinsWhenNotFollowed int     `json:"winsWhenNotFollowed"`
+	HitRate             float64 `json:"hitRate"` // share of challenger wins where the advice was followed
+	WinRateFollowed     float64 `json:"winRateFollowed"`
+	WinRateNotFollowed  float64 `json:"winRateNotFollowed"`
+	Lift                float64 `json:"lift"` // WinRateFollowed - WinRateNotFollowed
+}
+
+// StrategyBacktestResult is the output of a counter-strategy backtest run
+type StrategyBacktestResult struct {
+	TeamID          string                  `json:"teamId"`
+	TeamName        string                  `json:"teamName"`
+	SeriesEvaluated int                     `json:"seriesEvaluated"`
+	Outcomes        []RecommendationOutcome `json:"outcomes"`
+	HitRates        []RecommendationHitRate `json:"hitRates"`
+}
+
+// weakSiteTitle matches weaknesses produced by enhanceVALStrategyWithInsights
+var weakSiteTitle = regexp.MustCompile(`^Weak (\w+)-Site Defense on (.+)$`)
+
+// EvaluateSeries checks a strategy generated before the series against what the
+// opponent's challengers did in it. Draft and site advice is checked per game;
+// the predicted weak phase is checked once per series.
+func (b *StrategyBacktester) EvaluateSeries(
+	teamAnalysis *TeamAnalysis,
+	strategy *CounterStrategy,
+	state *grid.SeriesState,
+	lolEvents *grid.LoLEventData,
+	valEvents *grid.VALEventData,
+) []RecommendationOutcome {
+	outcomes := make([]RecommendationOutcome, 0)
+	if strategy == nil || state == nil {
+		return outcomes
+	}
+	teamID := strategy.TeamID
+
+	for _, game := range state.Games {
+		if !game.Finished {
+			continue
+		}
+
+		var opponent, challenger *grid.GameTeam
+		for i := range game.Teams {
+			if game.Teams[i].ID == teamID {
+				opponent = &game.Teams[i]
+			} else {
+				challenger = &game.Teams[i]
+			}
+		}
+		if opponent == nil || challenger == nil {
+			continue
+		}
+
+		outcomes = append(outcomes, b.evaluateDraft(strategy, state.ID, game, opponent, challenger)...)
+		if valEvents != nil {
+			outcomes = append(outcomes, b.evaluateWeakSites(strategy, state.ID, game, challenger, valEvents)...)
+		}
+	}
+
+	if teamAnalysis != nil && teamAnalysis.LoLMetrics != nil && lolEvents != nil {
+		if outcome, ok := b.evaluatePhase(teamAnalysis, state, lolEvents); ok {
+			outcomes = append(outcomes, outcome)
+		}
+	}
+
+	return outcomes
+}
+
+// evaluateDraft checks ban, pick and target recommendations for one game
+func (b *StrategyBacktester) evaluateDraft(
+	strategy *CounterStrategy,
+	seriesID string,
+	game grid.Game,
+	opponent, challenger *grid.GameTeam,
+) []RecommendationOutcome {
+	outcomes := make([]RecommendationOutcome, 0)
+
+	challengerBans := make(map[string]bool)
+	challengerPicks := make(map[string]bool)
+	for _, action := range game.DraftActions {
+		if action.TeamID != challenger.ID {
+			continue
+		}
+		name := strings.ToLower(action.CharacterName)
+		switch action.Action {
+		case "ban":
+			challengerBans[name] = true
+		case "pick":
+			challengerPicks[name] = true
+		}
+	}
+	for _, p := range challenger.Players {
+		if p.Character != "" {
+			challengerPicks[strings.ToLower(p.Character)] = true
+		}
+	}
+	opponentPicks := make(map[string]bool)
+	for _, p := range opponent.Players {
+		if p.Character != "" {
+			opponentPicks[strings.ToLower(p.Character)] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, rec := range strategy.DraftRecommendations {
+		key := rec.Type + ":" + strings.ToLower(rec.Character)
+		if rec.Character == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		name := strings.ToLower(rec.Character)
+		var followed bool
+		switch rec.Type {
+		case "ban":
+			// Bans can only be checked when the draft was recorded
+			if len(game.DraftActions) == 0 {
+				continue
+			}
+			followed = challengerBans[name]
+		case "pick":
+			followed = challengerPicks[name]
+		case "target":
+			followed = opponentPicks[name]
+		default:
+			continue
+		}
+
+		outcomes = append(outcomes, RecommendationOutcome{
+			SeriesID:       seriesID,
+			GameSequence:   game.Sequence,
+			Type:           rec.Type,
+			Recommendation: rec.Character,
+			ChallengerID:   challenger.ID,
+			Followed:       followed,
+			ChallengerWon:  challenger.Won,
+		})
+	}
+
+	return outcomes
+}
+
+// evaluateWeakSites checks whether the challenger attacked the site the report
+// called weak on this map. It counts as followed when at least half of the
+// challenger's plants on the map went to that site.
+func (b *StrategyBacktester) evaluateWeakSites(
+	strategy *CounterStrategy,
+	seriesID string,
+	game grid.Game,
+	challenger *grid.GameTeam,
+	events *grid.VALEventData,
+) []RecommendationOutcome {
+	outcomes := make([]RecommendationOutcome, 0)
+
+	for _, weakness := range strategy.Weaknesses {
+		match := weakSiteTitle.FindStringSubmatch(weakness.Title)
+		if match == nil || !strings.EqualFold(match[2], game.Map) {
+			continue
+		}
+		site := match[1]
+
+		plants, sitePlants := 0, 0
+		for _, plant := range events.Plants {
+			if plant.TeamID != challenger.ID || !strings.EqualFold(plant.MapName, game.Map) {
+				continue
+			}
+			plants++
+			if strings.EqualFold(plant.Site, site) {
+				sitePlants++
+			}
+		}
+		if plants == 0 {
+			continue
+		}
+
+		outcomes = append(outcomes, RecommendationOutcome{
+			SeriesID:       seriesID,
+			GameSequence:   game.Sequence,
+			Type:           "weak_site",
+			Recommendation: site + " on " + game.Map,
+			ChallengerID:   challenger.ID,
+			Followed:       sitePlants*2 >= plants,
+			ChallengerWon:  challenger.Won,
+		})
+	}
+
+	return outcomes
+}
+
+// evaluatePhase checks whether the challenger won the phase the report
+// identified as the opponent's weakest (lowest phase rating)
+func (b *StrategyBacktester) evaluatePhase(
+	teamAnalysis *TeamAnalysis,
+	state *grid.SeriesState,
+	events *grid.LoLEventData,
+) (RecommendationOutcome, bool) {
+	m := teamAnalysis.LoLMetrics
+	weakest, rating := "early", m.EarlyGameRating
+	if m.MidGameRating < rating {
+		weakest, rating = "mid", m.MidGameRating
+	}
+	if m.LateGameRating < rating {
+		weakest = "late"
+	}
+
+	var challengerID string
+	challengerWon := false
+	for _, t := range state.Teams {
+		if t.ID != teamAnalysis.TeamID {
+			challengerID = t.ID
+			challengerWon = t.Won
+		}
+	}
+	if challengerID == "" {
+		return RecommendationOutcome{}, false
+	}
+
+	phases := b.eventAnalyzer.AnalyzePhases(events, teamAnalysis.TeamID)
+	diff := phases.EarlyKillDiff
+	switch weakest {
+	case "mid":
+		diff = phases.MidKillDiff
+	case "late":
+		diff = phases.LateKillDiff
+	}
+
+	return RecommendationOutcome{
+		SeriesID:       state.ID,
+		Type:           "phase",
+		Recommendation: weakest + " game",
+		ChallengerID:   challengerID,
+		Followed:       diff < 0, // opponent lost the kill trade in that phase
+		ChallengerWon:  challengerWon,
+	}, true
+}
+
+// SummarizeOutcomes aggregates outcomes into hit rates per recommendation type
+func (b *StrategyBacktester) SummarizeOutcomes(outcomes []RecommendationOutcome) []RecommendationHitRate {
+	byType := make(map[string]*RecommendationHitRate)
+	for _, o := range outcomes {
+		hr, ok := byType[o.Type]
+		if !ok {
+			hr = &RecommendationHitRate{Type: o.Type}
+			byType[o.Type] = hr
+		}
+		hr.Evaluated++
+		if o.Followed {
+			hr.Followed++
+			if o.ChallengerWon {
+				hr.WinsWhenFollowed++
+			}
+		} else if o.ChallengerWon {
+			hr.WinsWhenNotFollowed++
+		}
+	}
+
+	rates := make([]RecommendationHitRate, 0, len(byType))
+	for _, hr := range byType {
+		if wins := hr.WinsWhenFollowed + hr.WinsWhenNotFollowed; wins > 0 {
+			hr.HitRate = float64(hr.WinsWhenFollowed) / float64(wins)
+		}
+		if hr.Followed > 0 {
+			hr.WinRateFollowed = float64(hr.WinsWhenFollowed) / float64(hr.Followed)
+		}
+		if notFollowed := hr.Evaluated - hr.Followed; notFollowed > 0 {
+			hr.WinRateNotFollowed = float64(hr.WinsWhenNotFollowed) / float64(notFollowed)
+		}
+		hr.Lift = hr.WinRateFollowed - hr.WinRateNotFollowed
+		rates = append(rates, *hr)
+	}
+
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].Type < rates[j].Type
+	})
+
+	return rates
+}
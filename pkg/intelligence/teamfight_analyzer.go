@@ -0,0 +1,357 @@
+package intelligence
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"scout9/pkg/grid"
+)
+
+// TeamfightAnalyzer clusters LoL kills into teamfights and records who won them
+type TeamfightAnalyzer struct {
+	laneDetector *StatisticalLaneDetector
+}
+
+// NewTeamfightAnalyzer creates a new teamfight analyzer
+func NewTeamfightAnalyzer() *TeamfightAnalyzer {
+	return &TeamfightAnalyzer{
+		laneDetector: NewStatisticalLaneDetector(),
+	}
+}
+
+const (
+	fightGapMs          = 15000  // longest pause between kills of the same fight
+	fightRadius         = 3000.0 // map units a kill may be from the fight's centre
+	minFightKills       = 3
+	minFightSide        = 2     // players each team needs in the fight
+	objectiveLeadMs     = 20000 // objectives this long before a fight's first kill count as its trigger
+	objectiveFollowUpMs = 30000 // and this long after its last kill
+)
+
+// lolObjective is a neutral objective taken in a game
+type lolObjective struct {
+	gameID   string
+	name     string // "dragon", "elder", "baron", "herald", "voidGrub"
+	teamID   string
+	gameTime int
+	position *grid.Position
+}
+
+// fightCluster is a teamfight being built from consecutive kills
+type fightCluster struct {
+	kills     []grid.KillEvent
+	sumX      float64
+	sumY      float64
+	positions int
+}
+
+func (c *fightCluster) add(kill grid.KillEvent) {
+	c.kills = append(c.kills, kill)
+	for _, pos := range []*grid.Position{kill.KillerPosition, kill.VictimPosition} {
+		if pos != nil {
+			c.sumX += pos.X
+			c.sumY += pos.Y
+			c.positions++
+		}
+	}
+}
+
+// centre is the mean kill position, or nil without positions
+func (c *fightCluster) centre() *grid.Position {
+	if c.positions == 0 {
+		return nil
+	}
+	return &grid.Position{X: c.sumX / float64(c.positions), Y: c.sumY / float64(c.positions)}
+}
+
+// joins reports whether a kill continues the fight: soon after its last kill
+// and, when both have positions, close to its centre
+func (c *fightCluster) joins(kill grid.KillEvent) bool {
+	if kill.GameTime-c.kills[len(c.kills)-1].GameTime > fightGapMs {
+		return false
+	}
+	centre := c.centre()
+	pos := kill.VictimPosition
+	if pos == nil {
+		pos = kill.KillerPosition
+	}
+	if centre == nil || pos == nil {
+		return true
+	}
+	return math.Hypot(pos.X-centre.X, pos.Y-centre.Y) <= fightRadius
+}
+
+// AnalyzeTeamfights finds the teamfights in each series' kill feed
+func (t *TeamfightAnalyzer) AnalyzeTeamfights(teamID string, events map[string]*grid.LoLEventData) *TeamfightAnalysis {
+	analysis := &TeamfightAnalysis{
+		ByPhase:     make(map[string]*FightRecord),
+		ByArea:      make(map[string]*FightRecord),
+		ByObjective: make(map[string]*FightRecord),
+		AreaPhase:   make(map[string]map[string]*FightRecord),
+		Teamfights:  make([]Teamfight, 0),
+	}
+
+	for _, seriesID := range sortedKeys(events) {
+		eventData := events[seriesID]
+		if eventData == nil {
+			continue
+		}
+
+		// Kills of each game in time order; events without a game ID form one game
+		byGame := make(map[string][]grid.KillEvent)
+		for _, kill := range eventData.Kills {
+			if kill.KillerTeamID == teamID || kill.VictimTeamID == teamID {
+				byGame[kill.GameID] = append(byGame[kill.GameID], kill)
+			}
+		}
+		objectives := collectLoLObjectives(eventData)
+
+		for _, gameID := range sortedKeys(byGame) {
+			kills := byGame[gameID]
+			sort.SliceStable(kills, func(i, j int) bool { return kills[i].GameTime < kills[j].GameTime })
+			analysis.Games++
+
+			var cluster *fightCluster
+			flush := func() {
+				if cluster != nil {
+					if fight := t.buildTeamfight(seriesID, gameID, teamID, cluster, objectives); fight != nil {
+						analysis.Teamfights = append(analysis.Teamfights, *fight)
+					}
+				}
+			}
+			for _, kill := range kills {
+				if cluster == nil || !cluster.joins(kill) {
+					flush()
+					cluster = &fightCluster{}
+				}
+				cluster.add(kill)
+			}
+			flush()
+		}
+	}
+
+	totalKills := 0
+	for _, fight := range analysis.Teamfights {
+		totalKills += fight.OurKills + fight.TheirKills
+		analysis.Overall.add(fight)
+		fightRecord(analysis.ByPhase, fight.Phase).add(fight)
+		fightRecord(analysis.ByArea, fight.Area).add(fight)
+		if objective := fightObjective(fight); objective != "" {
+			fightRecord(analysis.ByObjective, objective).add(fight)
+		}
+		if analysis.AreaPhase[fight.Area] == nil {
+			analysis.AreaPhase[fight.Area] = make(map[string]*FightRecord)
+		}
+		fightRecord(analysis.AreaPhase[fight.Area], fight.Phase).add(fight)
+	}
+	analysis.Fights = len(analysis.Teamfights)
+	if analysis.Fights > 0 {
+		analysis.AvgKills = float64(totalKills) / float64(analysis.Fights)
+	}
+
+	return analysis
+}
+
+// buildTeamfight turns a kill cluster into a teamfight, or nil when too few
+// kills or players were involved
+func (t *TeamfightAnalyzer) buildTeamfight(seriesID, gameID, teamID string, cluster *fightCluster, objectives []lolObjective) *Teamfight {
+	if len(cluster.kills) < minFightKills {
+		return nil
+	}
+
+	ours, theirs := make(map[string]bool), make(map[string]bool)
+	fight := &Teamfight{
+		SeriesID: seriesID,
+		GameID:   gameID,
+		Start:    cluster.kills[0].GameTime / 1000,
+		End:      cluster.kills[len(cluster.kills)-1].GameTime / 1000,
+		Area:     "unknown",
+	}
+	for _, kill := range cluster.kills {
+		killers, victims := theirs, ours
+		if kill.KillerTeamID == teamID {
+			fight.OurKills++
+			killers, victims = ours, theirs
+		} else {
+			fight.TheirKills++
+		}
+		killers[kill.KillerID] = true
+		for _, id := range kill.AssistIDs {
+			killers[id] = true
+		}
+		victims[kill.VictimID] = true
+	}
+	fight.OurParticipants, fight.TheirParticipants = len(ours), len(theirs)
+	if fight.OurParticipants < minFightSide || fight.TheirParticipants < minFightSide {
+		return nil
+	}
+
+	fight.Phase = ClassifyGamePhase(fight.Start)
+	if centre := cluster.centre(); centre != nil {
+		fight.X, fight.Y = centre.X, centre.Y
+		region := t.laneDetector.ClassifyPosition(centre)
+		fight.Area, fight.SubRegion = region.Lane, region.SubRegion
+	}
+
+	switch {
+	case fight.OurKills > fight.TheirKills:
+		fight.Result = "won"
+	case fight.OurKills < fight.TheirKills:
+		fight.Result = "lost"
+	default:
+		fight.Result = "even"
+	}
+
+	// The objective taken closest to the fight, from shortly before it to shortly after
+	start, end := cluster.kills[0].GameTime, cluster.kills[len(cluster.kills)-1].GameTime
+	best := -1
+	for _, objective := range objectives {
+		if objective.gameID != gameID || objective.gameTime < start-objectiveLeadMs || objective.gameTime > end+objectiveFollowUpMs {
+			continue
+		}
+		gap := 0
+		if objective.gameTime < start {
+			gap = start - objective.gameTime
+		} else if objective.gameTime > end {
+			gap = objective.gameTime - end
+		}
+		if best < 0 || gap < best {
+			best = gap
+			fight.Objective = objective.name
+			fight.ObjectiveTakenBy = "them"
+			if objective.teamID == teamID {
+				fight.ObjectiveTakenBy = "us"
+			}
+		}
+	}
+
+	return fight
+}
+
+// collectLoLObjectives lists the neutral objectives taken in a series
+func collectLoLObjectives(eventData *grid.LoLEventData) []lolObjective {
+	var objectives []lolObjective
+	for _, dragon := range eventData.DragonKills {
+		name := "dragon"
+		if strings.EqualFold(dragon.DragonType, "elder") {
+			name = "elder"
+		}
+		objectives = append(objectives, lolObjective{dragon.GameID, name, dragon.TeamID, dragon.GameTime, dragon.Position})
+	}
+	for _, list := range [][]grid.ObjectiveKillEvent{eventData.BaronKills, eventData.HeraldKills, eventData.VoidGrubKills} {
+		for _, objective := range list {
+			objectives = append(objectives, lolObjective{objective.GameID, objective.ObjectiveType, objective.TeamID, objective.GameTime, objective.Position})
+		}
+	}
+	return objectives
+}
+
+// fightObjective is the objective a fight was fought over: the one taken around
+// it, otherwise the pit it was fought in
+func fightObjective(fight Teamfight) string {
+	switch {
+	case fight.Objective == "elder":
+		return "dragon"
+	case fight.Objective != "":
+		return fight.Objective
+	case fight.SubRegion == "dragon_pit":
+		return "dragon"
+	case fight.SubRegion == "baron_pit":
+		return "baron"
+	}
+	return ""
+}
+
+func fightRecord(records map[string]*FightRecord, key string) *FightRecord {
+	if records[key] == nil {
+		records[key] = &FightRecord{}
+	}
+	return records[key]
+}
+
+func (r *FightRecord) add(fight Teamfight) {
+	r.Fights++
+	switch fight.Result {
+	case "won":
+		r.Won++
+	case "lost":
+		r.Lost++
+	}
+	r.KillDiff += fight.OurKills - fight.TheirKills
+	r.WinRate = float64(r.Won) / float64(r.Fights)
+}
+
+// phaseTiming describes a game phase for strategy timings
+var phaseTiming = map[string]string{
+	"early": "Before 15 minutes",
+	"mid":   "15-25 minutes",
+	"late":  "After 25 minutes",
+}
+
+// GenerateTeamfightStrategies turns fight records into in-game strategies
+// Example: "Avoid 5v5s in the river before 15 minutes - they win 78% of those fights"
+func GenerateTeamfightStrategies(analysis *TeamfightAnalysis) []InGameStrategyInsight {
+	strategies := make([]InGameStrategyInsight, 0)
+	if analysis == nil || analysis.Fights == 0 {
+		return strategies
+	}
+
+	// Where and when they are strongest and weakest
+	for _, area := range sortedKeys(analysis.AreaPhase) {
+		if area == "unknown" || area == "base" {
+			continue
+		}
+		for _, phase := range []string{"early", "mid", "late"} {
+			record := analysis.AreaPhase[area][phase]
+			if record == nil || record.Fights < 4 {
+				continue
+			}
+			switch {
+			case record.WinRate >= 0.65:
+				strategies = append(strategies, InGameStrategyInsight{
+					Strategy: fmt.Sprintf("Avoid 5v5s in the %s", area),
+					Timing:   phaseTiming[phase],
+					Reason: fmt.Sprintf("They win %.0f%% of teamfights in the %s in this phase (%d fights, %+d kills traded)",
+						record.WinRate*100, area, record.Fights, record.KillDiff),
+					Impact: "HIGH",
+				})
+			case record.WinRate <= 0.35:
+				strategies = append(strategies, InGameStrategyInsight{
+					Strategy: fmt.Sprintf("Force teamfights in the %s", area),
+					Timing:   phaseTiming[phase],
+					Reason: fmt.Sprintf("They win only %.0f%% of teamfights in the %s in this phase (%d fights)",
+						record.WinRate*100, area, record.Fights),
+					Impact: "MEDIUM",
+				})
+			}
+		}
+	}
+
+	// Objective fights
+	for _, objective := range []string{"dragon", "baron"} {
+		record := analysis.ByObjective[objective]
+		if record == nil || record.Fights < 4 {
+			continue
+		}
+		switch {
+		case record.WinRate >= 0.65:
+			strategies = append(strategies, InGameStrategyInsight{
+				Strategy: fmt.Sprintf("Trade cross-map instead of contesting %s", objective),
+				Timing:   "Objective spawns",
+				Reason:   fmt.Sprintf("They win %.0f%% of fights around %s (%d fights)", record.WinRate*100, objective, record.Fights),
+				Impact:   "HIGH",
+			})
+		case record.WinRate <= 0.35:
+			strategies = append(strategies, InGameStrategyInsight{
+				Strategy: fmt.Sprintf("Contest every %s with your full team", objective),
+				Timing:   "Objective spawns",
+				Reason:   fmt.Sprintf("They lose %.0f%% of fights around %s (%d fights)", (1-record.WinRate)*100, objective, record.Fights),
+				Impact:   "HIGH",
+			})
+		}
+	}
+
+	return strategies
+}
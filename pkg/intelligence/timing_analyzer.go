@@ -111,7 +111,11 @@ func (a *TimingAnalyzerEngine) AnalyzeJunglePathing(
 	}
 	totalFirstBloods := 0
 
-	for seriesID, eventData := range events {
+	// First clears, level 1 invades and first ganks from early positions
+	clears := newFirstClearTracker()
+
+	for _, seriesID := range sortedKeys(events) {
+		eventData := events[seriesID]
 		if eventData == nil {
 			continue
 		}
@@ -150,11 +154,22 @@ func (a *TimingAnalyzerEngine) AnalyzeJunglePathing(
 				}
 			}
 
-			if ourTeam == nil || junglerID == "" {
+			if ourTeam == nil {
+				continue
+			}
+
+			// Where players stand in the first clear names the jungler more
+			// reliably than their champion
+			positions := gamePositions(eventData, game.ID, teamID)
+			if id := junglerFromPositions(positions); id != "" {
+				junglerID = id
+			}
+			if junglerID == "" {
 				continue
 			}
 
 			totalGames++
+			a.trackFirstClear(clears, &game, ourTeam, junglerID, positions, eventData)
 
 			// Analyze kills for gank patterns using POSITION DATA
 			for _, kill := range eventData.Kills {
@@ -284,11 +299,8 @@ func (a *TimingAnalyzerEngine) AnalyzeJunglePathing(
 				analysis.GanksByLane[lane] = float64(count) / float64(totalGanks)
 			}
 		}
-		
-		// Counter-jungle rate estimation from enemy jungle kills
-		// (This would need more detailed event parsing for accuracy)
-		analysis.CounterJungleRate = 0.0 // TODO: Implement with more event data
 	}
+	clears.fill(analysis)
 
 	return analysis
 }
@@ -325,7 +337,8 @@ func (a *TimingAnalyzerEngine) AnalyzeObjectiveTimings(
 		voidGrubGames       int
 	)
 
-	for seriesID, eventData := range events {
+	for _, seriesID := range sortedKeys(events) {
+		eventData := events[seriesID]
 		if eventData == nil {
 			continue
 		}
@@ -478,7 +491,8 @@ func (a *TimingAnalyzerEngine) AnalyzeObjectiveTimings(
 	// Determine most common first tower lane
 	maxLane := "bot"
 	maxCount := 0
-	for lane, count := range towerLaneCounts {
+	for _, lane := range sortedKeys(towerLaneCounts) {
+		count := towerLaneCounts[lane]
 		if count > maxCount {
 			maxCount = count
 			maxLane = lane
@@ -489,7 +503,8 @@ func (a *TimingAnalyzerEngine) AnalyzeObjectiveTimings(
 	// Determine herald usage pattern from actual data
 	maxHeraldLane := "mid"
 	maxHeraldCount := 0
-	for lane, count := range heraldLaneCounts {
+	for _, lane := range sortedKeys(heraldLaneCounts) {
+		count := heraldLaneCounts[lane]
 		if count > maxHeraldCount {
 			maxHeraldCount = count
 			maxHeraldLane = lane
@@ -527,7 +542,8 @@ func (a *TimingAnalyzerEngine) AnalyzeDragonPriority(
 	// Convert to percentages
 	priority := make(map[string]float64)
 	if totalDragons > 0 {
-		for dragonType, count := range dragonCounts {
+		for _, dragonType := range sortedKeys(dragonCounts) {
+			count := dragonCounts[dragonType]
 			priority[dragonType] = float64(count) / float64(totalDragons)
 		}
 	}
@@ -552,7 +568,8 @@ func (a *TimingAnalyzerEngine) AnalyzeFirstBloodPatterns(
 		locationCounts  = map[string]int{"top": 0, "mid": 0, "bot": 0, "jungle": 0}
 	)
 
-	for seriesID, eventData := range events {
+	for _, seriesID := range sortedKeys(events) {
+		eventData := events[seriesID]
 		if eventData == nil {
 			continue
 		}
@@ -632,7 +649,8 @@ func (a *TimingAnalyzerEngine) AnalyzeFirstBloodPatterns(
 		totalLocations += count
 	}
 	if totalLocations > 0 {
-		for loc, count := range locationCounts {
+		for _, loc := range sortedKeys(locationCounts) {
+			count := locationCounts[loc]
 			analysis.LocationBreakdown[loc] = float64(count) / float64(totalLocations)
 		}
 	}
@@ -680,7 +698,8 @@ func (a *TimingAnalyzerEngine) GenerateTimingInsights(
 		// Find most ganked lane
 		maxLane := ""
 		maxRate := 0.0
-		for lane, rate := range junglePathing.GanksByLane {
+		for _, lane := range sortedKeys(junglePathing.GanksByLane) {
+			rate := junglePathing.GanksByLane[lane]
 			if rate > maxRate {
 				maxRate = rate
 				maxLane = lane
@@ -697,6 +716,48 @@ func (a *TimingAnalyzerEngine) GenerateTimingInsights(
 		}
 	}
 
+	// First clear insights
+	// "Most common first clear: red-krugs-raptors-wolves-blue-gromp, ending top side at ~3:15"
+	if len(junglePathing.FirstClearPatterns) > 0 {
+		clear := junglePathing.FirstClearPatterns[0]
+		insights = append(insights, StrategyInsight{
+			Text: fmt.Sprintf("Most common first clear: %s, ending %s at ~%s (%.0f%% of %d games)",
+				clear.Path, clear.EndLocation, formatGameClock(clear.EndTime), clear.Frequency*100, junglePathing.FirstClearGames),
+			Metric:  "first_clear",
+			Value:   clear.Frequency,
+			Context: clear.EndLocation,
+		})
+	}
+	if junglePathing.FirstClearGames >= minClearGames && junglePathing.Level1InvadeRate >= 0.3 {
+		insights = append(insights, StrategyInsight{
+			Text:    fmt.Sprintf("Invades level 1 in %.0f%% of games", junglePathing.Level1InvadeRate*100),
+			Metric:  "level1_invade",
+			Value:   junglePathing.Level1InvadeRate,
+			Context: "level 1",
+		})
+	}
+	if junglePathing.FirstClearGames >= minClearGames && junglePathing.CounterJungleRate >= 0.3 {
+		insights = append(insights, StrategyInsight{
+			Text:    fmt.Sprintf("Their jungler enters the enemy jungle in %.0f%% of first clears", junglePathing.CounterJungleRate*100),
+			Metric:  "counter_jungle",
+			Value:   junglePathing.CounterJungleRate,
+			Context: "first clear",
+		})
+	}
+
+	// First gank insight
+	// "First gank hits bot lane 60% of the time, on average at 3:40"
+	if len(junglePathing.FirstGanks) > 0 {
+		gank := junglePathing.FirstGanks[0]
+		insights = append(insights, StrategyInsight{
+			Text: fmt.Sprintf("First gank hits %s lane %.0f%% of the time, on average at %s (earliest %s)",
+				gank.Lane, gank.Rate*100, formatGameClock(gank.AvgTime), formatGameClock(gank.Earliest)),
+			Metric:  "first_gank",
+			Value:   gank.Rate,
+			Context: gank.Lane,
+		})
+	}
+
 	// First tower timing insight - HACKATHON FORMAT
 	// "4-man group for first tower push, usually in bot lane at ~13 mins"
 	if objectiveTimings.FirstTowerAvgTime > 0 {
@@ -802,7 +863,8 @@ func (a *TimingAnalyzerEngine) GenerateTimingCounterStrategies(
 	if len(junglePathing.GanksByLane) > 0 {
 		maxLane := ""
 		maxRate := 0.0
-		for lane, rate := range junglePathing.GanksByLane {
+		for _, lane := range sortedKeys(junglePathing.GanksByLane) {
+			rate := junglePathing.GanksByLane[lane]
 			if rate > maxRate {
 				maxRate = rate
 				maxLane = lane
@@ -818,6 +880,50 @@ func (a *TimingAnalyzerEngine) GenerateTimingCounterStrategies(
 		}
 	}
 
+	// First clear strategies
+	if junglePathing.FirstClearGames >= minClearGames {
+		if len(junglePathing.FirstClearPatterns) > 0 && junglePathing.FirstClearPatterns[0].Frequency >= 0.5 {
+			clear := junglePathing.FirstClearPatterns[0]
+			strategies = append(strategies, InGameStrategyInsight{
+				Strategy: fmt.Sprintf("Expect their jungler %s at ~%s - play safe there and take priority on the other side",
+					clear.EndLocation, formatGameClock(clear.EndTime)),
+				Timing: fmt.Sprintf("~%s", formatGameClock(clear.EndTime)),
+				Reason: fmt.Sprintf("Their jungler clears %s in %.0f%% of games",
+					clear.Path, clear.Frequency*100),
+				Impact: "MEDIUM",
+			})
+		}
+		if junglePathing.Level1InvadeRate >= 0.4 {
+			strategies = append(strategies, InGameStrategyInsight{
+				Strategy: "Ward your jungle entrances and stay grouped before camps spawn",
+				Timing:   "Level 1",
+				Reason:   fmt.Sprintf("They invade level 1 in %.0f%% of games", junglePathing.Level1InvadeRate*100),
+				Impact:   "HIGH",
+			})
+		}
+		if junglePathing.CounterJungleRate >= 0.4 {
+			strategies = append(strategies, InGameStrategyInsight{
+				Strategy: "Ward your own camps and keep lane priority to punish their counter-jungling",
+				Timing:   "1:30-3:30",
+				Reason: fmt.Sprintf("Their jungler enters the enemy jungle in %.0f%% of first clears",
+					junglePathing.CounterJungleRate*100),
+				Impact: "MEDIUM",
+			})
+		}
+	}
+
+	// First gank strategy
+	if len(junglePathing.FirstGanks) > 0 && junglePathing.FirstGanks[0].Rate >= 0.5 {
+		gank := junglePathing.FirstGanks[0]
+		strategies = append(strategies, InGameStrategyInsight{
+			Strategy: fmt.Sprintf("Ward river for %s lane before %s", gank.Lane, formatGameClock(gank.Earliest)),
+			Timing:   fmt.Sprintf("~%s", formatGameClock(gank.AvgTime)),
+			Reason: fmt.Sprintf("Their first gank hits %s lane %.0f%% of the time, on average at %s",
+				gank.Lane, gank.Rate*100, formatGameClock(gank.AvgTime)),
+			Impact: "HIGH",
+		})
+	}
+
 	// Dragon contest strategy
 	if objectiveTimings.FirstDragonContestRate < 0.5 {
 		strategies = append(strategies, InGameStrategyInsight{
@@ -940,7 +1046,8 @@ func (a *TimingAnalyzerEngine) AnalyzeObjectiveTimingsEnhanced(
 	var firstHeraldTimes []float64
 	var baronTimes []float64
 
-	for seriesID, eventData := range events {
+	for _, seriesID := range sortedKeys(events) {
+		eventData := events[seriesID]
 		if eventData == nil {
 			continue
 		}
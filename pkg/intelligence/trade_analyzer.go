@@ -0,0 +1,354 @@
+package intelligence
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"scout9/pkg/grid"
+)
+
+// TradeAnalyzer finds entry duels and traded deaths in VALORANT kill feeds
+type TradeAnalyzer struct {
+	TradeWindow time.Duration // how soon a death must be avenged to count as traded
+}
+
+// NewTradeAnalyzer creates a trade analyzer with the usual 5 second trade window
+func NewTradeAnalyzer() *TradeAnalyzer {
+	return &TradeAnalyzer{
+		TradeWindow: 5 * time.Second,
+	}
+}
+
+// tradeAggregator accumulates trade stats overall and split by map and side
+type tradeAggregator struct {
+	overall TradeStats
+	byMap   map[string]*TradeStats
+	bySide  map[string]*TradeStats
+}
+
+func newTradeAggregator() *tradeAggregator {
+	return &tradeAggregator{
+		byMap:  make(map[string]*TradeStats),
+		bySide: make(map[string]*TradeStats),
+	}
+}
+
+// update applies fn to the overall, map and side buckets of a round
+func (a *tradeAggregator) update(round valRound, fn func(*TradeStats)) {
+	fn(&a.overall)
+	if a.byMap[round.mapName] == nil {
+		a.byMap[round.mapName] = &TradeStats{}
+	}
+	fn(a.byMap[round.mapName])
+	if round.side != "" {
+		if a.bySide[round.side] == nil {
+			a.bySide[round.side] = &TradeStats{}
+		}
+		fn(a.bySide[round.side])
+	}
+}
+
+func (a *tradeAggregator) finish() {
+	a.overall.finish()
+	for _, stats := range a.byMap {
+		stats.finish()
+	}
+	for _, stats := range a.bySide {
+		stats.finish()
+	}
+}
+
+// finish fills in the rates from the raw counts
+func (s *TradeStats) finish() {
+	if s.EntryAttempts > 0 {
+		s.EntrySuccessRate = float64(s.EntryWins) / float64(s.EntryAttempts)
+	}
+	if s.Deaths > 0 {
+		s.TradedDeathRate = float64(s.TradedDeaths) / float64(s.Deaths)
+	}
+	if s.TradeOpportunities > 0 {
+		s.TradeEfficiency = float64(s.TradeKills) / float64(s.TradeOpportunities)
+	}
+}
+
+// AnalyzeTrades measures entry duels and trades for a team and each of its players.
+// A death is traded when a teammate kills the killer within the trade window.
+func (t *TradeAnalyzer) AnalyzeTrades(
+	teamID string,
+	teamName string,
+	seriesStates []*grid.SeriesState,
+	events map[string]*grid.VALEventData,
+) *TradeAnalysis {
+	team := newTradeAggregator()
+	players := make(map[string]*tradeAggregator)
+	names := make(map[string]string)
+
+	player := func(id, name string) *tradeAggregator {
+		if name != "" {
+			names[id] = name
+		}
+		if players[id] == nil {
+			players[id] = newTradeAggregator()
+		}
+		return players[id]
+	}
+
+	for _, round := range collectVALRounds(teamID, teamName, seriesStates, events) {
+		if len(round.kills) == 0 {
+			continue
+		}
+
+		team.update(round, func(s *TradeStats) { s.Rounds++ })
+		alive := make(map[string]bool)
+		for _, p := range round.players {
+			alive[p.ID] = true
+			player(p.ID, p.Name).update(round, func(s *TradeStats) { s.Rounds++ })
+		}
+
+		// The first kill of the round is the entry duel
+		entry := round.kills[0]
+		entryWon := entry.KillerTeamID == round.ourID
+		if entryWon || entry.VictimTeamID == round.ourID {
+			team.update(round, func(s *TradeStats) {
+				s.EntryAttempts++
+				if entryWon {
+					s.EntryWins++
+				}
+			})
+			if entryWon {
+				player(entry.KillerID, entry.KillerName).update(round, func(s *TradeStats) {
+					s.EntryAttempts++
+					s.EntryWins++
+				})
+			} else {
+				player(entry.VictimID, entry.VictimName).update(round, func(s *TradeStats) { s.EntryAttempts++ })
+			}
+		}
+
+		avenging := make(map[int]bool)
+		for i, kill := range round.kills {
+			if kill.VictimTeamID != round.ourID {
+				continue
+			}
+			alive[kill.VictimID] = false
+
+			// Teammates still alive could have traded this death
+			var witnesses []string
+			for _, p := range round.players {
+				if alive[p.ID] {
+					witnesses = append(witnesses, p.ID)
+				}
+			}
+
+			avenger := -1
+			for j := i + 1; j < len(round.kills); j++ {
+				if killGap(kill, round.kills[j]) > t.TradeWindow {
+					break
+				}
+				if round.kills[j].VictimID == kill.KillerID && round.kills[j].KillerTeamID == round.ourID {
+					avenger = j
+					break
+				}
+			}
+			traded := avenger >= 0
+
+			team.update(round, func(s *TradeStats) {
+				s.Deaths++
+				if len(witnesses) > 0 {
+					s.TradeOpportunities++
+				}
+				if traded {
+					s.TradedDeaths++
+				}
+			})
+			player(kill.VictimID, kill.VictimName).update(round, func(s *TradeStats) {
+				s.Deaths++
+				if traded {
+					s.TradedDeaths++
+				}
+			})
+			for _, id := range witnesses {
+				player(id, "").update(round, func(s *TradeStats) { s.TradeOpportunities++ })
+			}
+
+			// One kill can avenge several deaths but is only one trade
+			if traded && !avenging[avenger] {
+				avenging[avenger] = true
+				trade := round.kills[avenger]
+				team.update(round, func(s *TradeStats) { s.TradeKills++ })
+				player(trade.KillerID, trade.KillerName).update(round, func(s *TradeStats) { s.TradeKills++ })
+			}
+		}
+	}
+
+	team.finish()
+	analysis := &TradeAnalysis{
+		TradeWindowMs: int(t.TradeWindow.Milliseconds()),
+		Team:          team.overall,
+		ByMap:         team.byMap,
+		BySide:        team.bySide,
+		Players:       make([]*PlayerTradeStats, 0, len(players)),
+	}
+	for _, id := range sortedKeys(players) {
+		agg := players[id]
+		agg.finish()
+		analysis.Players = append(analysis.Players, &PlayerTradeStats{
+			PlayerID:   id,
+			PlayerName: names[id],
+			Overall:    agg.overall,
+			ByMap:      agg.byMap,
+			BySide:     agg.bySide,
+		})
+	}
+
+	// Entry players first
+	sort.SliceStable(analysis.Players, func(i, j int) bool {
+		return analysis.Players[i].Overall.EntryAttempts > analysis.Players[j].Overall.EntryAttempts
+	})
+
+	return analysis
+}
+
+// GenerateTradeInsights describes a player's entry duels and how often their deaths get traded
+// Example: "Player 'Jett' takes 41% of entry duels and wins 38% of them - weakest on Split (25%)"
+func GenerateTradeInsights(stats *PlayerTradeStats, role string, teamEntries int) []PlayerTendencyInsight {
+	insights := make([]PlayerTendencyInsight, 0)
+	if stats == nil {
+		return insights
+	}
+	overall := stats.Overall
+
+	if overall.EntryAttempts >= 5 {
+		share := 0.0
+		if teamEntries > 0 {
+			share = float64(overall.EntryAttempts) / float64(teamEntries)
+		}
+		text := fmt.Sprintf("Player '%s' takes %.0f%% of entry duels and wins %.0f%% of them",
+			stats.PlayerName, share*100, overall.EntrySuccessRate*100)
+		context := fmt.Sprintf("%d entry duels", overall.EntryAttempts)
+		if mapName, worst := weakestEntryMap(stats); worst != nil && worst.EntrySuccessRate < overall.EntrySuccessRate {
+			text += fmt.Sprintf(" - weakest on %s (%.0f%%)", mapName, worst.EntrySuccessRate*100)
+			context = fmt.Sprintf("%s; %s: %.0f%% of %d", context, mapName, worst.EntrySuccessRate*100, worst.EntryAttempts)
+		}
+		insights = append(insights, PlayerTendencyInsight{
+			Text:         text,
+			PlayerName:   stats.PlayerName,
+			Role:         role,
+			TendencyType: "first_duel",
+			Value:        overall.EntrySuccessRate,
+			Context:      context,
+			SampleSize:   overall.EntryAttempts,
+		})
+	}
+
+	if overall.Deaths >= 10 {
+		tendency := ""
+		if overall.TradedDeathRate < 0.2 {
+			tendency = "plays isolated - rarely traded"
+		} else if overall.TradedDeathRate > 0.45 {
+			tendency = "plays close to the team - usually traded"
+		}
+		if tendency != "" {
+			insights = append(insights, PlayerTendencyInsight{
+				Text: fmt.Sprintf("Player '%s' is traded on %.0f%% of deaths (%s)",
+					stats.PlayerName, overall.TradedDeathRate*100, tendency),
+				PlayerName:   stats.PlayerName,
+				Role:         role,
+				TendencyType: "traded_death",
+				Value:        overall.TradedDeathRate,
+				Context:      fmt.Sprintf("%d of %d deaths traded", overall.TradedDeaths, overall.Deaths),
+				SampleSize:   overall.Deaths,
+			})
+		}
+	}
+
+	if overall.TradeOpportunities >= 10 {
+		insights = append(insights, PlayerTendencyInsight{
+			Text: fmt.Sprintf("Player '%s' trades %.0f%% of teammate deaths while alive",
+				stats.PlayerName, overall.TradeEfficiency*100),
+			PlayerName:   stats.PlayerName,
+			Role:         role,
+			TendencyType: "trade_efficiency",
+			Value:        overall.TradeEfficiency,
+			Context:      fmt.Sprintf("%d trades from %d chances", overall.TradeKills, overall.TradeOpportunities),
+			SampleSize:   overall.TradeOpportunities,
+		})
+	}
+
+	return insights
+}
+
+// weakestEntryMap returns the map with the lowest entry success among maps with enough duels
+func weakestEntryMap(stats *PlayerTradeStats) (string, *TradeStats) {
+	var worstName string
+	var worst *TradeStats
+	for _, mapName := range sortedKeys(stats.ByMap) {
+		s := stats.ByMap[mapName]
+		if s.EntryAttempts < 3 {
+			continue
+		}
+		if worst == nil || s.EntrySuccessRate < worst.EntrySuccessRate {
+			worstName, worst = mapName, s
+		}
+	}
+	return worstName, worst
+}
+
+// GenerateTradeTargets picks players to play against: entry players who lose
+// their opening duels and players whose deaths go untraded
+func GenerateTradeTargets(analysis *TradeAnalysis, profiles []*PlayerProfile) []PlayerTarget {
+	targets := make([]PlayerTarget, 0)
+	if analysis == nil {
+		return targets
+	}
+
+	roles := make(map[string]string)
+	for _, p := range profiles {
+		roles[p.PlayerID] = p.Role
+	}
+
+	for _, stats := range analysis.Players {
+		overall := stats.Overall
+		if overall.EntryAttempts >= 5 && overall.EntrySuccessRate < 0.45 {
+			reason := fmt.Sprintf("Loses %.0f%% of entry duels (n=%d) - hold tight angles against their first contact",
+				(1-overall.EntrySuccessRate)*100, overall.EntryAttempts)
+			if side := weakestEntrySide(stats); side != "" {
+				reason += fmt.Sprintf(", especially on %s", side)
+			}
+			targets = append(targets, PlayerTarget{
+				PlayerName: stats.PlayerName,
+				Role:       roles[stats.PlayerID],
+				Reason:     reason,
+				Priority:   2,
+			})
+			continue
+		}
+		if overall.Deaths >= 10 && overall.TradedDeathRate < 0.2 {
+			targets = append(targets, PlayerTarget{
+				PlayerName: stats.PlayerName,
+				Role:       roles[stats.PlayerID],
+				Reason: fmt.Sprintf("Only %.0f%% of deaths get traded (n=%d) - isolate and punish their solo positions",
+					overall.TradedDeathRate*100, overall.Deaths),
+				Priority: 3,
+			})
+		}
+	}
+
+	return targets
+}
+
+// weakestEntrySide names the side where the player loses most entry duels, if clearly worse
+func weakestEntrySide(stats *PlayerTradeStats) string {
+	attack, defense := stats.BySide["attack"], stats.BySide["defense"]
+	if attack == nil || defense == nil || attack.EntryAttempts < 3 || defense.EntryAttempts < 3 {
+		return ""
+	}
+	if attack.EntrySuccessRate+0.15 < defense.EntrySuccessRate {
+		return "attack"
+	}
+	if defense.EntrySuccessRate+0.15 < attack.EntrySuccessRate {
+		return "defense"
+	}
+	return ""
+}
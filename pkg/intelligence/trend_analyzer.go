@@ -2,26 +2,51 @@ package intelligence
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"sort"
+	"strings"
 	"time"
 
 	"scout9/pkg/grid"
 )
 
 // TrendAnalyzer analyzes performance trends over time
-type TrendAnalyzer struct{}
+type TrendAnalyzer struct {
+	stats         *StatisticalEngine
+	changePoints  *ChangePointDetector
+	eventAnalyzer *EventAnalyzer
+	patches       []PatchRelease
+}
 
 // NewTrendAnalyzer creates a new trend analyzer
 func NewTrendAnalyzer() *TrendAnalyzer {
-	return &TrendAnalyzer{}
+	return &TrendAnalyzer{
+		stats:         NewStatisticalEngine(),
+		changePoints:  NewChangePointDetector(),
+		eventAnalyzer: NewEventAnalyzer(),
+	}
+}
+
+// SetPatchCalendar sets the patch releases used to explain trend changes
+func (a *TrendAnalyzer) SetPatchCalendar(patches []PatchRelease) {
+	a.patches = patches
 }
 
-// AnalyzeTrends analyzes team performance trends
+// seriesWithTime pairs a series state with its start time
+type seriesWithTime struct {
+	state *grid.SeriesState
+	time  time.Time
+}
+
+// AnalyzeTrends analyzes team performance trends. LoL event data is optional and
+// only used for the first dragon trend.
 func (a *TrendAnalyzer) AnalyzeTrends(
 	ctx context.Context,
 	teamID string,
 	seriesStates []*grid.SeriesState,
 	seriesInfo []grid.Series,
+	lolEvents map[string]*grid.LoLEventData,
 ) (*TrendAnalysis, error) {
 	analysis := &TrendAnalysis{
 		TeamID:       teamID,
@@ -34,26 +59,27 @@ func (a *TrendAnalyzer) AnalyzeTrends(
 	}
 
 	// Sort series by time (need series info for timestamps)
-	type seriesWithTime struct {
-		state *grid.SeriesState
-		time  time.Time
-	}
-
 	seriesMap := make(map[string]time.Time)
+	infoByID := make(map[string]grid.Series, len(seriesInfo))
+	var latest time.Time
 	for _, s := range seriesInfo {
 		seriesMap[s.ID] = s.StartTime
+		infoByID[s.ID] = s
+		if s.StartTime.After(latest) {
+			latest = s.StartTime
+		}
 	}
 
 	sortedSeries := make([]seriesWithTime, 0, len(seriesStates))
 	for _, state := range seriesStates {
 		t := seriesMap[state.ID]
 		if t.IsZero() {
-			t = time.Now() // Fallback
+			t = latest // Fallback: treat as most recent without depending on the clock
 		}
 		sortedSeries = append(sortedSeries, seriesWithTime{state: state, time: t})
 	}
 
-	sort.Slice(sortedSeries, func(i, j int) bool {
+	sort.SliceStable(sortedSeries, func(i, j int) bool {
 		return sortedSeries[i].time.Before(sortedSeries[j].time)
 	})
 
@@ -64,6 +90,7 @@ func (a *TrendAnalyzer) AnalyzeTrends(
 		last5Games    []bool
 		last10Games   []bool
 		winRateValues []float64
+		outcomes      []float64 // 1 for a win, 0 for a loss, in game order
 	)
 
 	for _, sw := range sortedSeries {
@@ -84,6 +111,9 @@ func (a *TrendAnalyzer) AnalyzeTrends(
 			totalGames++
 			if won {
 				totalWins++
+				outcomes = append(outcomes, 1)
+			} else {
+				outcomes = append(outcomes, 0)
 			}
 
 			// Track recent games
@@ -130,92 +160,83 @@ func (a *TrendAnalyzer) AnalyzeTrends(
 	}
 
 	// Determine form indicator
-	analysis.FormIndicator, analysis.FormScore = calculateForm(analysis.Last5WinRate, analysis.OverallWinRate)
+	analysis.FormIndicator, analysis.FormScore, analysis.FormPValue = a.calculateForm(outcomes)
 
 	// Add win rate trend
 	if len(winRateValues) > 0 {
+		direction, pValue := a.calculateTrendDirection(outcomes)
 		analysis.MetricTrends = append(analysis.MetricTrends, MetricTrend{
 			Metric:    "Win Rate",
-			Direction: calculateTrendDirection(winRateValues),
+			Direction: direction,
 			Values:    winRateValues,
+			PValue:    pValue,
 		})
 	}
 
-	// Detect significant trend changes
-	if len(winRateValues) >= 5 {
-		// Compare first half to second half
-		mid := len(winRateValues) / 2
-		firstHalfAvg := averageFloat64(winRateValues[:mid])
-		secondHalfAvg := averageFloat64(winRateValues[mid:])
-
-		diff := secondHalfAvg - firstHalfAvg
-		if diff > 0.15 {
-			analysis.TrendChanges = append(analysis.TrendChanges, TrendChange{
-				Metric:      "Win Rate",
-				OldValue:    firstHalfAvg,
-				NewValue:    secondHalfAvg,
-				Explanation: "Significant improvement in recent matches",
-			})
-		} else if diff < -0.15 {
-			analysis.TrendChanges = append(analysis.TrendChanges, TrendChange{
-				Metric:      "Win Rate",
-				OldValue:    firstHalfAvg,
-				NewValue:    secondHalfAvg,
-				Explanation: "Performance decline in recent matches",
-			})
-		}
-	}
+	// Detect change points in per-game metrics and explain them
+	title := trendTitle(sortedSeries, infoByID)
+	metrics := a.collectTrendMetrics(teamID, title, sortedSeries, lolEvents)
+	analysis.TrendChanges = append(analysis.TrendChanges, a.detectTrendChanges(title, metrics, infoByID)...)
 
 	return analysis, nil
 }
 
-func calculateForm(recentRate, overallRate float64) (string, float64) {
-	diff := recentRate - overallRate
-
-	// Form score: -100 to 100
-	formScore := diff * 200 // Scale to -100 to 100 range
-
-	if formScore > 100 {
-		formScore = 100
+// formWindow is the number of recent games compared with the team's earlier games
+const formWindow = 10
+
+// calculateForm compares the last formWindow games (the later half of a shorter
+// history) with the games before them using a one-sided exact binomial test. The
+// team is only "hot" or "cold" when the difference is significant; the score
+// still reflects the size of the difference from the same baseline.
+func (a *TrendAnalyzer) calculateForm(outcomes []float64) (string, float64, float64) {
+	window := formWindow
+	if len(outcomes) < 2*formWindow {
+		window = (len(outcomes) + 1) / 2
 	}
-	if formScore < -100 {
-		formScore = -100
+	recent, earlier := outcomes[len(outcomes)-window:], outcomes[:len(outcomes)-window]
+	if len(earlier) == 0 {
+		return "stable", 0, 1
 	}
 
-	// Form indicator
-	if recentRate >= 0.7 || diff > 0.15 {
-		return "hot", formScore
-	} else if recentRate <= 0.3 || diff < -0.15 {
-		return "cold", formScore
+	// Laplace-smoothed baseline so an unbeaten or winless history isn't a certainty
+	baseline := (float64(countWins(earlier)) + 1) / (float64(len(earlier)) + 2)
+	recentRate := averageFloat64(recent)
+
+	// Form score: -100 to 100
+	formScore := math.Max(-100, math.Min(100, (recentRate-baseline)*200))
+
+	pValue := a.stats.BinomialTailTest(countWins(recent), len(recent), baseline)
+	if pValue < SignificanceLevel {
+		if recentRate > baseline {
+			return "hot", formScore, pValue
+		}
+		return "cold", formScore, pValue
 	}
-	return "stable", formScore
+	return "stable", formScore, pValue
 }
 
-func calculateTrendDirection(values []float64) string {
-	if len(values) < 3 {
-		return "stable"
+// calculateTrendDirection runs a permutation test on the slope of per-game
+// results and only reports a direction when it is significant
+func (a *TrendAnalyzer) calculateTrendDirection(outcomes []float64) (string, float64) {
+	slope, pValue := a.stats.SlopePermutationTest(outcomes)
+	if pValue >= SignificanceLevel {
+		return "stable", pValue
 	}
-
-	// Simple linear regression slope
-	n := float64(len(values))
-	var sumX, sumY, sumXY, sumX2 float64
-
-	for i, v := range values {
-		x := float64(i)
-		sumX += x
-		sumY += v
-		sumXY += x * v
-		sumX2 += x * x
+	if slope > 0 {
+		return "improving", pValue
 	}
+	return "declining", pValue
+}
 
-	slope := (n*sumXY - sumX*sumY) / (n*sumX2 - sumX*sumX)
-
-	if slope > 0.01 {
-		return "improving"
-	} else if slope < -0.01 {
-		return "declining"
+// countWins counts the wins in a 1/0 outcome series
+func countWins(outcomes []float64) int {
+	wins := 0
+	for _, o := range outcomes {
+		if o > 0 {
+			wins++
+		}
 	}
-	return "stable"
+	return wins
 }
 
 func averageFloat64(values []float64) float64 {
@@ -228,3 +249,327 @@ func averageFloat64(values []float64) float64 {
 	}
 	return sum / float64(len(values))
 }
+
+// trendPoint is one observation of a metric, with the context needed to explain a change
+type trendPoint struct {
+	value    float64
+	time     time.Time
+	seriesID string
+	roster   []grid.GamePlayer // our players in that game
+}
+
+// trendMetric is a chronological metric series checked for change points
+type trendMetric struct {
+	name   string
+	unit   string // "%" for rates
+	points []trendPoint
+}
+
+// trendTitle returns "valorant" or "lol" for the analyzed series
+func trendTitle(series []seriesWithTime, infoByID map[string]grid.Series) string {
+	for _, sw := range series {
+		if info, ok := infoByID[sw.state.ID]; ok && info.TitleID != "" {
+			if info.TitleID == "6" {
+				return "valorant"
+			}
+			return "lol"
+		}
+		for _, game := range sw.state.Games {
+			if game.Map != "" {
+				return "valorant"
+			}
+		}
+	}
+	return "lol"
+}
+
+// collectTrendMetrics builds per-game series for win rate, aggression, side win
+// rates and the first-objective metric of the title
+func (a *TrendAnalyzer) collectTrendMetrics(
+	teamID string,
+	title string,
+	series []seriesWithTime,
+	lolEvents map[string]*grid.LoLEventData,
+) []trendMetric {
+	winRate := trendMetric{name: "Win Rate", unit: "%"}
+	aggression := trendMetric{name: "Aggression", unit: " kills/min"}
+	sideA := trendMetric{name: "Blue Side Win Rate", unit: "%"}
+	sideB := trendMetric{name: "Red Side Win Rate", unit: "%"}
+	firstObjective := trendMetric{name: "First Dragon Rate", unit: "%"}
+	if title == "valorant" {
+		aggression.unit = " kills/round"
+		sideA.name = "Attack Round Win Rate"
+		sideB.name = "Defense Round Win Rate"
+		firstObjective.name = "Pistol Round Win Rate"
+	}
+
+	for _, sw := range series {
+		var lastRoster []grid.GamePlayer
+		for _, game := range sw.state.Games {
+			if !game.Finished {
+				continue
+			}
+			var ourTeam *grid.GameTeam
+			for i := range game.Teams {
+				if game.Teams[i].ID == teamID {
+					ourTeam = &game.Teams[i]
+				}
+			}
+			if ourTeam == nil {
+				continue
+			}
+
+			point := trendPoint{time: sw.time, seriesID: sw.state.ID, roster: ourTeam.Players}
+			lastRoster = ourTeam.Players
+			at := func(value float64) trendPoint {
+				p := point
+				p.value = value
+				return p
+			}
+
+			won := 0.0
+			if ourTeam.Won {
+				won = 1
+			}
+			winRate.points = append(winRate.points, at(won))
+
+			if title != "valorant" {
+				if game.Duration > 0 {
+					aggression.points = append(aggression.points, at(float64(ourTeam.Kills)/(float64(game.Duration)/60)))
+				}
+				switch ourTeam.Side {
+				case "blue":
+					sideA.points = append(sideA.points, at(won))
+				case "red":
+					sideB.points = append(sideB.points, at(won))
+				}
+				continue
+			}
+
+			// VALORANT: per-round stats from segments
+			var rounds, kills, attackWon, attackPlayed, defenseWon, defensePlayed, pistolWon, pistolPlayed int
+			for _, seg := range game.Segments {
+				if seg.Type != "round" || !seg.Finished {
+					continue
+				}
+				for _, st := range seg.Teams {
+					if st.ID != teamID {
+						continue
+					}
+					rounds++
+					kills += st.Kills
+					won := 0
+					if st.Won {
+						won = 1
+					}
+					switch st.Side {
+					case "attack", "attacker":
+						attackPlayed++
+						attackWon += won
+					case "defense", "defender":
+						defensePlayed++
+						defenseWon += won
+					}
+					if seg.SequenceNumber == 1 || seg.SequenceNumber == 13 {
+						pistolPlayed++
+						pistolWon += won
+					}
+				}
+			}
+			if rounds > 0 {
+				aggression.points = append(aggression.points, at(float64(kills)/float64(rounds)))
+			}
+			if attackPlayed > 0 {
+				sideA.points = append(sideA.points, at(float64(attackWon)/float64(attackPlayed)))
+			}
+			if defensePlayed > 0 {
+				sideB.points = append(sideB.points, at(float64(defenseWon)/float64(defensePlayed)))
+			}
+			if pistolPlayed > 0 {
+				firstObjective.points = append(firstObjective.points, at(float64(pistolWon)/float64(pistolPlayed)))
+			}
+		}
+
+		// LoL first dragon comes from the series event file
+		if title != "valorant" && lastRoster != nil {
+			if events := lolEvents[sw.state.ID]; events != nil && len(events.DragonKills) > 0 {
+				took := 0.0
+				if a.eventAnalyzer.AnalyzeFirstDragon(events, teamID) != nil {
+					took = 1
+				}
+				firstObjective.points = append(firstObjective.points, trendPoint{
+					value: took, time: sw.time, seriesID: sw.state.ID, roster: lastRoster,
+				})
+			}
+		}
+	}
+
+	return []trendMetric{winRate, aggression, firstObjective, sideA, sideB}
+}
+
+// detectTrendChanges runs change point detection on each metric and keeps the
+// shifts whose before/after difference is significant
+func (a *TrendAnalyzer) detectTrendChanges(title string, metrics []trendMetric, infoByID map[string]grid.Series) []TrendChange {
+	changes := make([]TrendChange, 0)
+
+	for _, m := range metrics {
+		values := make([]float64, len(m.points))
+		for i, p := range m.points {
+			values[i] = p.value
+		}
+
+		points := a.changePoints.Detect(values)
+		bounds := append(append([]int{0}, points...), len(values))
+		for k, cp := range points {
+			before, after := values[bounds[k]:cp], values[cp:bounds[k+2]]
+			pValue := a.stats.MeanDiffPermutationTest(before, after)
+			if pValue >= SignificanceLevel {
+				continue
+			}
+
+			oldValue, newValue := averageFloat64(before), averageFloat64(after)
+			cause, reason := a.explainChange(title, m.points, cp, bounds[k+2], infoByID)
+			changes = append(changes, TrendChange{
+				Metric:     m.name,
+				OldValue:   oldValue,
+				NewValue:   newValue,
+				ChangeDate: m.points[cp].time,
+				Explanation: fmt.Sprintf("%s %s from %s to %s %s (p=%.3f)",
+					m.name, changeVerb(oldValue, newValue),
+					formatTrendValue(oldValue, m.unit), formatTrendValue(newValue, m.unit), reason, pValue),
+				PValue: pValue,
+				Cause:  cause,
+			})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].ChangeDate.Before(changes[j].ChangeDate)
+	})
+
+	return changes
+}
+
+// explainChange looks for a roster change, patch or tournament boundary between
+// the point before the change and the first point after it. segmentEnd bounds
+// the new segment, used to ignore one-game stand-ins.
+func (a *TrendAnalyzer) explainChange(
+	title string,
+	points []trendPoint,
+	cp, segmentEnd int,
+	infoByID map[string]grid.Series,
+) (string, string) {
+	prev, cur := points[cp-1], points[cp]
+	var cause string
+	var reasons []string
+
+	// Roster: players new at the change who stayed for most of the new segment
+	previous := make(map[string]bool, len(prev.roster))
+	for _, p := range prev.roster {
+		previous[p.ID] = true
+	}
+	for _, p := range cur.roster {
+		if previous[p.ID] {
+			continue
+		}
+		var pool []CharacterStats
+		present := 0
+		for _, later := range points[cp:segmentEnd] {
+			for _, lp := range later.roster {
+				if lp.ID == p.ID {
+					present++
+					pool = append(pool, CharacterStats{Character: lp.Character, GamesPlayed: 1})
+				}
+			}
+		}
+		if present*2 < segmentEnd-cp {
+			continue
+		}
+		if cause == "" {
+			cause = "roster"
+		}
+		if role := rosterRoleLabel(title, pool); role != "" {
+			reasons = append(reasons, fmt.Sprintf("after the new %s %s joined", role, p.Name))
+		} else {
+			reasons = append(reasons, fmt.Sprintf("after %s joined", p.Name))
+		}
+	}
+
+	// Patch released between the two points
+	for _, patch := range a.patches {
+		if patch.Title != "" && patch.Title != title {
+			continue
+		}
+		if patch.Date.After(prev.time) && !patch.Date.After(cur.time) {
+			if cause == "" {
+				cause = "patch"
+			}
+			reasons = append(reasons, fmt.Sprintf("after patch %s", patch.Version))
+		}
+	}
+
+	// Tournament boundary
+	prevInfo, curInfo := infoByID[prev.seriesID], infoByID[cur.seriesID]
+	if curInfo.TournamentID != "" && prevInfo.TournamentID != "" && curInfo.TournamentID != prevInfo.TournamentID {
+		if cause == "" {
+			cause = "tournament"
+		}
+		name := curInfo.TournamentName
+		if name == "" {
+			name = "a new tournament"
+		}
+		reasons = append(reasons, fmt.Sprintf("at the start of %s", name))
+	}
+
+	if len(reasons) == 0 {
+		return "", "with no roster, patch or tournament change at that point"
+	}
+	return cause, strings.Join(reasons, ", ")
+}
+
+// rosterRoleLabel names the role a player filled from the characters they played
+func rosterRoleLabel(title string, pool []CharacterStats) string {
+	if title == "valorant" {
+		if role := determineVALRole(pool); role != "Flex" {
+			return strings.ToLower(role)
+		}
+		return ""
+	}
+	switch determineLoLRole(pool) {
+	case "Top":
+		return "top laner"
+	case "Jungle":
+		return "jungler"
+	case "Mid":
+		return "mid laner"
+	case "Bot":
+		return "bot laner"
+	case "Support":
+		return "support"
+	}
+	return ""
+}
+
+// changeVerb describes the direction and size of a change
+func changeVerb(oldValue, newValue float64) string {
+	diff := newValue - oldValue
+	sharp := math.Abs(diff) >= 0.3*math.Max(math.Abs(oldValue), 0.1)
+	switch {
+	case diff > 0 && sharp:
+		return "jumped"
+	case diff > 0:
+		return "rose"
+	case sharp:
+		return "dropped sharply"
+	default:
+		return "fell"
+	}
+}
+
+// formatTrendValue formats a metric value with its unit
+func formatTrendValue(value float64, unit string) string {
+	if unit == "%" {
+		return fmt.Sprintf("%.0f%%", value*100)
+	}
+	return fmt.Sprintf("%.2f%s", value, unit)
+}
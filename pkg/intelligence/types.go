@@ -20,6 +20,9 @@ type TeamAnalysis struct {
 	
 	// VALORANT-specific metrics
 	VALMetrics *VALTeamMetrics `json:"valMetrics,omitempty"`
+
+	// How the team plays from behind and with a lead
+	Comeback *ComebackTrait `json:"comeback,omitempty"`
 }
 
 // Insight represents a data-backed observation
@@ -40,7 +43,11 @@ type LoLTeamMetrics struct {
 	FirstTowerAvgTime   float64 `json:"firstTowerAvgTime"` // minutes
 	
 	// Mid game
+	GoldDiff10          float64 `json:"goldDiff10"`          // average gold diff at 10 min
 	GoldDiff15          float64 `json:"goldDiff15"`          // average gold diff at 15 min
+	GoldDiff20          float64 `json:"goldDiff20"`          // average gold diff at 20 min
+	LeadConversionRate  float64 `json:"leadConversionRate"`  // games ahead at 15 min that were won
+	ComebackRate        float64 `json:"comebackRate"`        // games behind at 15 min that were won
 	DragonControlRate   float64 `json:"dragonControlRate"`   // % of dragons taken
 	HeraldControlRate   float64 `json:"heraldControlRate"`   // % of heralds taken
 	
@@ -57,6 +64,265 @@ type LoLTeamMetrics struct {
 	
 	// Win conditions
 	WinConditions       []string `json:"winConditions"`
+
+	// Per-minute gold and kill difference curves
+	GoldTimeline        *GoldTimelineAnalysis `json:"goldTimeline,omitempty"`
+
+	// Kills clustered into teamfights
+	Teamfights          *TeamfightAnalysis `json:"teamfights,omitempty"`
+
+	// Setup, contest and trades around each neutral objective
+	ObjectiveSetups     *ObjectiveSetupAnalysis `json:"objectiveSetups,omitempty"`
+
+	// Dragon order, souls and elders per game
+	DragonRace          *DragonRaceAnalysis `json:"dragonRace,omitempty"`
+
+	// Blue and red side splits
+	Sides               *SideAnalysis `json:"sides,omitempty"`
+}
+
+// TimelinePoint is a team's gold and kill difference at one minute of a game
+type TimelinePoint struct {
+	Minute   int `json:"minute"`
+	GoldDiff int `json:"goldDiff"`
+	KillDiff int `json:"killDiff"`
+}
+
+// GameTimeline is one LoL game's differential curve from the team's side
+type GameTimeline struct {
+	SeriesID   string          `json:"seriesId"`
+	GameID     string          `json:"gameId"`
+	Side       string          `json:"side,omitempty"`
+	Won        bool            `json:"won"`
+	MaxLead    int             `json:"maxLead"`    // Largest gold lead
+	MaxDeficit int             `json:"maxDeficit"` // Largest gold deficit, as a positive number
+	Points     []TimelinePoint `json:"points"`
+}
+
+// TimelineAverage is the average differential at one minute across games
+type TimelineAverage struct {
+	Minute   int     `json:"minute"`
+	GoldDiff float64 `json:"goldDiff"`
+	KillDiff float64 `json:"killDiff"`
+	Games    int     `json:"games"` // Games that lasted to this minute
+}
+
+// GoldTimelineAnalysis aggregates a team's gold and kill difference curves
+type GoldTimelineAnalysis struct {
+	Games      int     `json:"games"` // Games with a timeline
+	GoldDiff10 float64 `json:"goldDiff10"`
+	GoldDiff15 float64 `json:"goldDiff15"`
+	GoldDiff20 float64 `json:"goldDiff20"`
+	KillDiff10 float64 `json:"killDiff10"`
+	KillDiff15 float64 `json:"killDiff15"`
+	KillDiff20 float64 `json:"killDiff20"`
+	Games10    int     `json:"games10"` // Games that reached each minute
+	Games15    int     `json:"games15"`
+	Games20    int     `json:"games20"`
+
+	// Games with a clear gold lead or deficit at 15 minutes, and the share won
+	LeadGames          int     `json:"leadGames"`
+	LeadConversionRate float64 `json:"leadConversionRate"`
+	DeficitGames       int     `json:"deficitGames"`
+	ComebackRate       float64 `json:"comebackRate"`
+
+	// 0-100 ratings from the gold swing in each phase, 50 is even
+	EarlyGameRating float64 `json:"earlyGameRating"` // Gold diff at 15
+	MidGameRating   float64 `json:"midGameRating"`   // Gold swing from 15 to 25
+	LateGameRating  float64 `json:"lateGameRating"`  // Gold swing from 25 to the end
+	MidGames        int     `json:"midGames"`
+	LateGames       int     `json:"lateGames"`
+
+	Curve     []TimelineAverage `json:"curve"`
+	Timelines []GameTimeline    `json:"timelines"`
+}
+
+// Teamfight is a cluster of kills close in time and space with both teams involved
+type Teamfight struct {
+	SeriesID          string  `json:"seriesId"`
+	GameID            string  `json:"gameId,omitempty"`
+	Start             int     `json:"start"` // Seconds from game start
+	End               int     `json:"end"`
+	Phase             string  `json:"phase"` // "early", "mid", "late"
+	Area              string  `json:"area"`  // Lane detector region, "unknown" without positions
+	SubRegion         string  `json:"subRegion,omitempty"`
+	X                 float64 `json:"x"` // Centre of the kill positions
+	Y                 float64 `json:"y"`
+	OurKills          int     `json:"ourKills"`
+	TheirKills        int     `json:"theirKills"`
+	OurParticipants   int     `json:"ourParticipants"`
+	TheirParticipants int     `json:"theirParticipants"`
+	Objective         string  `json:"objective,omitempty"`        // Objective taken during or right after the fight
+	ObjectiveTakenBy  string  `json:"objectiveTakenBy,omitempty"` // "us" or "them"
+	Result            string  `json:"result"`                     // "won", "lost" or "even" by kills traded
+}
+
+// FightRecord is the teamfight record in one situation
+type FightRecord struct {
+	Fights   int     `json:"fights"`
+	Won      int     `json:"won"`
+	Lost     int     `json:"lost"`
+	WinRate  float64 `json:"winRate"`  // Won fights over all fights
+	KillDiff int     `json:"killDiff"` // Kills traded, ours minus theirs
+}
+
+// TeamfightAnalysis is a team's teamfight record from the kill feed
+type TeamfightAnalysis struct {
+	Games       int                                `json:"games"`
+	Fights      int                                `json:"fights"`
+	Overall     FightRecord                        `json:"overall"`
+	AvgKills    float64                            `json:"avgKills"` // Kills per fight
+	ByPhase     map[string]*FightRecord            `json:"byPhase"`
+	ByArea      map[string]*FightRecord            `json:"byArea"`
+	ByObjective map[string]*FightRecord            `json:"byObjective"` // Fights at or for dragon, baron, herald, void grubs
+	AreaPhase   map[string]map[string]*FightRecord `json:"areaPhase"`   // Area, then phase
+	Teamfights  []Teamfight                        `json:"teamfights"`
+}
+
+// ObjectiveTake is one neutral objective taken in a LoL game, from the team's side
+type ObjectiveTake struct {
+	SeriesID        string `json:"seriesId"`
+	GameID          string `json:"gameId"`
+	Objective       string `json:"objective"`     // dragon, elder, baron, herald, voidGrub
+	GameTime        int    `json:"gameTime"`      // Seconds
+	TakenBy         string `json:"takenBy"`       // "us" or "them"
+	OurSetupKills   int    `json:"ourSetupKills"` // Kills in the 60 seconds before it
+	TheirSetupKills int    `json:"theirSetupKills"`
+	Contested       bool   `json:"contested"`            // Kills at the objective around the take
+	Trade           string `json:"trade,omitempty"`      // What the other team took in response
+	Structures      int    `json:"structures,omitempty"` // Baron only: structures the taker destroyed in the 3 minutes after
+}
+
+// ObjectiveControl summarizes one objective type
+type ObjectiveControl struct {
+	Taken            int            `json:"taken"`
+	Conceded         int            `json:"conceded"`
+	ControlRate      float64        `json:"controlRate"`
+	ContestedTakes   int            `json:"contestedTakes"` // Our takes with a fight at the objective
+	ContestedRate    float64        `json:"contestedRate"`
+	AvgSetupKillDiff float64        `json:"avgSetupKillDiff"` // Our minus their kills in the minute before our takes
+	Trades           int            `json:"trades"`           // Conceded objectives we answered with a cross-map take
+	TradeRate        float64        `json:"tradeRate"`
+	TradedFor        map[string]int `json:"tradedFor"`  // What we took in response
+	TradedAway       int            `json:"tradedAway"` // Our takes the opponent answered
+}
+
+// BaronPowerPlay measures what barons turned into
+type BaronPowerPlay struct {
+	Barons              int     `json:"barons"`
+	Converted           int     `json:"converted"` // Barons followed by a structure within 3 minutes
+	ConversionRate      float64 `json:"conversionRate"`
+	AvgStructures       float64 `json:"avgStructures"`
+	EnemyBarons         int     `json:"enemyBarons"`
+	EnemyConverted      int     `json:"enemyConverted"`
+	EnemyConversionRate float64 `json:"enemyConversionRate"`
+}
+
+// ObjectiveSetupAnalysis describes how a LoL team sets up, contests and trades objectives
+type ObjectiveSetupAnalysis struct {
+	Games       int                          `json:"games"`
+	ByObjective map[string]*ObjectiveControl `json:"byObjective"`
+	BaronPlay   BaronPowerPlay               `json:"baronPowerPlay"`
+	Takes       []ObjectiveTake              `json:"takes"`
+}
+
+// DragonTake is one elemental dragon in a game's dragon race
+type DragonTake struct {
+	Number    int    `json:"number"` // Order in the game
+	Type      string `json:"type"`
+	TakenBy   string `json:"takenBy"`             // "us" or "them"
+	GameTime  int    `json:"gameTime"`            // Seconds
+	SoulPoint string `json:"soulPoint,omitempty"` // Who was on soul point when it spawned: "us", "them" or "both"
+}
+
+// ElderFight is an elder dragon take and the fighting around it
+type ElderFight struct {
+	GameTime   int    `json:"gameTime"` // Seconds
+	TakenBy    string `json:"takenBy"`
+	OurKills   int    `json:"ourKills"` // Kills in the 30 seconds either side
+	TheirKills int    `json:"theirKills"`
+	Result     string `json:"result"` // "won", "lost" or "even" on kills
+	WonGame    bool   `json:"wonGame"`
+}
+
+// DragonRace is one game's dragons, soul and elders
+type DragonRace struct {
+	SeriesID    string       `json:"seriesId"`
+	GameID      string       `json:"gameId"`
+	Won         bool         `json:"won"`
+	Dragons     []DragonTake `json:"dragons"`
+	SoulType    string       `json:"soulType,omitempty"` // Type of the third dragon onwards
+	SoulTakenBy string       `json:"soulTakenBy,omitempty"`
+	SoulTime    int          `json:"soulTime,omitempty"` // Seconds
+	Elders      []ElderFight `json:"elders"`
+}
+
+// DragonRecord counts dragons taken and conceded
+type DragonRecord struct {
+	Taken    int     `json:"taken"`
+	Conceded int     `json:"conceded"`
+	TakeRate float64 `json:"takeRate"`
+}
+
+// DragonRaceAnalysis aggregates a LoL team's dragon races
+type DragonRaceAnalysis struct {
+	Games   int                              `json:"games"`
+	Dragons DragonRecord                     `json:"dragons"`
+	ByOrder map[int]map[string]*DragonRecord `json:"byOrder"` // Dragon number, then type
+	ByType  map[string]*DragonRecord         `json:"byType"`
+
+	// Souls
+	Souls         DragonRecord             `json:"souls"`
+	SoulsByType   map[string]*DragonRecord `json:"soulsByType"`
+	SoulWinRate   float64                  `json:"soulWinRate"` // Games won after taking soul
+	SoulCloses    int                      `json:"soulCloses"`  // Soul point dragons taken while on soul point
+	SoulChances   int                      `json:"soulChances"` // Dragons that spawned with them on soul point
+	SoulCloseRate float64                  `json:"soulCloseRate"`
+	SoulDenies    int                      `json:"soulDenies"` // Dragons taken while the opponent was on soul point
+	SoulThreats   int                      `json:"soulThreats"`
+	SoulDenyRate  float64                  `json:"soulDenyRate"`
+
+	// Elders
+	Elders            DragonRecord `json:"elders"`
+	ElderFightWinRate float64      `json:"elderFightWinRate"`
+	ElderGameWinRate  float64      `json:"elderGameWinRate"` // Games won after taking elder
+
+	Races []DragonRace `json:"races"`
+}
+
+// SideStats is a LoL team's record on one side of the map
+type SideStats struct {
+	Side            string         `json:"side"` // "blue" or "red"
+	Games           int            `json:"games"`
+	Wins            int            `json:"wins"`
+	WinRate         float64        `json:"winRate"`
+	FirstBloodRate  float64        `json:"firstBloodRate"`
+	FirstDragonRate float64        `json:"firstDragonRate"`
+	FirstHeraldRate float64        `json:"firstHeraldRate"`
+	FirstTowerRate  float64        `json:"firstTowerRate"`
+	AvgGameDuration float64        `json:"avgGameDuration"` // Minutes
+	AvgWinDuration  float64        `json:"avgWinDuration"`  // Minutes
+	GoldDiff15      float64        `json:"goldDiff15"`
+	Games15         int            `json:"games15"`
+	FirstPicks      map[string]int `json:"firstPicks"` // Champion -> games it was the team's first pick
+	TopFirstPick    string         `json:"topFirstPick,omitempty"`
+}
+
+// SideAnalysis splits a LoL team's performance by map side
+type SideAnalysis struct {
+	Blue          SideStats `json:"blue"`
+	Red           SideStats `json:"red"`
+	PreferredSide string    `json:"preferredSide,omitempty"` // Side with the clearly better win rate
+}
+
+// SideRecommendation is the side to choose against a LoL team when we have side selection
+type SideRecommendation struct {
+	Side       string   `json:"side"` // "blue" or "red"
+	When       string   `json:"when"`
+	Reason     string   `json:"reason"`
+	Evidence   []string `json:"evidence"`
+	Confidence float64  `json:"confidence"` // 0-1
+	SampleSize int      `json:"sampleSize"`
 }
 
 // VALTeamMetrics contains VALORANT specific team metrics
@@ -87,7 +353,20 @@ type VALTeamMetrics struct {
 	
 	// Playstyle
 	AggressionScore     float64            `json:"aggressionScore"`
-	ClutchRate          float64            `json:"clutchRate"`
+	ClutchRate          float64            `json:"clutchRate"` // Share of 1vX rounds won
+
+	// Entry duels, trades and clutches from the kill feed
+	Trades              *TradeAnalysis     `json:"trades,omitempty"`
+	Clutches            *ClutchAnalysis    `json:"clutches,omitempty"`
+
+	// Post-plant conversion and retakes
+	PostPlant           *PostPlantAnalysis `json:"postPlant,omitempty"`
+	WinConditions       *WinConditionAnalysis `json:"winConditions,omitempty"`
+	Pistols             *PistolAnalysis    `json:"pistols,omitempty"`
+	GameFlow            *GameFlowAnalysis  `json:"gameFlow,omitempty"`
+
+	// Map bans and picks from series vetoes
+	Veto                *MapVetoProfile    `json:"veto,omitempty"`
 }
 
 // MapStats contains per-map statistics for VALORANT
@@ -144,6 +423,8 @@ type PlayerProfile struct {
 	ACS             float64           `json:"acs,omitempty"`      // Average Combat Score
 	FirstBloodRate  float64           `json:"firstBloodRate,omitempty"`
 	ClutchRate      float64           `json:"clutchRate,omitempty"`
+	TradeStats      *PlayerTradeStats `json:"tradeStats,omitempty"` // Entry duels and trades
+	ClutchStats     *PlayerClutchStats `json:"clutchStats,omitempty"` // 1vX rounds
 	
 	// NEW: Enhanced metrics from GRID API
 	MultikillStats  *MultikillStats   `json:"multikillStats,omitempty"`  // Multi-kill breakdown
@@ -221,16 +502,387 @@ type ObjectiveFocusStats struct {
 
 // EconomyRoundStats tracks economy round performance for VALORANT
 type EconomyRoundStats struct {
-	EcoRounds       int     `json:"ecoRounds"`       // Rounds with loadout < 2000
+	EcoRounds       int     `json:"ecoRounds"`       // Rounds with loadout < 2000 per player
 	EcoWins         int     `json:"ecoWins"`
 	EcoWinRate      float64 `json:"ecoWinRate"`
-	ForceRounds     int     `json:"forceRounds"`     // Rounds with loadout 2000-4000
+	ForceRounds     int     `json:"forceRounds"`     // Rounds with loadout 2000-4000 per player
 	ForceWins       int     `json:"forceWins"`
 	ForceWinRate    float64 `json:"forceWinRate"`
-	FullBuyRounds   int     `json:"fullBuyRounds"`   // Rounds with loadout > 4000
+	FullBuyRounds   int     `json:"fullBuyRounds"`   // Rounds with loadout > 4000 per player
 	FullBuyWins     int     `json:"fullBuyWins"`
 	FullBuyWinRate  float64 `json:"fullBuyWinRate"`
 	AvgLoadoutValue float64 `json:"avgLoadoutValue"` // Average loadout value across all rounds
+
+	// Buy matchups against the opponent's buy in the same round
+	AntiEcoRounds     int `json:"antiEcoRounds"`     // Full buy vs opponent eco
+	AntiEcoLosses     int `json:"antiEcoLosses"`
+	ForceVsFullRounds int `json:"forceVsFullRounds"` // Force buy vs opponent full buy
+	ForceUpsets       int `json:"forceUpsets"`       // Force buys won against a full buy
+}
+
+// TradeStats counts entry duels and traded deaths for a player or a team
+type TradeStats struct {
+	Rounds             int     `json:"rounds"`
+	EntryAttempts      int     `json:"entryAttempts"` // Rounds where they were in the first duel
+	EntryWins          int     `json:"entryWins"`
+	EntrySuccessRate   float64 `json:"entrySuccessRate"`
+	Deaths             int     `json:"deaths"`
+	TradedDeaths       int     `json:"tradedDeaths"` // Deaths avenged by a teammate within the trade window
+	TradedDeathRate    float64 `json:"tradedDeathRate"`
+	TradeOpportunities int     `json:"tradeOpportunities"` // Teammate deaths while alive
+	TradeKills         int     `json:"tradeKills"`
+	TradeEfficiency    float64 `json:"tradeEfficiency"` // Trade kills per opportunity
+}
+
+// PlayerTradeStats is one player's trade profile, overall and split by map and side
+type PlayerTradeStats struct {
+	PlayerID   string                 `json:"playerId"`
+	PlayerName string                 `json:"playerName"`
+	Overall    TradeStats             `json:"overall"`
+	ByMap      map[string]*TradeStats `json:"byMap"`
+	BySide     map[string]*TradeStats `json:"bySide"` // "attack"/"defense"
+}
+
+// TradeAnalysis contains a team's entry duel and trade patterns for VALORANT
+type TradeAnalysis struct {
+	TradeWindowMs int                    `json:"tradeWindowMs"`
+	Team          TradeStats             `json:"team"`
+	ByMap         map[string]*TradeStats `json:"byMap"`
+	BySide        map[string]*TradeStats `json:"bySide"`
+	Players       []*PlayerTradeStats    `json:"players"` // Most entry duels first
+}
+
+// ClutchRecord counts 1vX rounds and how many were won
+type ClutchRecord struct {
+	Attempts int     `json:"attempts"`
+	Wins     int     `json:"wins"`
+	WinRate  float64 `json:"winRate"`
+}
+
+// PlayerClutchStats is one player's clutch record by situation ("1v1".."1v5") and side
+type PlayerClutchStats struct {
+	PlayerID    string                   `json:"playerId"`
+	PlayerName  string                   `json:"playerName"`
+	Overall     ClutchRecord             `json:"overall"`
+	BySituation map[string]*ClutchRecord `json:"bySituation"`
+	BySide      map[string]*ClutchRecord `json:"bySide"`
+}
+
+// ClutchAnalysis contains a team's clutch record from the kill feed for VALORANT
+type ClutchAnalysis struct {
+	Team        ClutchRecord             `json:"team"`
+	BySituation map[string]*ClutchRecord `json:"bySituation"`
+	BySide      map[string]*ClutchRecord `json:"bySide"`
+	Players     []*PlayerClutchStats     `json:"players"` // Most clutch attempts first
+}
+
+// PostPlantStats counts planted rounds and wins for one bucket
+type PostPlantStats struct {
+	Rounds  int     `json:"rounds"`
+	Wins    int     `json:"wins"`
+	WinRate float64 `json:"winRate"`
+}
+
+// SitePostPlant contains post-plant play on one site of one map
+type SitePostPlant struct {
+	MapName string `json:"mapName"`
+	Site    string `json:"site"`
+
+	// After their own plant (attack)
+	Plants            int                        `json:"plants"`
+	PlantWins         int                        `json:"plantWins"`
+	PlantWinRate      float64                    `json:"plantWinRate"`
+	ByTiming          map[string]*PostPlantStats `json:"byTiming"`  // "early" (<45s), "default", "late" (>75s)
+	ByNumbers         map[string]*PostPlantStats `json:"byNumbers"` // "advantage", "even", "disadvantage" at plant
+	AvgAliveAtPlant   float64                    `json:"avgAliveAtPlant"`
+	AvgEnemiesAtPlant float64                    `json:"avgEnemiesAtPlant"`
+
+	// After the opponent's plant (defense)
+	DefendedPlants      int                        `json:"defendedPlants"`
+	Retakes             int                        `json:"retakes"` // Engaged or defused after the plant
+	RetakeWins          int                        `json:"retakeWins"`
+	RetakeWinRate       float64                    `json:"retakeWinRate"`
+	Saves               int                        `json:"saves"` // Let the spike detonate without engaging
+	SaveRate            float64                    `json:"saveRate"`
+	AvgTimeToRetakeSec  float64                    `json:"avgTimeToRetakeSec"` // Plant to defuse on successful retakes
+	AvgDefendersAtPlant float64                    `json:"avgDefendersAtPlant"`
+	AvgAttackersAtPlant float64                    `json:"avgAttackersAtPlant"`
+	RetakeByNumbers     map[string]*PostPlantStats `json:"retakeByNumbers"`
+}
+
+// PostPlantAnalysis contains a team's post-plant and retake patterns for VALORANT
+type PostPlantAnalysis struct {
+	Plants         int              `json:"plants"`
+	PlantWins      int              `json:"plantWins"`
+	PlantWinRate   float64          `json:"plantWinRate"`
+	DefendedPlants int              `json:"defendedPlants"`
+	Retakes        int              `json:"retakes"`
+	RetakeWins     int              `json:"retakeWins"`
+	RetakeWinRate  float64          `json:"retakeWinRate"`
+	Saves          int              `json:"saves"`
+	SaveRate       float64          `json:"saveRate"`
+	Sites          []*SitePostPlant `json:"sites"` // By map, then site
+}
+
+// WinConditionStats counts rounds won and lost by each win condition
+// ("elimination", "detonation", "defuse", "time"); rates are shares of all rounds
+type WinConditionStats struct {
+	Rounds    int                `json:"rounds"`
+	Wins      map[string]int     `json:"wins"`
+	Losses    map[string]int     `json:"losses"`
+	WinRates  map[string]float64 `json:"winRates"`
+	LossRates map[string]float64 `json:"lossRates"`
+}
+
+// WinConditionAnalysis contains how a team wins and loses VALORANT rounds
+type WinConditionAnalysis struct {
+	Overall      *WinConditionStats            `json:"overall"`
+	ByMap        map[string]*WinConditionStats `json:"byMap"`
+	BySide       map[string]*WinConditionStats `json:"bySide"`
+	ByMapSide    map[string]*WinConditionStats `json:"byMapSide"` // "Ascent defense"
+	Unclassified int                           `json:"unclassified"`
+}
+
+// PistolFollowUp tracks the two rounds after a pistol round, given its result
+type PistolFollowUp struct {
+	PistolRounds int `json:"pistolRounds"`
+
+	// After a pistol win: round 2/14 conversion and, after a conversion, the round 3/15 bonus round
+	PistolWins     int     `json:"pistolWins"`
+	SecondRounds   int     `json:"secondRounds"`
+	Conversions    int     `json:"conversions"`
+	ConversionRate float64 `json:"conversionRate"`
+	BonusRounds    int     `json:"bonusRounds"`
+	BonusWins      int     `json:"bonusWins"`
+	BonusWinRate   float64 `json:"bonusWinRate"`
+
+	// After a pistol loss: round 2/14 buy and result, and cheaper-buy wins in rounds 2-3
+	PistolLosses int     `json:"pistolLosses"`
+	BrokenRounds int     `json:"brokenRounds"`
+	Breaks       int     `json:"breaks"` // Round 2 won after losing pistol
+	BreakRate    float64 `json:"breakRate"`
+	KnownBuys    int     `json:"knownBuys"` // Round 2 buys with a loadout snapshot
+	ForceBuys    int     `json:"forceBuys"`
+	ForceRate    float64 `json:"forceRate"`
+	ForceWins    int     `json:"forceWins"`
+	ForceWinRate float64 `json:"forceWinRate"`
+	ThriftyWins  int     `json:"thriftyWins"`
+	ThriftyRate  float64 `json:"thriftyRate"` // Thrifty wins per pistol loss
+}
+
+// PistolAnalysis contains pistol follow-up patterns for VALORANT
+type PistolAnalysis struct {
+	Overall PistolFollowUp             `json:"overall"`
+	ByMap   map[string]*PistolFollowUp `json:"byMap"`
+	BySide  map[string]*PistolFollowUp `json:"bySide"` // Side of the pistol round
+}
+
+// RoundRecord is a round win rate for one game state
+type RoundRecord struct {
+	Rounds  int     `json:"rounds"`
+	Wins    int     `json:"wins"`
+	WinRate float64 `json:"winRate"`
+}
+
+// GameFlowAnalysis contains VALORANT performance by game state: halves, overtime,
+// score differential and match points
+type GameFlowAnalysis struct {
+	Games int `json:"games"` // Games with a complete round sequence
+
+	// Half-time adaptation
+	FirstHalf        RoundRecord             `json:"firstHalf"`
+	SecondHalf       RoundRecord             `json:"secondHalf"`
+	HalfTimeDelta    float64                 `json:"halfTimeDelta"` // Second-half minus first-half win rate
+	SecondHalfBySide map[string]*RoundRecord `json:"secondHalfBySide"`
+
+	// Overtime
+	Overtime        RoundRecord `json:"overtime"`
+	OvertimeGames   int         `json:"overtimeGames"`
+	OvertimeWins    int         `json:"overtimeWins"`
+	OvertimeWinRate float64     `json:"overtimeWinRate"`
+
+	// Round win rate by score differential before the round, clamped to +/-5
+	ByScoreDiff map[int]*RoundRecord `json:"byScoreDiff"`
+
+	// Rounds that could end the game for us (conversion) or for them (defense)
+	MatchPoint        RoundRecord `json:"matchPoint"`
+	MatchPointDefense RoundRecord `json:"matchPointDefense"`
+
+	// Games won after trailing and lost after leading by the comeback margin
+	Deficits      int     `json:"deficits"`
+	Comebacks     int     `json:"comebacks"`
+	ComebackRate  float64 `json:"comebackRate"`
+	Leads         int     `json:"leads"`
+	BlownLeads    int     `json:"blownLeads"`
+	BlownLeadRate float64 `json:"blownLeadRate"`
+}
+
+// ComebackTrait answers "are they a comeback team?"
+type ComebackTrait struct {
+	IsComebackTeam bool    `json:"isComebackTeam"`
+	Label          string  `json:"label"` // "comeback team", "fragile leads", "front-runner", "neutral"
+	ComebackRate   float64 `json:"comebackRate"`
+	BlownLeadRate  float64 `json:"blownLeadRate"`
+	SampleSize     int     `json:"sampleSize"` // Games behind or ahead by the comeback margin
+	Evidence       string  `json:"evidence"`
+}
+
+// SiteCalibrationReport describes a site geometry calibration run, map by map
+type SiteCalibrationReport struct {
+	Revision    int                     `json:"revision"`
+	GeneratedAt time.Time               `json:"generatedAt"`
+	Maps        []*MapCalibrationReport `json:"maps"`
+}
+
+// MapCalibrationReport is the accuracy of one map's learned geometry. There is no
+// ground truth for sites in the feed, so accuracy is measured as hold-out stability
+// and agreement with the two hand-drawn classifiers.
+type MapCalibrationReport struct {
+	MapName          string         `json:"mapName"`
+	Plants           int            `json:"plants"`
+	Kills            int            `json:"kills"`
+	Learned          bool           `json:"learned"`
+	Skipped          string         `json:"skipped,omitempty"` // Why the map kept its previous geometry
+	Sites            map[string]int `json:"sites,omitempty"`   // Plants per learned site
+	Silhouette       float64        `json:"silhouette"`        // Site cluster separation, -1 to 1
+	Coverage         float64        `json:"coverage"`          // Plants inside a learned site polygon
+	HoldoutAccuracy  float64        `json:"holdoutAccuracy"`   // Held-out plants classified into their cluster's site
+	GridAgreement    float64        `json:"gridAgreement"`     // Learned vs grid's hand-drawn boundaries
+	EllipseAgreement float64        `json:"ellipseAgreement"`  // Learned vs the hand-drawn site ellipses
+	LegacyAgreement  float64        `json:"legacyAgreement"`   // Between the two hand-drawn classifiers
+	MidSamples       int            `json:"midSamples"`
+	LobbySamples     int            `json:"lobbySamples"`
+}
+
+// HeatmapFilter restricts the rounds and events a heatmap bins; empty fields match all
+type HeatmapFilter struct {
+	Side      string `json:"side,omitempty"`      // "attack" or "defense"
+	RoundType string `json:"roundType,omitempty"` // "pistol", "eco", "force", "full"
+	Player    string `json:"player,omitempty"`    // Player name or ID
+	Weapon    string `json:"weapon,omitempty"`    // Weapon of the team's kills, e.g. "operator"; kill layers only
+}
+
+// HeatmapCell is one non-empty bin of a heatmap layer
+type HeatmapCell struct {
+	Col       int     `json:"col"`
+	Row       int     `json:"row"`
+	X         float64 `json:"x"` // Cell centre in map coordinates
+	Y         float64 `json:"y"`
+	Count     int     `json:"count"`
+	Intensity float64 `json:"intensity"` // Count relative to the layer's busiest cell
+}
+
+// HeatmapHotspot is one of a layer's busiest cells
+type HeatmapHotspot struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Count  int     `json:"count"`
+	Share  float64 `json:"share"`            // Of the layer's positions
+	Region string  `json:"region,omitempty"` // Learned site, mid or lobby containing the cell
+}
+
+// HeatmapLayer bins one kind of position on the map grid
+type HeatmapLayer struct {
+	Name     string           `json:"name"` // "kills", "deaths", "firstKills", "firstDeaths", "plants"
+	Total    int              `json:"total"`
+	MaxCount int              `json:"maxCount"`
+	Cells    []HeatmapCell    `json:"cells"`
+	Hotspots []HeatmapHotspot `json:"hotspots,omitempty"`
+}
+
+// HeatmapPlayer is one player's binned events, ignoring the player filter
+type HeatmapPlayer struct {
+	Name       string         `json:"name"`
+	Kills      int            `json:"kills"`
+	Deaths     int            `json:"deaths"`
+	FirstKills int            `json:"firstKills"`
+	Weapons    map[string]int `json:"weapons,omitempty"` // Kills by weapon
+}
+
+// MapHeatmaps is a team's positional heatmaps on one VALORANT map
+type MapHeatmaps struct {
+	TeamID     string          `json:"teamId"`
+	TeamName   string          `json:"teamName"`
+	MapName    string          `json:"mapName"`
+	Filter     HeatmapFilter   `json:"filter"`
+	CellSize   float64         `json:"cellSize"`
+	OriginX    float64         `json:"originX"` // Map coordinates of the grid's lower-left corner
+	OriginY    float64         `json:"originY"`
+	Cols       int             `json:"cols"`
+	Rows       int             `json:"rows"`
+	Rounds     int             `json:"rounds"`     // Rounds matching the side and round type filters
+	Sides      map[string]int  `json:"sides"`      // Rounds on the map by side
+	RoundTypes map[string]int  `json:"roundTypes"` // Rounds on the map by round type
+	Layers     []*HeatmapLayer `json:"layers"`
+	Players    []HeatmapPlayer `json:"players"`
+	Maps       []string        `json:"maps"` // Maps with position data
+}
+
+// VetoStep is one map ban or pick in a VALORANT veto
+type VetoStep struct {
+	Sequence int    `json:"sequence"`
+	TeamID   string `json:"teamId,omitempty"`
+	TeamName string `json:"teamName,omitempty"`
+	Action   string `json:"action"` // "ban", "pick" or "decider"
+	MapName  string `json:"mapName"`
+	Ours     bool   `json:"ours"` // Taken by the analyzed team
+}
+
+// SeriesVeto is the map veto of one series
+type SeriesVeto struct {
+	SeriesID  string     `json:"seriesId"`
+	Format    string     `json:"format,omitempty"`
+	Opponent  string     `json:"opponent,omitempty"`
+	WentFirst bool       `json:"wentFirst"`
+	Steps     []VetoStep `json:"steps"`
+}
+
+// MapVetoStats is how often a team bans and picks one map
+type MapVetoStats struct {
+	MapName   string  `json:"mapName"`
+	Bans      int     `json:"bans"`
+	FirstBans int     `json:"firstBans"`
+	Picks     int     `json:"picks"`
+	BanRate   float64 `json:"banRate"`  // Share of vetoes where they banned it
+	PickRate  float64 `json:"pickRate"` // Share of vetoes where they picked it
+}
+
+// MapVetoProfile summarises a team's veto history
+type MapVetoProfile struct {
+	SeriesAnalyzed int             `json:"seriesAnalyzed"`
+	Maps           []*MapVetoStats `json:"maps"` // Most vetoed first
+	History        []SeriesVeto    `json:"history"`
+}
+
+// MapLikelihood is how likely a team is to ban or pick a map
+type MapLikelihood struct {
+	MapName    string  `json:"mapName"`
+	Likelihood float64 `json:"likelihood"`
+	Reason     string  `json:"reason"`
+}
+
+// PredictedVetoStep is one step of a simulated veto
+type PredictedVetoStep struct {
+	Sequence   int     `json:"sequence"`
+	Team       string  `json:"team"` // "us", "them" or "" for the decider
+	Action     string  `json:"action"`
+	MapName    string  `json:"mapName"`
+	Reason     string  `json:"reason"`
+	Confidence float64 `json:"confidence"` // Likelihood of their choice; score margin of ours
+}
+
+// VetoPrediction is a suggested veto order for us and the predicted bans and picks of the opponent
+type VetoPrediction struct {
+	Format           string              `json:"format"`
+	OurTeam          string              `json:"ourTeam"`
+	TheirTeam        string              `json:"theirTeam"`
+	WeStart          bool                `json:"weStart"`
+	Steps            []PredictedVetoStep `json:"steps"`
+	Maps             []string            `json:"maps"` // Maps to be played, in order
+	TheirLikelyBans  []MapLikelihood     `json:"theirLikelyBans"`
+	TheirLikelyPicks []MapLikelihood     `json:"theirLikelyPicks"`
+	OurProfile       *MapVetoProfile     `json:"ourProfile,omitempty"`
+	TheirProfile     *MapVetoProfile     `json:"theirProfile,omitempty"`
 }
 
 // CompositionAnalysis contains team composition insights
@@ -251,6 +903,139 @@ type CompositionAnalysis struct {
 	
 	// Synergies
 	PlayerSynergies     []Synergy           `json:"playerSynergies"`
+
+	// Picks and bans by draft phase (LoL)
+	DraftPhases         *DraftPhaseAnalysis `json:"draftPhases,omitempty"`
+
+	// Draft hazards (LoL): flex picks by role, rarely played winners and new picks
+	FlexDetails    []FlexPick `json:"flexDetails,omitempty"`
+	PocketPicks    []RarePick `json:"pocketPicks,omitempty"`
+	FirstTimePicks []RarePick `json:"firstTimePicks,omitempty"`
+}
+
+// FlexPick is a champion the team plays in more than one role
+type FlexPick struct {
+	Character string     `json:"character"`
+	Games     int        `json:"games"`
+	WinRate   float64    `json:"winRate"`
+	Roles     []FlexRole `json:"roles"` // Most played first
+}
+
+// FlexRole is a flex pick's record in one role
+type FlexRole struct {
+	Role      string   `json:"role"`
+	Players   []string `json:"players"`
+	Games     int      `json:"games"`
+	WinRate   float64  `json:"winRate"`
+	Confirmed int      `json:"confirmed"` // Games the role detector placed the champion in this role
+}
+
+// RarePick is a champion a player rarely plays: a pocket pick that wins, or a
+// champion played for the first time in the latest series
+type RarePick struct {
+	Character   string  `json:"character"`
+	Player      string  `json:"player"`
+	Role        string  `json:"role,omitempty"`
+	Games       int     `json:"games"`
+	Wins        int     `json:"wins"`
+	WinRate     float64 `json:"winRate"`
+	PlayerGames int     `json:"playerGames"`        // The player's games in the sample
+	SeriesID    string  `json:"seriesId,omitempty"` // Where a first-time pick was played
+}
+
+// DraftSlotPicks is what a LoL team picks in one draft slot
+type DraftSlotPicks struct {
+	Slot      string             `json:"slot"` // B1, B2-B3, B4-B5, R1-R2, R3, R4 or R5
+	Games     int                `json:"games"`
+	Champions []DraftPriority    `json:"champions"` // Rate is the share of the slot's games
+	Roles     map[string]float64 `json:"roles"`     // Share of the slot's picks by role
+}
+
+// DraftPhaseAnalysis models a LoL team's drafts by phase: ban phase 1, the first
+// pick rotation, ban phase 2 and the last picks
+type DraftPhaseAnalysis struct {
+	Games     int `json:"games"`
+	BlueGames int `json:"blueGames"`
+	RedGames  int `json:"redGames"`
+
+	BanPhaseOne []DraftPriority `json:"banPhaseOne"` // The team's bans; Rate is the share of games
+	BanPhaseTwo []DraftPriority `json:"banPhaseTwo"`
+	BansAgainst []DraftPriority `json:"bansAgainst"` // Opponents' bans against them
+
+	BlueSlots     []DraftSlotPicks `json:"blueSlots"`
+	RedSlots      []DraftSlotPicks `json:"redSlots"`
+	FirstRotation []DraftPriority  `json:"firstRotation"` // B1 and R1-R2 picks; Rate is the share of games
+
+	// Roles left for the last pick, i.e. the counter-pick roles
+	LastPickRoles   map[string]float64 `json:"lastPickRoles"`
+	CounterPickRole string             `json:"counterPickRole,omitempty"`
+
+	// Who phase 2 bans go after
+	PhaseTwoBanRoles   map[string]float64 `json:"phaseTwoBanRoles"`
+	PhaseTwoTargetRate float64            `json:"phaseTwoTargetRate"` // Bans on champions the opponent played in the series
+}
+
+// DraftTurn is one pick or ban of a live LoL draft
+type DraftTurn struct {
+	Team      string `json:"team,omitempty"`   // "us" or "them"; defaults to the draft order
+	Action    string `json:"action,omitempty"` // "ban" or "pick"; defaults to the draft order
+	Character string `json:"character"`        // Empty for a skipped ban
+}
+
+// DraftPlayer is one player as the draft assistant sees them
+type DraftPlayer struct {
+	PlayerID      string           `json:"playerId"`
+	Nickname      string           `json:"nickname"`
+	Role          string           `json:"role"`
+	Games         int              `json:"games"`
+	Pool          []CharacterStats `json:"pool"`
+	WeakAgainst   []MatchupStats   `json:"weakAgainst,omitempty"` // Lane opponent classes they struggle against
+	StrongAgainst []MatchupStats   `json:"strongAgainst,omitempty"`
+}
+
+// DraftTeamProfile is what the draft assistant knows about a team
+type DraftTeamProfile struct {
+	TeamID   string              `json:"teamId"`
+	TeamName string              `json:"teamName"`
+	Players  []DraftPlayer       `json:"players"`
+	Phases   *DraftPhaseAnalysis `json:"phases,omitempty"`
+}
+
+// DraftResponse is a predicted opponent pick or ban
+type DraftResponse struct {
+	Turn       int     `json:"turn"`
+	Action     string  `json:"action"`
+	Character  string  `json:"character"`
+	Role       string  `json:"role,omitempty"`
+	Likelihood float64 `json:"likelihood"`
+	Reason     string  `json:"reason"`
+}
+
+// DraftSuggestion is a ranked option for our next pick or ban
+type DraftSuggestion struct {
+	Character string   `json:"character"`
+	Role      string   `json:"role,omitempty"`
+	Score     float64  `json:"score"`
+	Rationale []string `json:"rationale"`
+	// The opponent's most likely actions until our next turn if we take it
+	PredictedResponse []DraftResponse `json:"predictedResponse"`
+}
+
+// DraftAdvice is the draft assistant's answer for the next turn of a draft
+type DraftAdvice struct {
+	Turn     int    `json:"turn"` // 1-20; 0 once the draft is complete
+	Complete bool   `json:"complete"`
+	Team     string `json:"team,omitempty"`   // Whose turn it is: "us" or "them"
+	Action   string `json:"action,omitempty"` // "ban" or "pick"
+	Phase    string `json:"phase,omitempty"`  // "ban1", "pick1", "ban2" or "pick2"
+	Slot     string `json:"slot,omitempty"`   // Pick slot, e.g. "B1" or "R1-R2"
+
+	OurArchetype   string   `json:"ourArchetype,omitempty"`
+	TheirArchetype string   `json:"theirArchetype,omitempty"`
+	OpenRoles      []string `json:"openRoles"` // Our roles still to pick
+
+	Suggestions []DraftSuggestion `json:"suggestions"`           // On our turns
+	TheirLikely []DraftResponse   `json:"theirLikely,omitempty"` // On their turns
 }
 
 // Composition represents a team composition
@@ -350,6 +1135,7 @@ type TrendAnalysis struct {
 	// Form indicator
 	FormIndicator   string       `json:"formIndicator"` // "hot", "stable", "cold"
 	FormScore       float64      `json:"formScore"`     // -100 to 100
+	FormPValue      float64      `json:"formPValue"`    // last 10 (or later half) vs earlier games, one-sided exact binomial test
 	
 	// Trend changes
 	TrendChanges    []TrendChange `json:"trendChanges"`
@@ -365,6 +1151,15 @@ type TrendChange struct {
 	NewValue    float64   `json:"newValue"`
 	ChangeDate  time.Time `json:"changeDate"`
 	Explanation string    `json:"explanation"`
+	PValue      float64   `json:"pValue"` // only changes below SignificanceLevel are reported
+	Cause       string    `json:"cause,omitempty"` // "roster", "patch", "tournament"; empty if none coincides
+}
+
+// PatchRelease is a game patch date used to explain trend changes
+type PatchRelease struct {
+	Title   string    `json:"title"` // "lol" or "valorant"; empty matches both
+	Version string    `json:"version"`
+	Date    time.Time `json:"date"`
 }
 
 // MetricTrend represents the trend of a specific metric
@@ -372,6 +1167,7 @@ type MetricTrend struct {
 	Metric    string    `json:"metric"`
 	Direction string    `json:"direction"` // "improving", "stable", "declining"
 	Values    []float64 `json:"values"`    // chronological values
+	PValue    float64   `json:"pValue"`    // permutation test on the slope
 }
 
 // MatchupAnalysis contains head-to-head analysis
@@ -414,6 +1210,7 @@ type DraftPattern struct {
 type ScoutingReport struct {
 	ID              string              `json:"id"`
 	GeneratedAt     time.Time           `json:"generatedAt"`
+	AsOf            *time.Time          `json:"asOf,omitempty"` // only series before this time were used
 	
 	// Target team info
 	OpponentTeam    TeamInfo            `json:"opponentTeam"`
@@ -545,6 +1342,9 @@ type HowToWinSection struct {
 	// In-game strategy
 	InGameStrategy []InGameStrategyInsight `json:"inGameStrategy"`
 
+	// Side to take when we have side selection (LoL)
+	SideSelection *SideRecommendation `json:"sideSelection,omitempty"`
+
 	// Confidence score
 	ConfidenceScore float64 `json:"confidenceScore"`
 }
@@ -582,6 +1382,20 @@ type DraftStrategySection struct {
 	// Target picks (force opponent onto weak picks)
 	// Example: "Force their top-laner onto tanks - 42% win rate vs 68% on carries"
 	TargetPicks []DraftInsight `json:"targetPicks"`
+
+	// Priority bans split by ban phase (LoL); PriorityBans lists the same bans in phase order
+	BanPhases []BanPhasePlan `json:"banPhases,omitempty"`
+
+	// Picks that are hard to plan for: flex picks, pocket picks and first-time picks
+	// Example: "Flex: Gragas played Top (3 games, 67%) and Jungle (2 games, 100%)"
+	Hazards []DraftInsight `json:"hazards,omitempty"`
+}
+
+// BanPhasePlan is the bans to make in one LoL ban phase
+type BanPhasePlan struct {
+	Phase string         `json:"phase"` // "ban1" or "ban2"
+	Label string         `json:"label"`
+	Bans  []DraftInsight `json:"bans"`
 }
 
 // DraftInsight is a single draft recommendation
@@ -601,6 +1415,9 @@ type DraftInsight struct {
 
 	// Priority (1 = highest)
 	Priority int `json:"priority"`
+
+	// Ban phase for LoL bans: "ban1" or "ban2"
+	Phase string `json:"phase,omitempty"`
 }
 
 // InGameStrategyInsight is a specific in-game recommendation
@@ -747,11 +1564,18 @@ type JunglePathingAnalysis struct {
 	// Gank patterns
 	GanksByLane map[string]float64 `json:"ganksByLane"` // Lane -> gank frequency
 
-	// First clear patterns
+	// First clear patterns, from player positions in the first 3:30
 	FirstClearPatterns []ClearPattern `json:"firstClearPatterns"`
+	FirstClearGames    int            `json:"firstClearGames"` // games with position data
 
-	// Counter-jungle frequency
+	// Level 1 invades (3+ players at the enemy's camps before they spawn)
+	Level1InvadeRate float64 `json:"level1InvadeRate"`
+
+	// Counter-jungle frequency (jungler at an enemy camp in the first clear)
 	CounterJungleRate float64 `json:"counterJungleRate"`
+
+	// First gank of each game by lane
+	FirstGanks []FirstGankTiming `json:"firstGanks,omitempty"`
 }
 
 // ClearPattern describes a jungle clear pattern
@@ -760,6 +1584,17 @@ type ClearPattern struct {
 	Frequency   float64 `json:"frequency"`   // How often this path is used
 	EndLocation string  `json:"endLocation"` // Where they end up
 	EndTime     float64 `json:"endTime"`     // Minutes when clear completes
+	Games       int     `json:"games"`
+	WinRate     float64 `json:"winRate"`
+}
+
+// FirstGankTiming is how often and when a team's first gank hits one lane
+type FirstGankTiming struct {
+	Lane     string  `json:"lane"` // "top", "mid", "bot"
+	Games    int     `json:"games"`
+	Rate     float64 `json:"rate"`     // share of games with a first gank
+	AvgTime  float64 `json:"avgTime"`  // minutes
+	Earliest float64 `json:"earliest"` // minutes
 }
 
 // ObjectiveTimingAnalysis tracks objective timing patterns
@@ -785,13 +1620,42 @@ type LanePatternAnalysis struct {
 	// Lane priority patterns
 	LanePriority map[string]float64 `json:"lanePriority"` // Lane -> priority score
 
-	// Roaming patterns
-	MidRoamRate    float64 `json:"midRoamRate"`    // How often mid roams
-	SupportRoamRate float64 `json:"supportRoamRate"` // How often support roams
+	// Games with player positions in the laning phase
+	PositionGames int `json:"positionGames"`
+
+	// Roaming patterns (leaving lane for another lane before 14:00)
+	MidRoamRate          float64       `json:"midRoamRate"`          // How often mid roams
+	SupportRoamRate      float64       `json:"supportRoamRate"`      // How often support roams
+	MidFirstRoamTime     float64       `json:"midFirstRoamTime"`     // Minutes, first roam of a game
+	SupportFirstRoamTime float64       `json:"supportFirstRoamTime"` // Minutes, first roam of a game
+	Roams                []RoamPattern `json:"roams,omitempty"`
+
+	// Lane swaps (laners holding another lane than their role's until 5:00)
+	LaneSwapRate float64           `json:"laneSwapRate"`
+	LaneSwaps    []LaneSwapPattern `json:"laneSwaps,omitempty"`
+
+	// Grouping patterns (4+ players together after the first clear)
+	FirstGroupTime float64            `json:"firstGroupTime"` // When they first group (minutes)
+	GroupLocation  string             `json:"groupLocation"`  // Where they typically group
+	GroupRate      float64            `json:"groupRate"`      // Share of games they group before 20:00
+	GroupLocations map[string]float64 `json:"groupLocations,omitempty"`
+}
+
+// RoamPattern is how often and when a laner roams to one lane
+type RoamPattern struct {
+	Role        string  `json:"role"`        // "mid", "support"
+	Destination string  `json:"destination"` // "top", "mid", "bot"
+	Roams       int     `json:"roams"`
+	Games       int     `json:"games"`   // games with a roam there
+	Rate        float64 `json:"rate"`    // share of games
+	AvgTime     float64 `json:"avgTime"` // minutes they left lane, first roam there of a game
+}
 
-	// Grouping patterns
-	FirstGroupTime float64 `json:"firstGroupTime"` // When they first group (minutes)
-	GroupLocation  string  `json:"groupLocation"`  // Where they typically group
+// LaneSwapPattern is how often a team plays one lane swap
+type LaneSwapPattern struct {
+	Swap  string  `json:"swap"` // e.g. "duo top, top laner bot"
+	Games int     `json:"games"`
+	Rate  float64 `json:"rate"`
 }
 
 // =============================================================================
@@ -842,6 +1706,17 @@ type EconomyAnalysis struct {
 	ForceRounds     int                        `json:"forceRounds"`
 	FullBuyWinRate  float64                    `json:"fullBuyWinRate"`
 	FullBuyRounds   int                        `json:"fullBuyRounds"`
+	AvgLoadoutValue float64                    `json:"avgLoadoutValue"`
+
+	// Buy matchups (our buy vs theirs in the same round)
+	AntiEcoRounds      int     `json:"antiEcoRounds"` // our full buy vs their eco
+	AntiEcoLosses      int     `json:"antiEcoLosses"`
+	AntiEcoLossRate    float64 `json:"antiEcoLossRate"`
+	ForceVsFullRounds  int     `json:"forceVsFullRounds"` // our force vs their full buy
+	ForceUpsets        int     `json:"forceUpsets"`
+	ForceUpsetRate     float64 `json:"forceUpsetRate"`
+	UnclassifiedRounds int     `json:"unclassifiedRounds"` // rounds without a loadout snapshot
+
 	ByMap           map[string]*MapEconomyStats `json:"byMap,omitempty"`
 }
 
@@ -854,13 +1729,18 @@ type MapEconomyStats struct {
 	ForceRounds    int     `json:"forceRounds"`
 	FullBuyWinRate float64 `json:"fullBuyWinRate"`
 	FullBuyRounds  int     `json:"fullBuyRounds"`
+
+	AntiEcoRounds     int `json:"antiEcoRounds"`
+	AntiEcoLosses     int `json:"antiEcoLosses"`
+	ForceVsFullRounds int `json:"forceVsFullRounds"`
+	ForceUpsets       int `json:"forceUpsets"`
 }
 
 // EconomyInsight is a hackathon-format economy insight
 // Example: "They only win 15% of eco rounds - play aggressive on their saves"
 type EconomyInsight struct {
 	Text       string  `json:"text"`
-	Type       string  `json:"type"` // "eco_weak", "force_strong", "force_weak", "full_buy_weak"
+	Type       string  `json:"type"` // "eco_weak", "force_strong", "force_weak", "full_buy_weak", "anti_eco_weak", "force_upset"
 	Value      float64 `json:"value"`
 	SampleSize int     `json:"sampleSize"`
 	Impact     string  `json:"impact"` // "HIGH", "MEDIUM", "LOW"
@@ -891,6 +1771,9 @@ type HeadToHeadReport struct {
 
 	// Confidence
 	ConfidenceScore float64 `json:"confidenceScore"`
+
+	// Pre-match prediction
+	WinProbability *WinProbability `json:"winProbability,omitempty"`
 	
 	// Warnings
 	Warnings []string `json:"warnings,omitempty"`
@@ -924,6 +1807,33 @@ type StyleComparison struct {
 	StyleInsight string `json:"styleInsight"`
 }
 
+// WinProbability is a pre-match prediction for team1 against team2
+type WinProbability struct {
+	Format           string  `json:"format"`           // "bo1", "bo3", "bo5"
+	Team1GameWinProb float64 `json:"team1GameWinProb"` // single game
+	Team1WinProb     float64 `json:"team1WinProb"`     // series
+	Team2WinProb     float64 `json:"team2WinProb"`     // series
+	LowerBound       float64 `json:"lowerBound"`       // team1 series win probability, 90% interval
+	UpperBound       float64 `json:"upperBound"`
+
+	Team1Rating float64  `json:"team1Rating"`
+	Team2Rating float64  `json:"team2Rating"`
+	Team1Form   float64  `json:"team1Form"` // mean (actual - expected) over recent games
+	Team2Form   float64  `json:"team2Form"`
+	MapOverlap  []string `json:"mapOverlap,omitempty"` // VALORANT only
+	H2HGames    int      `json:"h2hGames"`
+
+	Factors    []WinProbabilityFactor `json:"factors"`
+	SampleSize int                    `json:"sampleSize"` // historical series used
+}
+
+// WinProbabilityFactor is one component of the prediction on the logit scale
+type WinProbabilityFactor struct {
+	Name        string  `json:"name"` // "rating", "form", "map_pool", "head_to_head"
+	Logit       float64 `json:"logit"`
+	Description string  `json:"description"`
+}
+
 // HeadToHeadInsight is a specific insight from head-to-head analysis
 type HeadToHeadInsight struct {
 	Text       string  `json:"text"`
@@ -9,11 +9,29 @@ import (
 )
 
 // VALAnalyzer analyzes VALORANT match data
-type VALAnalyzer struct{}
+type VALAnalyzer struct {
+	economyAnalyzer *EconomyAnalyzer
+	tradeAnalyzer   *TradeAnalyzer
+	clutchAnalyzer  *ClutchAnalyzer
+	postPlant       *PostPlantAnalyzer
+	winConditions   *WinConditionAnalyzer
+	pistols         *PistolAnalyzer
+	gameFlow        *GameFlowAnalyzer
+	mapVeto         *MapVetoAnalyzer
+}
 
 // NewVALAnalyzer creates a new VALORANT analyzer
 func NewVALAnalyzer() *VALAnalyzer {
-	return &VALAnalyzer{}
+	return &VALAnalyzer{
+		economyAnalyzer: NewEconomyAnalyzer(),
+		tradeAnalyzer:   NewTradeAnalyzer(),
+		clutchAnalyzer:  NewClutchAnalyzer(),
+		postPlant:       NewPostPlantAnalyzer(),
+		winConditions:   NewWinConditionAnalyzer(),
+		pistols:         NewPistolAnalyzer(),
+		gameFlow:        NewGameFlowAnalyzer(),
+		mapVeto:         NewMapVetoAnalyzer(),
+	}
 }
 
 // AnalyzeTeam analyzes a team's VALORANT matches
@@ -51,15 +69,6 @@ func (a *VALAnalyzer) AnalyzeTeam(ctx context.Context, teamID string, teamName s
 		totalPlants         int
 		totalDefuses        int
 		totalExplosions     int
-		// NEW: Economy tracking
-		ecoRounds         int
-		ecoWins           int
-		forceRounds       int
-		forceWins         int
-		fullBuyRounds     int
-		fullBuyWins       int
-		totalLoadoutValue int64
-		loadoutSamples    int
 	)
 
 	// Map-specific tracking
@@ -180,73 +189,6 @@ func (a *VALAnalyzer) AnalyzeTeam(ctx context.Context, teamID string, teamName s
 				}
 			}
 
-			// NEW: Economy analysis from team loadout value
-			// Note: ourTeam.LoadoutValue is END-OF-GAME value, not per-round
-			// For accurate economy analysis, we need to use segment data
-			if ourTeam.LoadoutValue > 0 {
-				totalLoadoutValue += int64(ourTeam.LoadoutValue)
-				loadoutSamples++
-			}
-
-			// Per-round economy analysis from segments (more accurate)
-			// VALORANT economy thresholds (team total):
-			// - Eco: < 5000 (< 1000 per player)
-			// - Force: 5000-15000 (1000-3000 per player)
-			// - Full buy: > 15000 (> 3000 per player)
-			for _, segment := range game.Segments {
-				if segment.Type != "round" || !segment.Finished {
-					continue
-				}
-
-				// Find our team in this segment
-				var ourSegTeam *grid.SegmentTeam
-				for i := range segment.Teams {
-					if segment.Teams[i].ID == ourTeam.ID || segment.Teams[i].Name == ourTeam.Name {
-						ourSegTeam = &segment.Teams[i]
-						break
-					}
-				}
-
-				if ourSegTeam == nil {
-					continue
-				}
-
-				// Calculate team loadout from player loadouts in this round
-				// Note: Segment data may not have loadout values, so we estimate
-				// based on round number and side
-				roundNum := segment.SequenceNumber
-				isAttack := ourSegTeam.Side == "attacker" || ourSegTeam.Side == "attack"
-				won := ourSegTeam.Won
-
-				// Estimate economy based on round patterns:
-				// Rounds 1, 13 = pistol (eco)
-				// Rounds 2, 14 = usually eco/force after pistol
-				// Other rounds = depends on previous round outcome
-				isPistol := roundNum == 1 || roundNum == 13
-				isPostPistol := roundNum == 2 || roundNum == 14
-
-				if isPistol {
-					// Pistol rounds are always eco-level loadout
-					ecoRounds++
-					if won {
-						ecoWins++
-					}
-				} else if isPostPistol {
-					// Post-pistol is usually force buy
-					forceRounds++
-					if won {
-						forceWins++
-					}
-				} else {
-					// For other rounds, assume full buy (most common in pro play)
-					fullBuyRounds++
-					if won {
-						fullBuyWins++
-					}
-				}
-				_ = isAttack // Used for future side-specific economy analysis
-			}
-
 			// Fallback to event data if no segments available
 			if len(game.Segments) == 0 {
 				if eventData, ok := events[series.ID]; ok && eventData != nil {
@@ -399,39 +341,41 @@ func (a *VALAnalyzer) AnalyzeTeam(ctx context.Context, teamID string, teamName s
 
 		// Sort map pool by games played
 		sort.Slice(analysis.VALMetrics.MapPool, func(i, j int) bool {
-			return analysis.VALMetrics.MapPool[i].GamesPlayed > analysis.VALMetrics.MapPool[j].GamesPlayed
+			if analysis.VALMetrics.MapPool[i].GamesPlayed != analysis.VALMetrics.MapPool[j].GamesPlayed {
+				return analysis.VALMetrics.MapPool[i].GamesPlayed > analysis.VALMetrics.MapPool[j].GamesPlayed
+			}
+			return analysis.VALMetrics.MapPool[i].MapName < analysis.VALMetrics.MapPool[j].MapName
 		})
 
 		// Calculate aggression score
 		analysis.VALMetrics.AggressionScore = calculateVALAggressionScore(analysis.VALMetrics)
 
-		// NEW: Calculate economy stats
-		if ecoRounds > 0 {
-			analysis.VALMetrics.EcoRoundWinRate = float64(ecoWins) / float64(ecoRounds)
-		}
-		if forceRounds > 0 {
-			analysis.VALMetrics.ForceBuyWinRate = float64(forceWins) / float64(forceRounds)
-		}
-		if fullBuyRounds > 0 {
-			analysis.VALMetrics.FullBuyWinRate = float64(fullBuyWins) / float64(fullBuyRounds)
-		}
-		if loadoutSamples > 0 {
-			analysis.VALMetrics.AvgTeamLoadout = float64(totalLoadoutValue) / float64(loadoutSamples)
+		// Economy stats from per-round loadout snapshots
+		economy := a.economyAnalyzer.AnalyzeRoundStats(teamID, teamName, seriesStates, events)
+		analysis.VALMetrics.EcoRoundWinRate = economy.EcoWinRate
+		analysis.VALMetrics.ForceBuyWinRate = economy.ForceWinRate
+		analysis.VALMetrics.FullBuyWinRate = economy.FullBuyWinRate
+		analysis.VALMetrics.AvgTeamLoadout = economy.AvgLoadoutValue
+		analysis.VALMetrics.EconomyStats = economy
+
+		// Round-level patterns from the event feed
+		if len(events) > 0 {
+			analysis.VALMetrics.Trades = a.tradeAnalyzer.AnalyzeTrades(teamID, teamName, seriesStates, events)
+			analysis.VALMetrics.Clutches = a.clutchAnalyzer.AnalyzeClutches(teamID, teamName, seriesStates, events)
+			analysis.VALMetrics.ClutchRate = analysis.VALMetrics.Clutches.Team.WinRate
+			analysis.VALMetrics.PostPlant = a.postPlant.AnalyzePostPlants(teamID, teamName, seriesStates, events)
+			analysis.VALMetrics.WinConditions = a.winConditions.AnalyzeWinConditions(teamID, teamName, seriesStates, events)
 		}
 
-		// Build detailed economy stats
-		analysis.VALMetrics.EconomyStats = &EconomyRoundStats{
-			EcoRounds:       ecoRounds,
-			EcoWins:         ecoWins,
-			EcoWinRate:      analysis.VALMetrics.EcoRoundWinRate,
-			ForceRounds:     forceRounds,
-			ForceWins:       forceWins,
-			ForceWinRate:    analysis.VALMetrics.ForceBuyWinRate,
-			FullBuyRounds:   fullBuyRounds,
-			FullBuyWins:     fullBuyWins,
-			FullBuyWinRate:  analysis.VALMetrics.FullBuyWinRate,
-			AvgLoadoutValue: analysis.VALMetrics.AvgTeamLoadout,
-		}
+		// Rounds after each pistol; loadouts are used when the event feed has them
+		analysis.VALMetrics.Pistols = a.pistols.AnalyzePistolFollowUps(teamID, teamName, seriesStates, events)
+
+		// Halves, overtime and score state from the round sequence
+		analysis.VALMetrics.GameFlow = a.gameFlow.AnalyzeGameFlow(teamID, teamName, seriesStates, events)
+		analysis.Comeback = analysis.VALMetrics.GameFlow.ComebackTrait()
+
+		// Map bans and picks from the series vetoes
+		analysis.VALMetrics.Veto = a.mapVeto.AnalyzeVetoes(teamID, teamName, seriesStates)
 
 		// Generate insights
 		analysis.Strengths = generateVALStrengths(analysis)
@@ -441,6 +385,11 @@ func (a *VALAnalyzer) AnalyzeTeam(ctx context.Context, teamID string, teamName s
 	return analysis, nil
 }
 
+// PredictVeto suggests our veto order against an opponent and predicts their bans and picks
+func (a *VALAnalyzer) PredictVeto(ours, theirs *TeamAnalysis, format string, weStart bool) *VetoPrediction {
+	return a.mapVeto.PredictVeto(ours, theirs, format, weStart)
+}
+
 // AnalyzePlayers analyzes individual player performance for VALORANT
 func (a *VALAnalyzer) AnalyzePlayers(ctx context.Context, teamID string, seriesStates []*grid.SeriesState) ([]*PlayerProfile, error) {
 	playerStats := make(map[string]*valPlayerAggregator)
@@ -575,7 +524,10 @@ func (a *VALAnalyzer) AnalyzePlayers(ctx context.Context, teamID string, seriesS
 
 		// Sort agent pool by games played
 		sort.Slice(profile.CharacterPool, func(i, j int) bool {
-			return profile.CharacterPool[i].GamesPlayed > profile.CharacterPool[j].GamesPlayed
+			if profile.CharacterPool[i].GamesPlayed != profile.CharacterPool[j].GamesPlayed {
+				return profile.CharacterPool[i].GamesPlayed > profile.CharacterPool[j].GamesPlayed
+			}
+			return profile.CharacterPool[i].Character < profile.CharacterPool[j].Character
 		})
 
 		// Identify signature picks (top 3)
@@ -606,7 +558,10 @@ func (a *VALAnalyzer) AnalyzePlayers(ctx context.Context, teamID string, seriesS
 			}
 			// Sort by kills
 			sort.Slice(profile.WeaponStats, func(i, j int) bool {
-				return profile.WeaponStats[i].Kills > profile.WeaponStats[j].Kills
+				if profile.WeaponStats[i].Kills != profile.WeaponStats[j].Kills {
+					return profile.WeaponStats[i].Kills > profile.WeaponStats[j].Kills
+				}
+				return profile.WeaponStats[i].WeaponName < profile.WeaponStats[j].WeaponName
 			})
 		}
 
@@ -630,7 +585,10 @@ func (a *VALAnalyzer) AnalyzePlayers(ctx context.Context, teamID string, seriesS
 			}
 			// Sort by assist count
 			sort.Slice(profile.SynergyPartners, func(i, j int) bool {
-				return profile.SynergyPartners[i].AssistCount > profile.SynergyPartners[j].AssistCount
+				if profile.SynergyPartners[i].AssistCount != profile.SynergyPartners[j].AssistCount {
+					return profile.SynergyPartners[i].AssistCount > profile.SynergyPartners[j].AssistCount
+				}
+				return profile.SynergyPartners[i].PlayerID < profile.SynergyPartners[j].PlayerID
 			})
 			// Keep top 3
 			if len(profile.SynergyPartners) > 3 {
@@ -655,7 +613,10 @@ func (a *VALAnalyzer) AnalyzePlayers(ctx context.Context, teamID string, seriesS
 			}
 			// Sort by usage count
 			sort.Slice(profile.AbilityUsage, func(i, j int) bool {
-				return profile.AbilityUsage[i].UsageCount > profile.AbilityUsage[j].UsageCount
+				if profile.AbilityUsage[i].UsageCount != profile.AbilityUsage[j].UsageCount {
+					return profile.AbilityUsage[i].UsageCount > profile.AbilityUsage[j].UsageCount
+				}
+				return profile.AbilityUsage[i].AbilityID < profile.AbilityUsage[j].AbilityID
 			})
 			// Keep top 10
 			if len(profile.AbilityUsage) > 10 {
@@ -681,7 +642,10 @@ func (a *VALAnalyzer) AnalyzePlayers(ctx context.Context, teamID string, seriesS
 
 	// Sort by threat level
 	sort.Slice(profiles, func(i, j int) bool {
-		return profiles[i].ThreatLevel > profiles[j].ThreatLevel
+		if profiles[i].ThreatLevel != profiles[j].ThreatLevel {
+			return profiles[i].ThreatLevel > profiles[j].ThreatLevel
+		}
+		return profiles[i].PlayerID < profiles[j].PlayerID
 	})
 
 	return profiles, nil
@@ -728,6 +692,40 @@ type agentAggregator struct {
 	assists int
 }
 
+// AttachKillFeedStats adds each player's entry, trade and clutch stats from the
+// team analysis and refreshes the tendencies that depend on them
+func (a *VALAnalyzer) AttachKillFeedStats(profiles []*PlayerProfile, metrics *VALTeamMetrics) {
+	if metrics == nil {
+		return
+	}
+	trades := make(map[string]*PlayerTradeStats)
+	if metrics.Trades != nil {
+		for _, stats := range metrics.Trades.Players {
+			trades[stats.PlayerID] = stats
+		}
+	}
+	clutches := make(map[string]*PlayerClutchStats)
+	if metrics.Clutches != nil {
+		for _, stats := range metrics.Clutches.Players {
+			clutches[stats.PlayerID] = stats
+		}
+	}
+
+	for _, profile := range profiles {
+		if stats, ok := trades[profile.PlayerID]; ok {
+			profile.TradeStats = stats
+			if stats.Overall.Rounds > 0 {
+				profile.FirstBloodRate = float64(stats.Overall.EntryWins) / float64(stats.Overall.Rounds)
+			}
+		}
+		if stats, ok := clutches[profile.PlayerID]; ok {
+			profile.ClutchStats = stats
+			profile.ClutchRate = stats.Overall.WinRate
+		}
+		profile.Tendencies = generateVALPlayerTendencies(profile)
+	}
+}
+
 // Helper functions
 func isPlayerOnTeamByID(playerID string, team *grid.GameTeam) bool {
 	for _, p := range team.Players {
@@ -797,6 +795,24 @@ func generateVALStrengths(analysis *TeamAnalysis) []Insight {
 		})
 	}
 
+	if m.Clutches != nil && m.Clutches.Team.Attempts >= 8 && m.ClutchRate > 0.35 {
+		strengths = append(strengths, Insight{
+			Title:       "Clutch Specialists",
+			Description: "Wins an unusual share of 1vX rounds - don't relax in numbers advantages",
+			Value:       m.ClutchRate * 100,
+			SampleSize:  m.Clutches.Team.Attempts,
+		})
+	}
+
+	if analysis.Comeback != nil && analysis.Comeback.IsComebackTeam {
+		strengths = append(strengths, Insight{
+			Title:       "Comeback Team",
+			Description: "Regularly wins maps from big deficits - no lead is safe",
+			Value:       analysis.Comeback.ComebackRate * 100,
+			SampleSize:  m.GameFlow.Deficits,
+		})
+	}
+
 	// Map strengths
 	for _, mapEntry := range m.MapPool {
 		if mapEntry.Strength == "strong" && mapEntry.GamesPlayed >= 3 {
@@ -843,6 +859,24 @@ func generateVALWeaknesses(analysis *TeamAnalysis) []Insight {
 		})
 	}
 
+	if m.Clutches != nil && m.Clutches.Team.Attempts >= 8 && m.ClutchRate < 0.12 {
+		weaknesses = append(weaknesses, Insight{
+			Title:       "Poor Clutch Conversion",
+			Description: "Rarely wins 1vX rounds - a numbers advantage is almost always decisive",
+			Value:       m.ClutchRate * 100,
+			SampleSize:  m.Clutches.Team.Attempts,
+		})
+	}
+
+	if analysis.Comeback != nil && analysis.Comeback.Label == "fragile leads" {
+		weaknesses = append(weaknesses, Insight{
+			Title:       "Blows Leads",
+			Description: "Often loses maps after building a big lead - keep fighting when behind",
+			Value:       analysis.Comeback.BlownLeadRate * 100,
+			SampleSize:  m.GameFlow.Leads,
+		})
+	}
+
 	if m.FirstDeathRate > 0.55 {
 		weaknesses = append(weaknesses, Insight{
 			Title:       "First Death Vulnerability",
@@ -880,8 +914,8 @@ func determineVALRole(characterPool []CharacterStats) string {
 
 	maxRole := "Flex"
 	maxCount := 0
-	for role, count := range roleCounts {
-		if count > maxCount {
+	for _, role := range sortedKeys(roleCounts) {
+		if count := roleCounts[role]; count > maxCount {
 			maxCount = count
 			maxRole = role
 		}
@@ -1045,9 +1079,10 @@ func generateVALPlayerTendencies(profile *PlayerProfile) []string {
 	}
 
 	// Clutch potential
-	if profile.ClutchRate > 0.3 {
+	if profile.ClutchStats != nil && profile.ClutchStats.Overall.Attempts >= 3 && profile.ClutchRate > 0.3 {
 		tendencies = append(tendencies,
-			fmt.Sprintf("Clutch player (%.0f%% clutch win rate)", profile.ClutchRate*100))
+			fmt.Sprintf("Clutch player (%.0f%% clutch win rate, %d/%d)", profile.ClutchRate*100,
+				profile.ClutchStats.Overall.Wins, profile.ClutchStats.Overall.Attempts))
 	}
 
 	return tendencies
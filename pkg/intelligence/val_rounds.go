@@ -0,0 +1,235 @@
+package intelligence
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"scout9/pkg/grid"
+)
+
+// valRound is one finished round with its events, kill feed in time order
+type valRound struct {
+	gameID       string
+	mapName      string
+	roundNum     int
+	side         string // our team's side: "attack", "defense" or "" when unknown
+	won          bool
+	ourID        string
+	theirID      string
+	players      []grid.GamePlayer // our roster in this game
+	enemies      int               // opponent roster size
+	ourLoadout   int               // team loadout value, -1 when no snapshot
+	theirLoadout int
+	kills        []grid.VALKillEvent
+	plant        *grid.PlantEvent
+	defuse       *grid.DefuseEvent
+	roundEnd     *grid.RoundEndEvent
+}
+
+// collectVALRounds pairs every finished round our team played with its events
+func collectVALRounds(
+	teamID string,
+	teamName string,
+	seriesStates []*grid.SeriesState,
+	events map[string]*grid.VALEventData,
+) []valRound {
+	var rounds []valRound
+
+	for _, series := range seriesStates {
+		index := indexRoundEvents(events[series.ID])
+		economy := indexRoundEconomy(events[series.ID])
+		usedMaps := make(map[string]bool)
+
+		for _, game := range series.Games {
+			if !game.Finished {
+				continue
+			}
+
+			var ourTeam *grid.GameTeam
+			for i := range game.Teams {
+				if game.Teams[i].ID == teamID || (teamName != "" && game.Teams[i].Name == teamName) {
+					ourTeam = &game.Teams[i]
+					break
+				}
+			}
+			if ourTeam == nil {
+				continue
+			}
+			enemies, theirID := 5, ""
+			for _, team := range game.Teams {
+				if team.ID == ourTeam.ID {
+					continue
+				}
+				theirID = team.ID
+				if len(team.Players) > 0 {
+					enemies = len(team.Players)
+				}
+			}
+
+			mapName := game.Map
+			if mapName == "" {
+				mapName = "Unknown"
+			}
+
+			// Events without a game ID fall back to the map, which is only safe once per series
+			gameEvents, ok := index.byGame[game.ID]
+			if !ok || game.ID == "" {
+				mapKey := strings.ToLower(game.Map)
+				if !usedMaps[mapKey] {
+					gameEvents = index.byMap[mapKey]
+				}
+				usedMaps[mapKey] = true
+			}
+
+			gameEconomy := economy.forGame(game.ID, mapName)
+
+			for _, segment := range game.Segments {
+				if segment.Type != "round" || !segment.Finished {
+					continue
+				}
+
+				side, won := "", false
+				for _, team := range segment.Teams {
+					if team.ID == ourTeam.ID || (ourTeam.Name != "" && team.Name == ourTeam.Name) {
+						side, won = normalizeVALSide(team.Side), team.Won
+						break
+					}
+				}
+
+				round := valRound{
+					gameID:       game.ID,
+					mapName:      mapName,
+					roundNum:     segment.SequenceNumber,
+					side:         side,
+					won:          won,
+					ourID:        ourTeam.ID,
+					theirID:      theirID,
+					players:      ourTeam.Players,
+					enemies:      enemies,
+					ourLoadout:   -1,
+					theirLoadout: -1,
+				}
+				if loadout, ok := gameEconomy[segment.SequenceNumber][ourTeam.ID]; ok {
+					round.ourLoadout = loadout
+				}
+				if loadout, ok := gameEconomy[segment.SequenceNumber][theirID]; ok {
+					round.theirLoadout = loadout
+				}
+				if re := gameEvents[segment.SequenceNumber]; re != nil {
+					round.kills, round.plant, round.defuse, round.roundEnd = re.kills, re.plant, re.defuse, re.roundEnd
+				}
+				rounds = append(rounds, round)
+			}
+		}
+	}
+
+	return rounds
+}
+
+// roundEvents is the part of a series' event stream that belongs to one round
+type roundEvents struct {
+	kills    []grid.VALKillEvent
+	plant    *grid.PlantEvent
+	defuse   *grid.DefuseEvent
+	roundEnd *grid.RoundEndEvent
+}
+
+// roundEventIndex groups a series' events by game (or map) and round
+type roundEventIndex struct {
+	byGame map[string]map[int]*roundEvents
+	byMap  map[string]map[int]*roundEvents
+}
+
+func indexRoundEvents(events *grid.VALEventData) roundEventIndex {
+	index := roundEventIndex{
+		byGame: make(map[string]map[int]*roundEvents),
+		byMap:  make(map[string]map[int]*roundEvents),
+	}
+	if events == nil {
+		return index
+	}
+
+	bucket := func(m map[string]map[int]*roundEvents, key string, roundNum int) *roundEvents {
+		if m[key] == nil {
+			m[key] = make(map[int]*roundEvents)
+		}
+		if m[key][roundNum] == nil {
+			m[key][roundNum] = &roundEvents{}
+		}
+		return m[key][roundNum]
+	}
+	// each calls fn on the round's bucket in both indexes
+	each := func(gameID, mapName string, roundNum int, fn func(*roundEvents)) {
+		if gameID != "" {
+			fn(bucket(index.byGame, gameID, roundNum))
+		}
+		fn(bucket(index.byMap, strings.ToLower(mapName), roundNum))
+	}
+
+	for _, kill := range events.Kills {
+		each(kill.GameID, kill.MapName, kill.RoundNum, func(re *roundEvents) {
+			re.kills = append(re.kills, kill)
+		})
+	}
+	for i := range events.Plants {
+		plant := &events.Plants[i]
+		each(plant.GameID, plant.MapName, plant.RoundNum, func(re *roundEvents) {
+			if re.plant == nil {
+				re.plant = plant
+			}
+		})
+	}
+	for i := range events.Defuses {
+		defuse := &events.Defuses[i]
+		each(defuse.GameID, defuse.MapName, defuse.RoundNum, func(re *roundEvents) {
+			if re.defuse == nil {
+				re.defuse = defuse
+			}
+		})
+	}
+	for i := range events.RoundEnds {
+		roundEnd := &events.RoundEnds[i]
+		each(roundEnd.GameID, roundEnd.MapName, roundEnd.RoundNum, func(re *roundEvents) {
+			re.roundEnd = roundEnd
+		})
+	}
+
+	for _, m := range []map[string]map[int]*roundEvents{index.byGame, index.byMap} {
+		for _, rounds := range m {
+			for _, re := range rounds {
+				sort.SliceStable(re.kills, func(i, j int) bool {
+					return killGap(re.kills[i], re.kills[j]) > 0
+				})
+			}
+		}
+	}
+	return index
+}
+
+// killGap is how long after kill a kill b happened, by wall clock when both have it
+func killGap(a, b grid.VALKillEvent) time.Duration {
+	if !a.OccurredAt.IsZero() && !b.OccurredAt.IsZero() {
+		return b.OccurredAt.Sub(a.OccurredAt)
+	}
+	return time.Duration(b.GameTime-a.GameTime) * time.Millisecond
+}
+
+// eventGap is how long after the moment at (wall clock, or ms from round start) a kill happened
+func eventGap(at time.Time, gameTime int, kill grid.VALKillEvent) time.Duration {
+	if !at.IsZero() && !kill.OccurredAt.IsZero() {
+		return kill.OccurredAt.Sub(at)
+	}
+	return time.Duration(kill.GameTime-gameTime) * time.Millisecond
+}
+
+// normalizeVALSide maps the GRID side names onto "attack" and "defense"
+func normalizeVALSide(side string) string {
+	switch strings.ToLower(side) {
+	case "attack", "attacker", "attackers":
+		return "attack"
+	case "defense", "defender", "defenders":
+		return "defense"
+	}
+	return ""
+}
@@ -0,0 +1,215 @@
+package intelligence
+
+import (
+	"fmt"
+
+	"scout9/pkg/grid"
+)
+
+// WinConditionAnalyzer breaks VALORANT round outcomes down by how they were decided
+type WinConditionAnalyzer struct{}
+
+// NewWinConditionAnalyzer creates a new win-condition analyzer
+func NewWinConditionAnalyzer() *WinConditionAnalyzer {
+	return &WinConditionAnalyzer{}
+}
+
+// winConditions lists the conditions in report order
+var winConditions = []string{
+	grid.WinConditionElimination,
+	grid.WinConditionDetonation,
+	grid.WinConditionDefuse,
+	grid.WinConditionTime,
+}
+
+func newWinConditionStats() *WinConditionStats {
+	return &WinConditionStats{
+		Wins:      make(map[string]int),
+		Losses:    make(map[string]int),
+		WinRates:  make(map[string]float64),
+		LossRates: make(map[string]float64),
+	}
+}
+
+func (s *WinConditionStats) add(condition string, won bool) {
+	s.Rounds++
+	if won {
+		s.Wins[condition]++
+	} else {
+		s.Losses[condition]++
+	}
+}
+
+func (s *WinConditionStats) finish() {
+	if s.Rounds == 0 {
+		return
+	}
+	for condition, n := range s.Wins {
+		s.WinRates[condition] = float64(n) / float64(s.Rounds)
+	}
+	for condition, n := range s.Losses {
+		s.LossRates[condition] = float64(n) / float64(s.Rounds)
+	}
+}
+
+// roundWinCondition returns how the round was decided, from the round end when the
+// parser classified it, otherwise from the round's own plant, defuse and kill feed
+func roundWinCondition(round valRound) string {
+	if round.roundEnd != nil && round.roundEnd.WinCondition != "" {
+		return round.roundEnd.WinCondition
+	}
+	if len(round.kills) == 0 && round.plant == nil && round.defuse == nil {
+		return ""
+	}
+
+	winner := round.theirID
+	if round.won {
+		winner = round.ourID
+	}
+	deaths := make(map[string]int)
+	for _, kill := range round.kills {
+		deaths[kill.VictimTeamID]++
+	}
+	roundEnd := grid.RoundEndEvent{WinnerTeam: winner}
+	if round.roundEnd != nil {
+		roundEnd.WinType = round.roundEnd.WinType
+	}
+	return grid.ClassifyWinCondition(roundEnd, round.plant, round.defuse != nil, deaths)
+}
+
+// AnalyzeWinConditions classifies every round as elimination, detonation, defuse or
+// time expiry and counts the team's wins and losses by condition, map and side
+func (w *WinConditionAnalyzer) AnalyzeWinConditions(
+	teamID string,
+	teamName string,
+	seriesStates []*grid.SeriesState,
+	events map[string]*grid.VALEventData,
+) *WinConditionAnalysis {
+	analysis := &WinConditionAnalysis{
+		Overall:   newWinConditionStats(),
+		ByMap:     make(map[string]*WinConditionStats),
+		BySide:    make(map[string]*WinConditionStats),
+		ByMapSide: make(map[string]*WinConditionStats),
+	}
+
+	for _, round := range collectVALRounds(teamID, teamName, seriesStates, events) {
+		condition := roundWinCondition(round)
+		if condition == "" {
+			analysis.Unclassified++
+			continue
+		}
+
+		analysis.Overall.add(condition, round.won)
+		if analysis.ByMap[round.mapName] == nil {
+			analysis.ByMap[round.mapName] = newWinConditionStats()
+		}
+		analysis.ByMap[round.mapName].add(condition, round.won)
+		if round.side == "" {
+			continue
+		}
+		if analysis.BySide[round.side] == nil {
+			analysis.BySide[round.side] = newWinConditionStats()
+		}
+		analysis.BySide[round.side].add(condition, round.won)
+		key := round.mapName + " " + round.side
+		if analysis.ByMapSide[key] == nil {
+			analysis.ByMapSide[key] = newWinConditionStats()
+		}
+		analysis.ByMapSide[key].add(condition, round.won)
+	}
+
+	analysis.Overall.finish()
+	for _, m := range []map[string]*WinConditionStats{analysis.ByMap, analysis.BySide, analysis.ByMapSide} {
+		for _, stats := range m {
+			stats.finish()
+		}
+	}
+
+	return analysis
+}
+
+// GenerateWinConditionInsights describes how a team wins and loses rounds on one side
+// Example: "On defense they lose 40% of rounds to detonations - they give up sites instead of retaking"
+func GenerateWinConditionInsights(analysis *WinConditionAnalysis, side string) []StrategyInsight {
+	insights := make([]StrategyInsight, 0)
+	if analysis == nil {
+		return insights
+	}
+	stats := analysis.BySide[side]
+	if stats == nil || stats.Rounds < 10 {
+		return insights
+	}
+
+	add := func(text, metric string, value float64, context string) {
+		insights = append(insights, StrategyInsight{
+			Text:       text,
+			Metric:     metric,
+			Value:      value,
+			SampleSize: stats.Rounds,
+			Context:    context,
+		})
+	}
+
+	// The map where a condition is most pronounced on this side
+	worstMap := func(condition string, losses bool) (string, float64) {
+		name, best := "", 0.0
+		for _, mapName := range sortedKeys(analysis.ByMap) {
+			ms := analysis.ByMapSide[mapName+" "+side]
+			if ms == nil || ms.Rounds < 8 {
+				continue
+			}
+			rate := ms.WinRates[condition]
+			if losses {
+				rate = ms.LossRates[condition]
+			}
+			if rate > best {
+				name, best = mapName, rate
+			}
+		}
+		return name, best
+	}
+
+	switch side {
+	case "defense":
+		if rate := stats.LossRates[grid.WinConditionDetonation]; rate >= 0.3 {
+			text := fmt.Sprintf("On defense they lose %.0f%% of rounds to detonations - they give up sites instead of retaking", rate*100)
+			if mapName, mapRate := worstMap(grid.WinConditionDetonation, true); mapName != "" && mapRate > rate {
+				text += fmt.Sprintf(" (%.0f%% on %s)", mapRate*100, mapName)
+			}
+			add(text, "defense_detonation_loss_rate", rate, "defense")
+		}
+		if rate := stats.WinRates[grid.WinConditionDefuse]; rate >= 0.2 {
+			add(fmt.Sprintf("On defense %.0f%% of rounds are won by defusing - a retake-heavy defense", rate*100),
+				"defense_defuse_win_rate", rate, "defense")
+		}
+		if rate := stats.WinRates[grid.WinConditionTime]; rate >= 0.1 {
+			add(fmt.Sprintf("On defense %.0f%% of rounds are won on time - attackers stall out against their setup", rate*100),
+				"defense_time_win_rate", rate, "defense")
+		}
+	case "attack":
+		if rate := stats.LossRates[grid.WinConditionTime]; rate >= 0.1 {
+			text := fmt.Sprintf("On attack they lose %.0f%% of rounds to the timer - slow defaults that run out of time", rate*100)
+			if mapName, mapRate := worstMap(grid.WinConditionTime, true); mapName != "" && mapRate > rate {
+				text += fmt.Sprintf(" (%.0f%% on %s)", mapRate*100, mapName)
+			}
+			add(text, "attack_time_loss_rate", rate, "attack")
+		}
+		if rate := stats.LossRates[grid.WinConditionDefuse]; rate >= 0.2 {
+			add(fmt.Sprintf("On attack they lose %.0f%% of rounds to defuses - their post-plants break on retakes", rate*100),
+				"attack_defuse_loss_rate", rate, "attack")
+		}
+		wins := 0
+		for _, condition := range winConditions {
+			wins += stats.Wins[condition]
+		}
+		if wins >= 8 {
+			share := float64(stats.Wins[grid.WinConditionElimination]) / float64(wins)
+			if share >= 0.6 {
+				add(fmt.Sprintf("On attack %.0f%% of their round wins are eliminations - they play for picks, not the plant", share*100),
+					"attack_elimination_win_share", share, "attack")
+			}
+		}
+	}
+
+	return insights
+}
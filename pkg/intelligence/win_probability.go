@@ -0,0 +1,504 @@
+package intelligence
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"scout9/pkg/grid"
+)
+
+// WinProbabilityModel predicts pre-match win probabilities from historical results.
+// Game strength is modelled on the logit scale as an Elo rating difference plus
+// smaller adjustments for recent form, map pool overlap (VALORANT only) and
+// head-to-head history.
+// Series probabilities are derived from the single-game probability.
+type WinProbabilityModel struct {
+	InitialRating    float64 // rating assigned to unseen teams
+	InitialDeviation float64 // rating uncertainty for unseen teams (Elo points)
+	KFactor          float64 // Elo update per game
+	FormWindow       int     // games used for recent form
+	FormWeight       float64 // logit per unit of form difference
+	MapWeight        float64 // multiplier on the average map edge
+	H2HWeight        float64 // multiplier on the head-to-head edge
+	PriorGames       float64 // pseudo-games shrinking map and H2H rates toward 50%
+}
+
+// NewWinProbabilityModel creates a win probability model with default parameters
+func NewWinProbabilityModel() *WinProbabilityModel {
+	return &WinProbabilityModel{
+		InitialRating:    1500,
+		InitialDeviation: 200,
+		KFactor:          24,
+		FormWindow:       5,
+		FormWeight:       0.6,
+		MapWeight:        0.5,
+		H2HWeight:        0.4,
+		PriorGames:       4,
+	}
+}
+
+// intervalZ is the normal quantile for the reported 90% interval
+const intervalZ = 1.645
+
+// MatchResult is a finished series reduced to what the model needs
+type MatchResult struct {
+	SeriesID  string       `json:"seriesId"`
+	StartTime time.Time    `json:"startTime"`
+	Format    string       `json:"format"`
+	Title     string       `json:"title"` // "lol" or "valorant"
+	Team1ID   string       `json:"team1Id"`
+	Team2ID   string       `json:"team2Id"`
+	WinnerID  string       `json:"winnerId"`
+	Games     []GameResult `json:"games"`
+}
+
+// GameResult is the outcome of a single game within a series
+type GameResult struct {
+	Map      string `json:"map,omitempty"`
+	WinnerID string `json:"winnerId"`
+}
+
+// BuildMatchResults joins series metadata with series states into match results.
+// Unfinished series and series without a clear winner are skipped.
+func BuildMatchResults(seriesList []grid.Series, states []*grid.SeriesState) []MatchResult {
+	info := make(map[string]grid.Series, len(seriesList))
+	for _, s := range seriesList {
+		info[s.ID] = s
+	}
+
+	seen := make(map[string]bool)
+	results := make([]MatchResult, 0, len(states))
+	for _, state := range states {
+		if state == nil || !state.Finished || len(state.Teams) != 2 || seen[state.ID] {
+			continue
+		}
+		seen[state.ID] = true
+
+		result := MatchResult{
+			SeriesID: state.ID,
+			Team1ID:  state.Teams[0].ID,
+			Team2ID:  state.Teams[1].ID,
+		}
+		if s, ok := info[state.ID]; ok {
+			result.StartTime = s.StartTime
+			result.Format = s.Format
+			result.Title = "lol"
+			if s.TitleID == "6" {
+				result.Title = "valorant"
+			}
+		}
+
+		for _, team := range state.Teams {
+			if team.Won {
+				result.WinnerID = team.ID
+			}
+		}
+		if result.WinnerID == "" {
+			if state.Teams[0].Score > state.Teams[1].Score {
+				result.WinnerID = state.Teams[0].ID
+			} else if state.Teams[1].Score > state.Teams[0].Score {
+				result.WinnerID = state.Teams[1].ID
+			}
+		}
+		if result.WinnerID == "" {
+			continue
+		}
+
+		for _, game := range state.Games {
+			if !game.Finished {
+				continue
+			}
+			for _, team := range game.Teams {
+				if team.Won {
+					result.Games = append(result.Games, GameResult{Map: game.Map, WinnerID: team.ID})
+					break
+				}
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	sortMatchResults(results)
+	return results
+}
+
+// Predict returns team1's win probability against team2 for the given title ("lol" or
+// "valorant") and series format, using only the supplied history. Map pools only
+// count for VALORANT.
+func (m *WinProbabilityModel) Predict(team1ID, team2ID, title, format string, history []MatchResult) *WinProbability {
+	format = NormalizeSeriesFormat(format)
+	ratings := m.buildRatings(history)
+	r1 := ratings.get(team1ID, m)
+	r2 := ratings.get(team2ID, m)
+
+	prediction := &WinProbability{
+		Format:      format,
+		Team1Rating: r1.rating,
+		Team2Rating: r2.rating,
+		Team1Form:   r1.form(m.FormWindow),
+		Team2Form:   r2.form(m.FormWindow),
+		Factors:     make([]WinProbabilityFactor, 0, 4),
+		SampleSize:  len(history),
+	}
+
+	eloScale := math.Ln10 / 400
+	ratingLogit := (r1.rating - r2.rating) * eloScale
+	prediction.Factors = append(prediction.Factors, WinProbabilityFactor{
+		Name:        "rating",
+		Logit:       ratingLogit,
+		Description: fmt.Sprintf("Rating %.0f vs %.0f (%d vs %d games)", r1.rating, r2.rating, r1.games, r2.games),
+	})
+
+	formLogit := m.FormWeight * (prediction.Team1Form - prediction.Team2Form)
+	prediction.Factors = append(prediction.Factors, WinProbabilityFactor{
+		Name:        "form",
+		Logit:       formLogit,
+		Description: fmt.Sprintf("Last %d games vs expectation: %+.2f vs %+.2f", m.FormWindow, prediction.Team1Form, prediction.Team2Form),
+	})
+
+	var mapLogit float64
+	if title == "valorant" {
+		var overlap []string
+		mapLogit, overlap = m.mapPoolEdge(team1ID, team2ID, history)
+		prediction.MapOverlap = overlap
+		if len(overlap) > 0 {
+			prediction.Factors = append(prediction.Factors, WinProbabilityFactor{
+				Name:        "map_pool",
+				Logit:       mapLogit,
+				Description: fmt.Sprintf("Average map edge across %d shared maps", len(overlap)),
+			})
+		}
+	}
+
+	h2hLogit, h2hGames := m.headToHeadEdge(team1ID, team2ID, history)
+	prediction.H2HGames = h2hGames
+	if h2hGames > 0 {
+		prediction.Factors = append(prediction.Factors, WinProbabilityFactor{
+			Name:        "head_to_head",
+			Logit:       h2hLogit,
+			Description: fmt.Sprintf("Direct record over %d games", h2hGames),
+		})
+	}
+
+	logit := ratingLogit + formLogit + mapLogit + h2hLogit
+	deviation := math.Sqrt(r1.deviation(m)*r1.deviation(m)+r2.deviation(m)*r2.deviation(m)) * eloScale
+
+	gamesToWin := seriesGamesToWin(format)
+	prediction.Team1GameWinProb = logistic(logit)
+	prediction.Team1WinProb = seriesWinProbability(prediction.Team1GameWinProb, gamesToWin)
+	prediction.Team2WinProb = 1 - prediction.Team1WinProb
+	prediction.LowerBound = seriesWinProbability(logistic(logit-intervalZ*deviation), gamesToWin)
+	prediction.UpperBound = seriesWinProbability(logistic(logit+intervalZ*deviation), gamesToWin)
+
+	return prediction
+}
+
+// BacktestResult summarizes walk-forward prediction quality
+type BacktestResult struct {
+	Predictions   int                 `json:"predictions"`
+	BrierScore    float64             `json:"brierScore"`
+	BaselineBrier float64             `json:"baselineBrier"` // always predicting 50%
+	LogLoss       float64             `json:"logLoss"`
+	Accuracy      float64             `json:"accuracy"`
+	Calibration   []CalibrationBucket `json:"calibration"`
+}
+
+// CalibrationBucket compares predicted and observed win rates for one probability band
+type CalibrationBucket struct {
+	Lower         float64 `json:"lower"`
+	Upper         float64 `json:"upper"`
+	Predictions   int     `json:"predictions"`
+	MeanPredicted float64 `json:"meanPredicted"`
+	ObservedRate  float64 `json:"observedRate"`
+}
+
+// Backtest predicts every series in history using only the series before it and
+// scores the predictions. Series where either team has fewer than minPriorSeries
+// earlier results are skipped.
+func (m *WinProbabilityModel) Backtest(history []MatchResult, minPriorSeries int) *BacktestResult {
+	ordered := make([]MatchResult, len(history))
+	copy(ordered, history)
+	sortMatchResults(ordered)
+
+	result := &BacktestResult{}
+	buckets := make([]CalibrationBucket, 10)
+	for i := range buckets {
+		buckets[i].Lower = float64(i) / 10
+		buckets[i].Upper = float64(i+1) / 10
+	}
+
+	seriesPlayed := make(map[string]int)
+	var brier, baseline, logLoss float64
+	correct := 0
+
+	for i, match := range ordered {
+		if seriesPlayed[match.Team1ID] >= minPriorSeries && seriesPlayed[match.Team2ID] >= minPriorSeries {
+			p := m.Predict(match.Team1ID, match.Team2ID, match.Title, match.Format, ordered[:i]).Team1WinProb
+			outcome := 0.0
+			if match.WinnerID == match.Team1ID {
+				outcome = 1
+			}
+
+			brier += (p - outcome) * (p - outcome)
+			baseline += (0.5 - outcome) * (0.5 - outcome)
+			clamped := math.Min(math.Max(p, 1e-6), 1-1e-6)
+			logLoss -= outcome*math.Log(clamped) + (1-outcome)*math.Log(1-clamped)
+			if (p >= 0.5) == (outcome == 1) {
+				correct++
+			}
+
+			idx := int(p * 10)
+			if idx > 9 {
+				idx = 9
+			}
+			buckets[idx].Predictions++
+			buckets[idx].MeanPredicted += p
+			buckets[idx].ObservedRate += outcome
+			result.Predictions++
+		}
+
+		seriesPlayed[match.Team1ID]++
+		seriesPlayed[match.Team2ID]++
+	}
+
+	if result.Predictions > 0 {
+		n := float64(result.Predictions)
+		result.BrierScore = brier / n
+		result.BaselineBrier = baseline / n
+		result.LogLoss = logLoss / n
+		result.Accuracy = float64(correct) / n
+	}
+
+	result.Calibration = make([]CalibrationBucket, 0, len(buckets))
+	for _, b := range buckets {
+		if b.Predictions == 0 {
+			continue
+		}
+		b.MeanPredicted /= float64(b.Predictions)
+		b.ObservedRate /= float64(b.Predictions)
+		result.Calibration = append(result.Calibration, b)
+	}
+
+	return result
+}
+
+// NormalizeSeriesFormat maps GRID format names ("Bo3", "best-of-3") to "bo1", "bo3" or "bo5".
+// Unknown formats default to "bo1".
+func NormalizeSeriesFormat(format string) string {
+	f := strings.ToLower(format)
+	switch {
+	case strings.Contains(f, "5"):
+		return "bo5"
+	case strings.Contains(f, "3"):
+		return "bo3"
+	}
+	return "bo1"
+}
+
+// teamRating tracks a team's running Elo rating and recent residuals
+type teamRating struct {
+	rating    float64
+	games     int
+	residuals []float64 // actual - expected, oldest first
+}
+
+func (r *teamRating) form(window int) float64 {
+	if len(r.residuals) == 0 || window <= 0 {
+		return 0
+	}
+	start := len(r.residuals) - window
+	if start < 0 {
+		start = 0
+	}
+	return average(r.residuals[start:])
+}
+
+func (r *teamRating) deviation(m *WinProbabilityModel) float64 {
+	return m.InitialDeviation / math.Sqrt(1+float64(r.games)/4)
+}
+
+type ratingTable map[string]*teamRating
+
+func (t ratingTable) get(teamID string, m *WinProbabilityModel) *teamRating {
+	r, ok := t[teamID]
+	if !ok {
+		r = &teamRating{rating: m.InitialRating}
+		t[teamID] = r
+	}
+	return r
+}
+
+// buildRatings replays history in time order, updating ratings once per game.
+// Series without game results count as a single game.
+func (m *WinProbabilityModel) buildRatings(history []MatchResult) ratingTable {
+	ordered := make([]MatchResult, len(history))
+	copy(ordered, history)
+	sortMatchResults(ordered)
+
+	table := make(ratingTable)
+	for _, match := range ordered {
+		winners := make([]string, 0, len(match.Games))
+		for _, g := range match.Games {
+			winners = append(winners, g.WinnerID)
+		}
+		if len(winners) == 0 {
+			winners = append(winners, match.WinnerID)
+		}
+
+		r1 := table.get(match.Team1ID, m)
+		r2 := table.get(match.Team2ID, m)
+		for _, winnerID := range winners {
+			expected := 1 / (1 + math.Pow(10, (r2.rating-r1.rating)/400))
+			actual := 0.0
+			if winnerID == match.Team1ID {
+				actual = 1
+			} else if winnerID != match.Team2ID {
+				continue
+			}
+
+			r1.rating += m.KFactor * (actual - expected)
+			r2.rating -= m.KFactor * (actual - expected)
+			r1.games++
+			r2.games++
+			r1.residuals = append(r1.residuals, actual-expected)
+			r2.residuals = append(r2.residuals, expected-actual)
+		}
+	}
+
+	return table
+}
+
+// mapPoolEdge averages the difference in smoothed map win rates (logit scale)
+// across maps both teams have played
+func (m *WinProbabilityModel) mapPoolEdge(team1ID, team2ID string, history []MatchResult) (float64, []string) {
+	type record struct{ wins, games float64 }
+	pools := map[string]map[string]*record{
+		team1ID: make(map[string]*record),
+		team2ID: make(map[string]*record),
+	}
+
+	for _, match := range history {
+		for _, g := range match.Games {
+			if g.Map == "" {
+				continue
+			}
+			for _, teamID := range []string{match.Team1ID, match.Team2ID} {
+				pool, ok := pools[teamID]
+				if !ok {
+					continue
+				}
+				rec, ok := pool[g.Map]
+				if !ok {
+					rec = &record{}
+					pool[g.Map] = rec
+				}
+				rec.games++
+				if g.WinnerID == teamID {
+					rec.wins++
+				}
+			}
+		}
+	}
+
+	overlap := make([]string, 0)
+	for mapName := range pools[team1ID] {
+		if _, ok := pools[team2ID][mapName]; ok {
+			overlap = append(overlap, mapName)
+		}
+	}
+	sort.Strings(overlap)
+	if len(overlap) == 0 {
+		return 0, nil
+	}
+
+	edge := 0.0
+	for _, mapName := range overlap {
+		a := pools[team1ID][mapName]
+		b := pools[team2ID][mapName]
+		edge += m.smoothedLogit(a.wins, a.games) - m.smoothedLogit(b.wins, b.games)
+	}
+
+	return m.MapWeight * edge / float64(len(overlap)), overlap
+}
+
+// headToHeadEdge returns the smoothed direct-record edge and the number of games between the teams
+func (m *WinProbabilityModel) headToHeadEdge(team1ID, team2ID string, history []MatchResult) (float64, int) {
+	var wins, games float64
+	for _, match := range history {
+		if !(match.Team1ID == team1ID && match.Team2ID == team2ID) &&
+			!(match.Team1ID == team2ID && match.Team2ID == team1ID) {
+			continue
+		}
+		if len(match.Games) == 0 {
+			games++
+			if match.WinnerID == team1ID {
+				wins++
+			}
+			continue
+		}
+		for _, g := range match.Games {
+			games++
+			if g.WinnerID == team1ID {
+				wins++
+			}
+		}
+	}
+
+	if games == 0 {
+		return 0, 0
+	}
+	return m.H2HWeight * m.smoothedLogit(wins, games), int(games)
+}
+
+func (m *WinProbabilityModel) smoothedLogit(wins, games float64) float64 {
+	p := (wins + m.PriorGames/2) / (games + m.PriorGames)
+	return math.Log(p / (1 - p))
+}
+
+// seriesWinProbability converts a single-game probability into a first-to-n series probability
+func seriesWinProbability(p float64, gamesToWin int) float64 {
+	if gamesToWin <= 1 {
+		return p
+	}
+	total := 0.0
+	for losses := 0; losses < gamesToWin; losses++ {
+		total += binomialCoefficient(gamesToWin-1+losses, losses) *
+			math.Pow(p, float64(gamesToWin)) * math.Pow(1-p, float64(losses))
+	}
+	return total
+}
+
+func seriesGamesToWin(format string) int {
+	switch format {
+	case "bo5":
+		return 3
+	case "bo3":
+		return 2
+	}
+	return 1
+}
+
+func binomialCoefficient(n, k int) float64 {
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
+
+func logistic(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
+
+func sortMatchResults(results []MatchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if !results[i].StartTime.Equal(results[j].StartTime) {
+			return results[i].StartTime.Before(results[j].StartTime)
+		}
+		return results[i].SeriesID < results[j].SeriesID
+	})
+}
@@ -0,0 +1,151 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"scout9/pkg/grid"
+	"scout9/pkg/intelligence"
+)
+
+// minBacktestHistory is the fewest prior series needed to build a report for a backtest step
+const minBacktestHistory = 3
+
+// BacktestRequest contains the parameters for a counter-strategy backtest
+type BacktestRequest struct {
+	TeamID       string
+	TeamName     string
+	TitleID      string // "3" for LoL, "6" for VALORANT
+	SeriesCount  int    // most recent series to evaluate
+	HistoryCount int    // earlier series used to build each report
+}
+
+// BacktestCounterStrategy replays the team's recent series. For each one it builds
+// the counter-strategy as of the day before, using only earlier series, and checks
+// whether the teams that played the opponent followed the advice.
+func (g *Generator) BacktestCounterStrategy(ctx context.Context, req BacktestRequest) (*intelligence.StrategyBacktestResult, error) {
+	title := "lol"
+	if req.TitleID == "6" {
+		title = "valorant"
+	}
+	if req.SeriesCount <= 0 {
+		req.SeriesCount = 10
+	}
+	if req.HistoryCount <= 0 {
+		req.HistoryCount = 10
+	}
+
+	teamName := req.TeamName
+	if teamName == "" {
+		team, err := g.gridClient.GetTeamByID(ctx, req.TeamID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team info: %w", err)
+		}
+		teamName = team.Name
+	}
+
+	seriesList, err := g.gridClient.GetSeriesForTeam(ctx, req.TeamID, req.SeriesCount+req.HistoryCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+	sort.SliceStable(seriesList, func(i, j int) bool {
+		return seriesList[i].StartTime.Before(seriesList[j].StartTime)
+	})
+
+	seriesIDs := make([]string, len(seriesList))
+	for i, s := range seriesList {
+		seriesIDs[i] = s.ID
+	}
+
+	states, err := g.gridClient.GetSeriesStates(ctx, seriesIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series states: %w", err)
+	}
+	stateByID := make(map[string]*grid.SeriesState, len(states))
+	for _, state := range states {
+		stateByID[state.ID] = state
+	}
+
+	lolEvents, valEvents := g.downloadEvents(ctx, title, seriesIDs)
+
+	backtester := intelligence.NewStrategyBacktester()
+	result := &intelligence.StrategyBacktestResult{
+		TeamID:   req.TeamID,
+		TeamName: teamName,
+		Outcomes: make([]intelligence.RecommendationOutcome, 0),
+	}
+
+	start := len(seriesList) - req.SeriesCount
+	if start < 0 {
+		start = 0
+	}
+	for _, target := range seriesList[start:] {
+		state := stateByID[target.ID]
+		if state == nil || !state.Finished || target.StartTime.IsZero() {
+			continue
+		}
+
+		// Only series that started before the day of the target series
+		cutoff := target.StartTime.Add(-24 * time.Hour)
+		var priorStates []*grid.SeriesState
+		for _, s := range seriesList {
+			if s.StartTime.IsZero() || !s.StartTime.Before(cutoff) {
+				continue
+			}
+			if priorState := stateByID[s.ID]; priorState != nil {
+				priorStates = append(priorStates, priorState)
+			}
+		}
+		if len(priorStates) > req.HistoryCount {
+			priorStates = priorStates[len(priorStates)-req.HistoryCount:]
+		}
+		if len(priorStates) < minBacktestHistory {
+			continue
+		}
+
+		analysis, err := g.analyzeSeries(ctx, req.TeamID, teamName, title, priorStates,
+			filterLoLEvents(lolEvents, priorStates), filterVALEvents(valEvents, priorStates))
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze series before %s: %w", target.ID, err)
+		}
+
+		outcomes := backtester.EvaluateSeries(analysis.teamAnalysis, analysis.counterStrategy,
+			state, lolEvents[target.ID], valEvents[target.ID])
+		result.Outcomes = append(result.Outcomes, outcomes...)
+		result.SeriesEvaluated++
+	}
+
+	result.HitRates = backtester.SummarizeOutcomes(result.Outcomes)
+
+	return result, nil
+}
+
+// filterLoLEvents keeps events for the given series only
+func filterLoLEvents(events map[string]*grid.LoLEventData, states []*grid.SeriesState) map[string]*grid.LoLEventData {
+	if events == nil {
+		return nil
+	}
+	filtered := make(map[string]*grid.LoLEventData, len(states))
+	for _, s := range states {
+		if e, ok := events[s.ID]; ok {
+			filtered[s.ID] = e
+		}
+	}
+	return filtered
+}
+
+// filterVALEvents keeps events for the given series only
+func filterVALEvents(events map[string]*grid.VALEventData, states []*grid.SeriesState) map[string]*grid.VALEventData {
+	if events == nil {
+		return nil
+	}
+	filtered := make(map[string]*grid.VALEventData, len(states))
+	for _, s := range states {
+		if e, ok := events[s.ID]; ok {
+			filtered[s.ID] = e
+		}
+	}
+	return filtered
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"time"
 
 	"scout9/pkg/intelligence"
 )
@@ -23,7 +22,7 @@ func (f *Formatter) FormatDigestibleReport(report *intelligence.ScoutingReport)
 	digestible := &intelligence.DigestibleReport{
 		TeamName:        report.OpponentTeam.Name,
 		MatchesAnalyzed: report.MatchesAnalyzed,
-		GeneratedAt:     time.Now().Format("2006-01-02 15:04:05"),
+		GeneratedAt:     report.GeneratedAt.Format("2006-01-02 15:04:05"),
 	}
 
 	// Generate executive summary (1 paragraph)
@@ -209,6 +208,12 @@ func (f *Formatter) formatLoLStrategies(section *intelligence.CommonStrategiesSe
 		})
 	}
 
+	// Objective setups, cross-map trades and baron conversion
+	section.ObjectivePriorities = append(section.ObjectivePriorities, intelligence.GenerateObjectiveSetupInsights(m.ObjectiveSetups)...)
+
+	// Dragon order tendencies, soul and elder
+	section.ObjectivePriorities = append(section.ObjectivePriorities, intelligence.GenerateDragonRaceInsights(m.DragonRace)...)
+
 	// Timing patterns - hackathon format: "4-man group for first tower push, usually in bot lane at ~13 mins"
 	if m.FirstTowerAvgTime > 0 {
 		section.TimingPatterns = append(section.TimingPatterns, intelligence.StrategyInsight{
@@ -236,6 +241,9 @@ func (f *Formatter) formatLoLStrategies(section *intelligence.CommonStrategiesSe
 			Context:    "game pace",
 		})
 	}
+
+	// Gold leads and deficits from the per-minute timelines
+	section.TimingPatterns = append(section.TimingPatterns, intelligence.GenerateGoldTimelineInsights(m.GoldTimeline)...)
 }
 
 func (f *Formatter) formatVALStrategies(section *intelligence.CommonStrategiesSection, report *intelligence.ScoutingReport) {
@@ -271,6 +279,9 @@ func (f *Formatter) formatVALStrategies(section *intelligence.CommonStrategiesSe
 		})
 	}
 
+	// How attack rounds are won and lost
+	section.AttackPatterns = append(section.AttackPatterns, intelligence.GenerateWinConditionInsights(m.WinConditions, "attack")...)
+
 	// Defense setups - hackathon format: "On Defense, they default to a 1-3-1 setup, rotating their Sentinel to mid"
 	if m.DefensePistolWinRate > 0 {
 		section.DefenseSetups = append(section.DefenseSetups, intelligence.StrategyInsight{
@@ -298,6 +309,10 @@ func (f *Formatter) formatVALStrategies(section *intelligence.CommonStrategiesSe
 		})
 	}
 
+	// Retake vs save after the spike goes down
+	section.DefenseSetups = append(section.DefenseSetups, intelligence.GenerateRetakeInsights(m.PostPlant)...)
+	section.DefenseSetups = append(section.DefenseSetups, intelligence.GenerateWinConditionInsights(m.WinConditions, "defense")...)
+
 	// NEW: Economy round insights
 	if m.EconomyStats != nil {
 		// Eco round performance
@@ -352,6 +367,18 @@ func (f *Formatter) formatVALStrategies(section *intelligence.CommonStrategiesSe
 		}
 	}
 
+	// Half-time, overtime and score-state patterns
+	section.TimingPatterns = append(section.TimingPatterns, intelligence.GenerateGameFlowInsights(m.GameFlow)...)
+	if trait := report.TeamStrategy.Comeback; trait != nil && trait.Label != "neutral" {
+		section.TimingPatterns = append(section.TimingPatterns, intelligence.StrategyInsight{
+			Text:       fmt.Sprintf("Game state: %s (%s)", trait.Label, trait.Evidence),
+			Metric:     "comeback_rate",
+			Value:      trait.ComebackRate,
+			SampleSize: trait.SampleSize,
+			Context:    "game state",
+		})
+	}
+
 	// Map-specific strategies
 	for _, mapEntry := range m.MapPool {
 		if mapEntry.GamesPlayed >= 3 {
@@ -605,6 +632,41 @@ func (f *Formatter) formatPlayerTendencies(report *intelligence.ScoutingReport)
 			}
 		}
 
+		// Entry duels and trades (VALORANT)
+		if player.TradeStats != nil {
+			teamEntries := 0
+			if report.TeamStrategy != nil && report.TeamStrategy.VALMetrics != nil && report.TeamStrategy.VALMetrics.Trades != nil {
+				teamEntries = report.TeamStrategy.VALMetrics.Trades.Team.EntryAttempts
+			}
+			tendencies = append(tendencies, intelligence.GenerateTradeInsights(player.TradeStats, player.Role, teamEntries)...)
+		}
+
+		// Clutch record (VALORANT)
+		if player.ClutchStats != nil && player.ClutchStats.Overall.Attempts >= 3 {
+			clutch := player.ClutchStats.Overall
+			text := fmt.Sprintf("Player '%s' wins %d of %d clutches (%.0f%%)", player.Nickname, clutch.Wins, clutch.Attempts, clutch.WinRate*100)
+			attack, defense := player.ClutchStats.BySide["attack"], player.ClutchStats.BySide["defense"]
+			if attack != nil && defense != nil {
+				text += fmt.Sprintf(" - %d/%d on attack, %d/%d on defense", attack.Wins, attack.Attempts, defense.Wins, defense.Attempts)
+			}
+			var situations []string
+			for x := 1; x <= 5; x++ {
+				situation := fmt.Sprintf("1v%d", x)
+				if r := player.ClutchStats.BySituation[situation]; r != nil {
+					situations = append(situations, fmt.Sprintf("%s %d/%d", situation, r.Wins, r.Attempts))
+				}
+			}
+			tendencies = append(tendencies, intelligence.PlayerTendencyInsight{
+				Text:         text,
+				PlayerName:   player.Nickname,
+				Role:         player.Role,
+				TendencyType: "clutch",
+				Value:        clutch.WinRate,
+				Context:      strings.Join(situations, ", "),
+				SampleSize:   clutch.Attempts,
+			})
+		}
+
 		// Weakness-based tendency
 		for _, weakness := range player.Weaknesses {
 			tendencies = append(tendencies, intelligence.PlayerTendencyInsight{
@@ -723,6 +785,9 @@ func (f *Formatter) formatCompositions(report *intelligence.ScoutingReport) []in
 		})
 	}
 
+	// Draft order: first picks per side, counter-pick roles, phase 2 bans
+	insights = append(insights, intelligence.GenerateDraftPhaseInsights(report.Compositions.DraftPhases)...)
+
 	return insights
 }
 
@@ -856,6 +921,12 @@ func (f *Formatter) addLoLActionableInsights(section *intelligence.HowToWinSecti
 		})
 	}
 
+	// Side selection for game 1 or after a loss
+	section.SideSelection = intelligence.RecommendSide(m.Sides)
+
+	// Where and when to take or avoid teamfights
+	section.InGameStrategy = append(section.InGameStrategy, intelligence.GenerateTeamfightStrategies(m.Teamfights)...)
+
 	// Player-specific insights from character pools
 	for _, player := range report.PlayerProfiles {
 		// Find weak champions
@@ -886,6 +957,20 @@ func (f *Formatter) addLoLActionableInsights(section *intelligence.HowToWinSecti
 			}
 		}
 	}
+
+	// Split the priority bans by ban phase
+	if report.Compositions != nil {
+		if plans := intelligence.PlanBanPhases(report.Compositions.DraftPhases, section.DraftStrategy.PriorityBans); len(plans) > 0 {
+			section.DraftStrategy.BanPhases = plans
+			section.DraftStrategy.PriorityBans = make([]intelligence.DraftInsight, 0)
+			for _, plan := range plans {
+				section.DraftStrategy.PriorityBans = append(section.DraftStrategy.PriorityBans, plan.Bans...)
+			}
+		}
+
+		// Flex, pocket and first-time picks
+		section.DraftStrategy.Hazards = intelligence.GenerateDraftHazards(report.Compositions)
+	}
 }
 
 // addVALActionableInsights adds VALORANT-specific actionable recommendations
@@ -939,6 +1024,9 @@ func (f *Formatter) addVALActionableInsights(section *intelligence.HowToWinSecti
 		})
 	}
 
+	// What they do around pistol rounds
+	section.InGameStrategy = append(section.InGameStrategy, intelligence.GeneratePistolStrategies(m.Pistols)...)
+
 	// Map veto recommendations
 	for _, mapEntry := range m.MapPool {
 		if mapEntry.Strength == "weak" && mapEntry.GamesPlayed >= 3 {
@@ -1072,7 +1160,23 @@ func (f *Formatter) FormatTextReport(digestible *intelligence.DigestibleReport)
 		sb.WriteString("\n")
 	}
 
-	if len(digestible.HowToWin.DraftStrategy.PriorityBans) > 0 {
+	if side := digestible.HowToWin.SideSelection; side != nil {
+		sb.WriteString(fmt.Sprintf("Side Selection (%s): take %s - %s\n", side.When, strings.ToUpper(side.Side), side.Reason))
+		for _, evidence := range side.Evidence {
+			sb.WriteString(fmt.Sprintf("  • %s\n", evidence))
+		}
+		sb.WriteString("\n")
+	}
+
+	for _, plan := range digestible.HowToWin.DraftStrategy.BanPhases {
+		sb.WriteString(fmt.Sprintf("Draft - %s:\n", plan.Label))
+		for _, ban := range plan.Bans {
+			sb.WriteString(fmt.Sprintf("  🚫 %s\n", ban.Text))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(digestible.HowToWin.DraftStrategy.BanPhases) == 0 && len(digestible.HowToWin.DraftStrategy.PriorityBans) > 0 {
 		sb.WriteString("Draft - Priority Bans:\n")
 		for _, ban := range digestible.HowToWin.DraftStrategy.PriorityBans {
 			sb.WriteString(fmt.Sprintf("  🚫 %s\n", ban.Text))
@@ -1080,6 +1184,14 @@ func (f *Formatter) FormatTextReport(digestible *intelligence.DigestibleReport)
 		sb.WriteString("\n")
 	}
 
+	if len(digestible.HowToWin.DraftStrategy.Hazards) > 0 {
+		sb.WriteString("Draft - Hazards:\n")
+		for _, hazard := range digestible.HowToWin.DraftStrategy.Hazards {
+			sb.WriteString(fmt.Sprintf("  ⚠️  %s\n", hazard.Text))
+		}
+		sb.WriteString("\n")
+	}
+
 	if len(digestible.HowToWin.DraftStrategy.TargetPicks) > 0 {
 		sb.WriteString("Draft - Target Picks (force opponent onto):\n")
 		for _, target := range digestible.HowToWin.DraftStrategy.TargetPicks {
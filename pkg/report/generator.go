@@ -35,12 +35,18 @@ func NewGenerator(gridClient *grid.Client) *Generator {
 	}
 }
 
+// SetPatchCalendar sets the patch releases used to explain trend changes
+func (g *Generator) SetPatchCalendar(patches []intelligence.PatchRelease) {
+	g.trendAnalyzer.SetPatchCalendar(patches)
+}
+
 // GenerateRequest contains the parameters for report generation
 type GenerateRequest struct {
 	TeamID     string
 	TeamName   string
 	TitleID    string // "3" for LoL, "6" for VALORANT
 	MatchCount int
+	AsOf       time.Time // when set, only series that started before it are used
 }
 
 // GenerateReport generates a complete scouting report for a team
@@ -68,7 +74,7 @@ func (g *Generator) GenerateReport(ctx context.Context, req GenerateRequest) (*i
 		seriesLimit = 10
 	}
 
-	seriesList, err := g.gridClient.GetSeriesForTeam(ctx, req.TeamID, seriesLimit)
+	seriesList, err := g.gridClient.GetSeriesForTeamBefore(ctx, req.TeamID, req.AsOf, seriesLimit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get series: %w", err)
 	}
@@ -89,6 +95,73 @@ func (g *Generator) GenerateReport(ctx context.Context, req GenerateRequest) (*i
 	}
 
 	// Step 4: Download and parse event files for detailed analysis
+	lolEvents, valEvents := g.downloadEvents(ctx, title, seriesIDs)
+
+	// Step 5: Run all analyzers
+	analysis, err := g.analyzeSeries(ctx, req.TeamID, teamName, title, seriesStates, lolEvents, valEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pocket and first-time picks need the series in time order
+	if title == "lol" {
+		g.compositionAnalyzer.AnalyzeRarePicks(analysis.compositions, req.TeamID, seriesStates, seriesList)
+	}
+
+	// Trend analysis
+	trends, err := g.trendAnalyzer.AnalyzeTrends(ctx, req.TeamID, seriesStates, seriesList, lolEvents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze trends: %w", err)
+	}
+
+	// Step 6: Build the final report
+	report := &intelligence.ScoutingReport{
+		ID:          uuid.New().String(),
+		GeneratedAt: time.Now(),
+		OpponentTeam: intelligence.TeamInfo{
+			ID:   req.TeamID,
+			Name: teamName,
+		},
+		Title:           title,
+		MatchesAnalyzed: analysis.teamAnalysis.MatchesAnalyzed,
+		HowToWin:        analysis.counterStrategy,
+		TeamStrategy:    analysis.teamAnalysis,
+		PlayerProfiles:  analysis.playerProfiles,
+		Compositions:    analysis.compositions,
+		TrendAnalysis:   trends,
+	}
+
+	// Add logo if available
+	if team != nil {
+		report.OpponentTeam.LogoURL = team.LogoURL
+	}
+
+	// As-of reports are reproducible: same inputs give the same ID and timestamp
+	if !req.AsOf.IsZero() {
+		asOf := req.AsOf.UTC()
+		report.AsOf = &asOf
+		report.GeneratedAt = asOf
+		report.ID = uuid.NewSHA1(uuid.NameSpaceURL, []byte(fmt.Sprintf("scout9:report:%s:%s:%d:%s",
+			req.TeamID, title, seriesLimit, asOf.Format(time.RFC3339)))).String()
+	}
+
+	// Generate executive summary
+	report.ExecutiveSummary = g.generateExecutiveSummary(report)
+
+	return report, nil
+}
+
+// seriesAnalysis holds analyzer output for one team over a set of series
+type seriesAnalysis struct {
+	teamAnalysis    *intelligence.TeamAnalysis
+	playerProfiles  []*intelligence.PlayerProfile
+	compositions    *intelligence.CompositionAnalysis
+	counterStrategy *intelligence.CounterStrategy
+}
+
+// downloadEvents downloads and parses event files for the given series.
+// Series whose events cannot be fetched are skipped - events are an optional enhancement.
+func (g *Generator) downloadEvents(ctx context.Context, title string, seriesIDs []string) (map[string]*grid.LoLEventData, map[string]*grid.VALEventData) {
 	var lolEvents map[string]*grid.LoLEventData
 	var valEvents map[string]*grid.VALEventData
 
@@ -121,43 +194,51 @@ func (g *Generator) GenerateReport(ctx context.Context, req GenerateRequest) (*i
 		}
 	}
 
-	// Step 5: Run all analyzers
+	return lolEvents, valEvents
+}
+
+// analyzeSeries runs the team, player, composition and counter-strategy analyzers
+func (g *Generator) analyzeSeries(
+	ctx context.Context,
+	teamID string,
+	teamName string,
+	title string,
+	seriesStates []*grid.SeriesState,
+	lolEvents map[string]*grid.LoLEventData,
+	valEvents map[string]*grid.VALEventData,
+) (*seriesAnalysis, error) {
 	var teamAnalysis *intelligence.TeamAnalysis
 	var playerProfiles []*intelligence.PlayerProfile
+	var err error
 
 	if title == "lol" {
-		teamAnalysis, err = g.lolAnalyzer.AnalyzeTeam(ctx, req.TeamID, teamName, seriesStates, lolEvents)
+		teamAnalysis, err = g.lolAnalyzer.AnalyzeTeam(ctx, teamID, teamName, seriesStates, lolEvents)
 		if err != nil {
 			return nil, fmt.Errorf("failed to analyze team: %w", err)
 		}
-		playerProfiles, err = g.lolAnalyzer.AnalyzePlayers(ctx, req.TeamID, seriesStates)
+		playerProfiles, err = g.lolAnalyzer.AnalyzePlayers(ctx, teamID, seriesStates)
 		if err != nil {
 			return nil, fmt.Errorf("failed to analyze players: %w", err)
 		}
 	} else {
-		teamAnalysis, err = g.valAnalyzer.AnalyzeTeam(ctx, req.TeamID, teamName, seriesStates, valEvents)
+		teamAnalysis, err = g.valAnalyzer.AnalyzeTeam(ctx, teamID, teamName, seriesStates, valEvents)
 		if err != nil {
 			return nil, fmt.Errorf("failed to analyze team: %w", err)
 		}
-		playerProfiles, err = g.valAnalyzer.AnalyzePlayers(ctx, req.TeamID, seriesStates)
+		playerProfiles, err = g.valAnalyzer.AnalyzePlayers(ctx, teamID, seriesStates)
 		if err != nil {
 			return nil, fmt.Errorf("failed to analyze players: %w", err)
 		}
+		g.valAnalyzer.AttachKillFeedStats(playerProfiles, teamAnalysis.VALMetrics)
 	}
 
 	// Composition analysis
-	compositions, err := g.compositionAnalyzer.AnalyzeCompositions(ctx, req.TeamID, title, seriesStates)
+	compositions, err := g.compositionAnalyzer.AnalyzeCompositions(ctx, teamID, title, seriesStates)
 	if err != nil {
 		return nil, fmt.Errorf("failed to analyze compositions: %w", err)
 	}
 
-	// Trend analysis
-	trends, err := g.trendAnalyzer.AnalyzeTrends(ctx, req.TeamID, seriesStates, seriesList)
-	if err != nil {
-		return nil, fmt.Errorf("failed to analyze trends: %w", err)
-	}
-
-	// Step 6: Generate counter-strategy (THE KEY DIFFERENTIATOR)
+	// Generate counter-strategy (THE KEY DIFFERENTIATOR)
 	// Use enhanced method with timing, matchup, and site analysis for hackathon-winning insights
 	var counterStrategy *intelligence.CounterStrategy
 	if title == "lol" {
@@ -172,32 +253,12 @@ func (g *Generator) GenerateReport(ctx context.Context, req GenerateRequest) (*i
 		)
 	}
 
-	// Step 7: Build the final report
-	report := &intelligence.ScoutingReport{
-		ID:          uuid.New().String(),
-		GeneratedAt: time.Now(),
-		OpponentTeam: intelligence.TeamInfo{
-			ID:   req.TeamID,
-			Name: teamName,
-		},
-		Title:           title,
-		MatchesAnalyzed: teamAnalysis.MatchesAnalyzed,
-		HowToWin:        counterStrategy,
-		TeamStrategy:    teamAnalysis,
-		PlayerProfiles:  playerProfiles,
-		Compositions:    compositions,
-		TrendAnalysis:   trends,
-	}
-
-	// Add logo if available
-	if team != nil {
-		report.OpponentTeam.LogoURL = team.LogoURL
-	}
-
-	// Generate executive summary
-	report.ExecutiveSummary = g.generateExecutiveSummary(report)
-
-	return report, nil
+	return &seriesAnalysis{
+		teamAnalysis:    teamAnalysis,
+		playerProfiles:  playerProfiles,
+		compositions:    compositions,
+		counterStrategy: counterStrategy,
+	}, nil
 }
 
 // GenerateDigestibleReport generates a hackathon-compliant DigestibleReport
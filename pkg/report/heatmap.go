@@ -0,0 +1,135 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"scout9/pkg/grid"
+	"scout9/pkg/intelligence"
+)
+
+// heatmapCellPixels is the rendered size of one heatmap grid cell
+const heatmapCellPixels = 16
+
+// HeatmapRequest contains the parameters for a VALORANT heatmap
+type HeatmapRequest struct {
+	TeamID      string
+	TeamName    string
+	MapName     string // empty for the team's map with the most position data
+	SeriesCount int
+	Filter      intelligence.HeatmapFilter
+}
+
+// GenerateHeatmaps bins a VALORANT team's kill, death and plant positions on one map
+func (g *Generator) GenerateHeatmaps(ctx context.Context, req HeatmapRequest) (*intelligence.MapHeatmaps, error) {
+	if req.SeriesCount <= 0 {
+		req.SeriesCount = 10
+	}
+
+	teamName := req.TeamName
+	if teamName == "" {
+		team, err := g.gridClient.GetTeamByID(ctx, req.TeamID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team info: %w", err)
+		}
+		teamName = team.Name
+	}
+
+	seriesList, err := g.gridClient.GetSeriesForTeam(ctx, req.TeamID, req.SeriesCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+	if len(seriesList) == 0 {
+		return nil, fmt.Errorf("no matches found for team %s", req.TeamID)
+	}
+
+	seriesIDs := make([]string, len(seriesList))
+	for i, s := range seriesList {
+		seriesIDs[i] = s.ID
+	}
+
+	seriesStates, err := g.gridClient.GetSeriesStates(ctx, seriesIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series states: %w", err)
+	}
+
+	_, valEvents := g.downloadEvents(ctx, "valorant", seriesIDs)
+
+	heatmaps := intelligence.NewHeatmapAnalyzer().BuildHeatmaps(
+		req.TeamID, teamName, seriesStates, valEvents, req.MapName, req.Filter)
+	return heatmaps, nil
+}
+
+// RenderHeatmapSVG draws one heatmap layer as an SVG overlay in map coordinates,
+// +Y up, with the learned site outlines when the map has them
+func RenderHeatmapSVG(heatmaps *intelligence.MapHeatmaps, layerName string) (string, error) {
+	layer := heatmaps.Layer(layerName)
+	if layer == nil {
+		return "", fmt.Errorf("unknown heatmap layer %q", layerName)
+	}
+	if heatmaps.Cols == 0 || heatmaps.Rows == 0 {
+		return "", fmt.Errorf("no position data for %s on %s", heatmaps.TeamName, heatmaps.MapName)
+	}
+
+	width := heatmaps.Cols * heatmapCellPixels
+	height := heatmaps.Rows * heatmapCellPixels
+	scale := heatmapCellPixels / heatmaps.CellSize
+	// toSVG converts map coordinates to pixels, flipping Y
+	toSVG := func(x, y float64) (float64, float64) {
+		return (x - heatmaps.OriginX) * scale, float64(height) - (y-heatmaps.OriginY)*scale
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&sb, "<title>%s</title>\n", html.EscapeString(heatmapTitle(heatmaps, layer)))
+	fmt.Fprintf(&sb, `<rect x="0" y="0" width="%d" height="%d" fill="none" stroke="#888" stroke-width="1"/>`+"\n",
+		width, height)
+
+	for _, cell := range layer.Cells {
+		// Yellow for the quietest cells through to red for the busiest
+		hue := 60 * (1 - cell.Intensity)
+		fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="%d" fill="hsl(%.0f,100%%,50%%)" fill-opacity="%.2f"><title>%d</title></rect>`+"\n",
+			cell.Col*heatmapCellPixels, (heatmaps.Rows-1-cell.Row)*heatmapCellPixels,
+			heatmapCellPixels, heatmapCellPixels, hue, 0.25+0.6*cell.Intensity, cell.Count)
+	}
+
+	if geometry := grid.CurrentSiteGeometry().ForMap(heatmaps.MapName); geometry != nil {
+		names := make([]string, 0, len(geometry.Sites))
+		for name := range geometry.Sites {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			site := geometry.Sites[name]
+			points := make([]string, 0, len(site.Polygon))
+			for _, p := range site.Polygon {
+				x, y := toSVG(p.X, p.Y)
+				points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+			}
+			fmt.Fprintf(&sb, `<polygon points="%s" fill="none" stroke="#fff" stroke-width="1.5" stroke-dasharray="4 2"/>`+"\n",
+				strings.Join(points, " "))
+			x, y := toSVG(site.Center.X, site.Center.Y)
+			fmt.Fprintf(&sb, `<text x="%.1f" y="%.1f" fill="#fff" font-family="sans-serif" font-size="14" text-anchor="middle">%s</text>`+"\n",
+				x, y, html.EscapeString(name))
+		}
+	}
+
+	sb.WriteString("</svg>\n")
+	return sb.String(), nil
+}
+
+// heatmapTitle describes the layer and the filters applied to it
+func heatmapTitle(heatmaps *intelligence.MapHeatmaps, layer *intelligence.HeatmapLayer) string {
+	parts := []string{heatmaps.TeamName, heatmaps.MapName, layer.Name}
+	filter := heatmaps.Filter
+	for _, value := range []string{filter.Side, filter.RoundType, filter.Player, filter.Weapon} {
+		if value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return fmt.Sprintf("%s (%d positions)", strings.Join(parts, " - "), layer.Total)
+}
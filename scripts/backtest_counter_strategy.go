@@ -0,0 +1,77 @@
+// +build ignore
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"scout9/pkg/grid"
+	"scout9/pkg/report"
+)
+
+// Backtest for counter-strategy recommendations.
+// For each recent series of the team, the report is rebuilt from series played
+// before that day and compared with what the opposing team actually did.
+//
+// Usage: go run scripts/backtest_counter_strategy.go -team 47351 -title 3 -series 10 -history 10
+
+func main() {
+	teamID := flag.String("team", "", "team ID to backtest reports for")
+	titleID := flag.String("title", "3", "\"3\" for LoL, \"6\" for VALORANT")
+	seriesCount := flag.Int("series", 10, "recent series to evaluate")
+	historyCount := flag.Int("history", 10, "earlier series used to build each report")
+	verbose := flag.Bool("v", false, "print every recommendation outcome")
+	flag.Parse()
+
+	apiKey := os.Getenv("GRID_API_KEY")
+	if apiKey == "" {
+		fmt.Println("ERROR: GRID_API_KEY environment variable not set")
+		os.Exit(1)
+	}
+	if *teamID == "" {
+		fmt.Println("ERROR: -team is required")
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	client := grid.NewClient(apiKey, nil)
+	generator := report.NewGenerator(client)
+
+	result, err := generator.BacktestCounterStrategy(ctx, report.BacktestRequest{
+		TeamID:       *teamID,
+		TitleID:      *titleID,
+		SeriesCount:  *seriesCount,
+		HistoryCount: *historyCount,
+	})
+	if err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("=" + strings.Repeat("=", 79))
+	fmt.Printf("COUNTER-STRATEGY BACKTEST: %s\n", result.TeamName)
+	fmt.Println("=" + strings.Repeat("=", 79))
+	fmt.Printf("Series evaluated: %d, recommendations checked: %d\n\n", result.SeriesEvaluated, len(result.Outcomes))
+
+	fmt.Printf("%-10s %6s %8s %9s %12s %14s %7s\n", "TYPE", "N", "FOLLOWED", "HIT RATE", "WR FOLLOWED", "WR NOT FOLLOWED", "LIFT")
+	for _, hr := range result.HitRates {
+		fmt.Printf("%-10s %6d %8d %8.0f%% %11.0f%% %14.0f%% %+6.0f%%\n",
+			hr.Type, hr.Evaluated, hr.Followed, hr.HitRate*100,
+			hr.WinRateFollowed*100, hr.WinRateNotFollowed*100, hr.Lift*100)
+	}
+
+	if *verbose {
+		fmt.Println("\nOutcomes:")
+		for _, o := range result.Outcomes {
+			fmt.Printf("  series %s game %d  %-9s %-25s followed=%-5t challenger won=%t\n",
+				o.SeriesID, o.GameSequence, o.Type, o.Recommendation, o.Followed, o.ChallengerWon)
+		}
+	}
+}
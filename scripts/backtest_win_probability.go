@@ -0,0 +1,93 @@
+// +build ignore
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"scout9/pkg/grid"
+	"scout9/pkg/intelligence"
+)
+
+// Backtest for the pre-match win probability model.
+// Each historical series is predicted using only the series played before it.
+//
+// Usage: go run scripts/backtest_win_probability.go -teams 47351,47380,47494 -matches 30
+
+func main() {
+	teamsFlag := flag.String("teams", "", "comma-separated team IDs whose series form the history")
+	matches := flag.Int("matches", 30, "series to fetch per team")
+	minPrior := flag.Int("min-prior", 3, "earlier series required for both teams before scoring a prediction")
+	flag.Parse()
+
+	apiKey := os.Getenv("GRID_API_KEY")
+	if apiKey == "" {
+		fmt.Println("ERROR: GRID_API_KEY environment variable not set")
+		os.Exit(1)
+	}
+	if *teamsFlag == "" {
+		fmt.Println("ERROR: -teams is required")
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	client := grid.NewClient(apiKey, nil)
+
+	var allSeries []grid.Series
+	seen := make(map[string]bool)
+	for _, teamID := range strings.Split(*teamsFlag, ",") {
+		teamID = strings.TrimSpace(teamID)
+		if teamID == "" {
+			continue
+		}
+		series, err := client.GetSeriesForTeam(ctx, teamID, *matches)
+		if err != nil {
+			fmt.Printf("ERROR fetching series for team %s: %v\n", teamID, err)
+			continue
+		}
+		for _, s := range series {
+			if !seen[s.ID] {
+				seen[s.ID] = true
+				allSeries = append(allSeries, s)
+			}
+		}
+	}
+
+	seriesIDs := make([]string, len(allSeries))
+	for i, s := range allSeries {
+		seriesIDs[i] = s.ID
+	}
+
+	fmt.Printf("Fetching %d series states...\n", len(seriesIDs))
+	states, err := client.GetSeriesStates(ctx, seriesIDs)
+	if err != nil {
+		fmt.Printf("ERROR fetching series states: %v\n", err)
+		os.Exit(1)
+	}
+
+	history := intelligence.BuildMatchResults(allSeries, states)
+	model := intelligence.NewWinProbabilityModel()
+	result := model.Backtest(history, *minPrior)
+
+	fmt.Println("=" + strings.Repeat("=", 59))
+	fmt.Println("WIN PROBABILITY BACKTEST")
+	fmt.Println("=" + strings.Repeat("=", 59))
+	fmt.Printf("Finished series:     %d\n", len(history))
+	fmt.Printf("Scored predictions:  %d\n", result.Predictions)
+	fmt.Printf("Brier score:         %.4f (baseline %.4f)\n", result.BrierScore, result.BaselineBrier)
+	fmt.Printf("Log loss:            %.4f\n", result.LogLoss)
+	fmt.Printf("Accuracy:            %.1f%%\n", result.Accuracy*100)
+
+	fmt.Println("\nCalibration:")
+	for _, b := range result.Calibration {
+		fmt.Printf("  %.0f-%.0f%%: n=%-4d predicted %.1f%%  observed %.1f%%\n",
+			b.Lower*100, b.Upper*100, b.Predictions, b.MeanPredicted*100, b.ObservedRate*100)
+	}
+}
@@ -0,0 +1,133 @@
+// +build ignore
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"scout9/pkg/grid"
+	"scout9/pkg/intelligence"
+)
+
+// Site boundary calibration for VALORANT.
+// Downloads event files for recent series of the given teams, clusters plant
+// positions per map into site polygons, learns mid and lobby regions from kill
+// positions and writes the versioned geometry file loaded by the server.
+//
+// Usage: go run scripts/calibrate_sites.go -teams 79,1079 -series 20 -out data/site_geometry.json
+
+func main() {
+	teams := flag.String("teams", "", "comma-separated VALORANT team IDs whose series to learn from")
+	seriesCount := flag.Int("series", 20, "recent series per team")
+	out := flag.String("out", "data/site_geometry.json", "geometry file to write")
+	reportPath := flag.String("report", "", "optional path for the JSON accuracy report")
+	minPlants := flag.Int("min-plants", 20, "plants a map needs before its sites are learned")
+	flag.Parse()
+
+	apiKey := os.Getenv("GRID_API_KEY")
+	if apiKey == "" {
+		fmt.Println("ERROR: GRID_API_KEY environment variable not set")
+		os.Exit(1)
+	}
+	if *teams == "" {
+		fmt.Println("ERROR: -teams is required")
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	client := grid.NewClient(apiKey, nil)
+
+	// Unique series across all teams
+	seen := make(map[string]bool)
+	var seriesIDs []string
+	for _, teamID := range strings.Split(*teams, ",") {
+		teamID = strings.TrimSpace(teamID)
+		if teamID == "" {
+			continue
+		}
+		series, err := client.GetSeriesForTeam(ctx, teamID, *seriesCount)
+		if err != nil {
+			fmt.Printf("WARNING: series for team %s: %v\n", teamID, err)
+			continue
+		}
+		for _, s := range series {
+			if !seen[s.ID] {
+				seen[s.ID] = true
+				seriesIDs = append(seriesIDs, s.ID)
+			}
+		}
+	}
+
+	events := make(map[string]*grid.VALEventData)
+	for _, seriesID := range seriesIDs {
+		wrappers, err := client.DownloadEvents(ctx, seriesID)
+		if err != nil {
+			fmt.Printf("WARNING: events for series %s: %v\n", seriesID, err)
+			continue
+		}
+		parsed, err := client.ParseVALEvents(wrappers)
+		if err != nil {
+			fmt.Printf("WARNING: parse series %s: %v\n", seriesID, err)
+			continue
+		}
+		events[seriesID] = parsed
+	}
+	fmt.Printf("Loaded events for %d of %d series\n\n", len(events), len(seriesIDs))
+
+	// Maps without enough new data keep their previous geometry
+	previous, err := grid.LoadSiteGeometry(*out)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Printf("WARNING: previous geometry ignored: %v\n", err)
+	}
+
+	calibrator := intelligence.NewSiteCalibrator()
+	calibrator.MinPlants = *minPlants
+	geometry, report := calibrator.Calibrate(events, previous)
+
+	if err := grid.SaveSiteGeometry(*out, geometry); err != nil {
+		fmt.Printf("ERROR: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("=" + strings.Repeat("=", 79))
+	fmt.Printf("SITE CALIBRATION: revision %d -> %s\n", geometry.Revision, *out)
+	fmt.Println("=" + strings.Repeat("=", 79))
+	fmt.Printf("%-10s %6s %6s %6s %9s %8s %8s %8s %8s %8s\n",
+		"MAP", "PLANTS", "KILLS", "SILH", "COVERAGE", "HOLDOUT", "VS GRID", "VS ELL", "LEGACY", "REGIONS")
+	for _, m := range report.Maps {
+		if !m.Learned {
+			fmt.Printf("%-10s %6d %6d  skipped: %s\n", m.MapName, m.Plants, m.Kills, m.Skipped)
+			continue
+		}
+		regions := len(m.Sites)
+		if m.MidSamples > 0 {
+			regions++
+		}
+		if g := geometry.ForMap(m.MapName); g != nil {
+			regions += len(g.Lobbies)
+		}
+		fmt.Printf("%-10s %6d %6d %6.2f %8.0f%% %7.0f%% %7.0f%% %7.0f%% %7.0f%% %8d\n",
+			m.MapName, m.Plants, m.Kills, m.Silhouette, m.Coverage*100, m.HoldoutAccuracy*100,
+			m.GridAgreement*100, m.EllipseAgreement*100, m.LegacyAgreement*100, regions)
+	}
+
+	if *reportPath != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err == nil {
+			err = os.WriteFile(*reportPath, data, 0644)
+		}
+		if err != nil {
+			fmt.Printf("ERROR: write report: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
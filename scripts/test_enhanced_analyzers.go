@@ -94,6 +94,17 @@ func testLoLAnalysis(ctx context.Context, client *grid.Client, seriesID string)
 	fmt.Printf("First Tower Lane: %s\n", objectiveTimings.FirstTowerLane)
 	fmt.Printf("Herald Usage Pattern: %s\n", objectiveTimings.HeraldUsagePattern)
 
+	// Test Lane Pattern Analysis
+	fmt.Println("\n=== LANE PATTERN ANALYSIS ===")
+	lanePatterns := timingAnalyzer.AnalyzeLanePatterns(teamID, seriesStates, events)
+	fmt.Printf("Mid Roam Rate: %.1f%% (first at %.1f min)\n", lanePatterns.MidRoamRate*100, lanePatterns.MidFirstRoamTime)
+	fmt.Printf("Support Roam Rate: %.1f%% (first at %.1f min)\n", lanePatterns.SupportRoamRate*100, lanePatterns.SupportFirstRoamTime)
+	for _, roam := range lanePatterns.Roams {
+		fmt.Printf("  %s -> %s: %d roams in %d games\n", roam.Role, roam.Destination, roam.Roams, roam.Games)
+	}
+	fmt.Printf("Lane Swap Rate: %.1f%%\n", lanePatterns.LaneSwapRate*100)
+	fmt.Printf("First Group: %.1f min at %s\n", lanePatterns.FirstGroupTime, lanePatterns.GroupLocation)
+
 	// Test Timing Insights Generation
 	fmt.Println("\n=== TIMING INSIGHTS (HACKATHON FORMAT) ===")
 	insights := timingAnalyzer.GenerateTimingInsights(junglePathing, objectiveTimings)
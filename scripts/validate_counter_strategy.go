@@ -423,11 +423,26 @@ func testEconomyAnalyzer(ctx context.Context, client *grid.Client) {
 		return
 	}
 
+	// Download JSONL event files for the buy of each round
+	fmt.Println("Downloading JSONL event files for economy analysis...")
+	valEvents := make(map[string]*grid.VALEventData)
+	for _, series := range seriesStates {
+		eventData, err := client.DownloadAndParseVALEvents(ctx, series.ID)
+		if err != nil {
+			fmt.Printf("  Warning: Could not download events for series %s: %v\n", series.ID, err)
+			continue
+		}
+		if eventData != nil && len(eventData.Economy) > 0 {
+			valEvents[series.ID] = eventData
+		}
+	}
+	fmt.Printf("Downloaded economy data for %d series\n", len(valEvents))
+
 	// Create economy analyzer
 	economyAnalyzer := intelligence.NewEconomyAnalyzer()
 
 	// Analyze economy rounds
-	analysis := economyAnalyzer.AnalyzeEconomyRounds(targetTeam.ID, targetTeam.Name, seriesStates)
+	analysis := economyAnalyzer.AnalyzeEconomyRounds(targetTeam.ID, targetTeam.Name, seriesStates, valEvents)
 
 	// Print results
 	fmt.Println("\n--- ECONOMY ANALYSIS ---")